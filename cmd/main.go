@@ -3,7 +3,6 @@ package main
 import (
 	"chessboard/internal/delivery/console"
 	"chessboard/internal/usecase"
-	"fmt"
 	"os"
 )
 
@@ -14,15 +13,11 @@ var (
 )
 
 func main() {
-	if len(os.Args) > 1 && os.Args[1] == "--version" {
-		fmt.Printf("chessboard version %s, commit %s, built %s\n", version, commit, date)
-		return
-	}
-
 	repo := usecase.NewBoardRepository()
 	service := usecase.NewBoardUsecase(repo)
 	handler := console.NewBoardHandler(service)
+	handler.SetBuildInfo(console.BuildInfo{Version: version, Commit: commit, Date: date})
 
-	// Обработка пользовательского ввода и отображение доски
-	handler.HandleUserInput()
+	// Выполнение команды из аргументов; код завершения сообщает об ошибке
+	os.Exit(handler.HandleUserInput())
 }
@@ -1,69 +1,376 @@
 package console
 
 import (
+	"bufio"
+	"flag"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
 	"strings"
 
 	"chessboard/internal/domain"
-	"chessboard/internal/usecase"
+	"chessboard/internal/i18n"
 )
 
 type BoardHandler struct {
-	boardService domain.BoardService
+	boardService  domain.BoardService
+	in            io.Reader
+	out           io.Writer
+	renderOptions domain.RenderOptions
+	setup         domain.Setup
+	buildInfo     BuildInfo
+	// strict включает строгий режим: см. SetStrict
+	strict bool
+	errOut io.Writer
+	// text - сообщения на выбранном языке: см. SetLanguage
+	text *i18n.Printer
 }
 
 func NewBoardHandler(service domain.BoardService) *BoardHandler {
-	return &BoardHandler{boardService: service}
+	return &BoardHandler{boardService: service, in: os.Stdin, out: os.Stdout, errOut: os.Stderr,
+		setup: domain.SetupEmpty, text: i18n.NewPrinter(i18n.Default)}
 }
 
-func (h *BoardHandler) CreateAndDisplayBoard(size int) {
-	board := h.boardService.CreateBoard(size)
-	chessboard := usecase.GenerateChessboard(board)
+// SetInput задает поток, из которого интерактивный режим читает команды
+func (h *BoardHandler) SetInput(r io.Reader) {
+	h.in = r
+}
 
-	fmt.Printf("Шахматная доска %dx%d:\n", board.Size, board.Size)
-	fmt.Println(chessboard)
+// SetOutput задает поток, в который выводится доска и сообщения
+func (h *BoardHandler) SetOutput(w io.Writer) {
+	h.out = w
 }
 
-func (h *BoardHandler) HandleUserInput() {
-	if len(os.Args) > 1 {
-		input := os.Args[1]
-		size, err := h.parseBoardSizeStrict(input)
-		if err != nil {
-			fmt.Printf("Ошибка: %s. Используется размер по умолчанию %dx%d.\n",
-				err.Error(), domain.DefaultBoardSize, domain.DefaultBoardSize)
-			size = domain.DefaultBoardSize
+// CreateAndDisplayBoard выводит новую доску и возвращает код завершения
+func (h *BoardHandler) CreateAndDisplayBoard(width, height int) int {
+	board, err := h.boardService.CreateBoard(width, height, h.setup)
+	if err != nil {
+		return h.failErr(err, ExitSize)
+	}
+	return h.displayBoard(board)
+}
+
+// LoadAndDisplayFEN выводит позицию, заданную в нотации FEN, и возвращает код завершения
+func (h *BoardHandler) LoadAndDisplayFEN(fen string) int {
+	board, err := h.boardService.LoadFEN(fen)
+	if err != nil {
+		return h.failErr(err, ExitFEN)
+	}
+	return h.displayBoard(board)
+}
+
+// displayBoard выводит доску с заголовком в выбранном формате
+func (h *BoardHandler) displayBoard(board *domain.Board) int {
+	return h.display(board, nil)
+}
+
+// displayGame выводит текущую позицию партии. В отличие от displayBoard,
+// состояние учитывает историю партии, в том числе повторения позиции.
+func (h *BoardHandler) displayGame(game domain.Game) int {
+	return h.display(game.Board(), game)
+}
+
+// display выводит доску и, для текстового формата, состояние партии game
+// или, если партии нет, состояние позиции на доске. Если доску не удалось
+// отрисовать или позиция невозможна, возвращает код по классу ошибки
+// (см. errorCode) или ExitError.
+func (h *BoardHandler) display(board *domain.Board, game domain.Game) int {
+	// Буферизуем вывод, чтобы большие доски не писались по одной строке в системный вызов
+	out := bufio.NewWriter(h.out)
+	defer out.Flush()
+
+	if h.isTextFormat() {
+		h.text.Fprintf(out, "board.header", board.Width, board.Height)
+	}
+	if err := h.boardService.Render(out, board, h.renderOptions); err != nil {
+		out.Flush()
+		if h.isTextFormat() {
+			// Сообщение начинается с новой строки, даже если доска выведена
+			// не полностью; в svg, png и json лишний перевод строки не пишется
+			fmt.Fprintln(h.out)
+		}
+		// Неизвестные тема и палитра обнаруживаются только при отрисовке
+		report := h.newMachineError(err, ExitError)
+		report.Message = h.text.Sprintf("board.render_error", report.Message)
+		return h.report(report)
+	}
+	if h.isTextFormat() {
+		fmt.Fprintln(out)
+		if err := h.displayStatus(out, board, game); err != nil {
+			out.Flush()
+			// Невозможная позиция обнаруживается только при определении состояния
+			report := h.newMachineError(err, ExitError)
+			report.Message = h.text.Sprintf("board.status_error", report.Message)
+			return h.report(report)
+		}
+	}
+	return ExitOK
+}
+
+// displayStatus выводит под доской строку с состоянием партии. Для доски
+// без фигур состояние не определено, и строка не выводится. Возвращает ошибку,
+// если состояние определить не удалось.
+func (h *BoardHandler) displayStatus(out io.Writer, board *domain.Board, game domain.Game) error {
+	if !board.HasPieces() {
+		return nil
+	}
+	if game != nil {
+		h.text.Fprintf(out, "board.status", statusLine(h.text, game.Status()))
+		return nil
+	}
+	status, err := h.boardService.Status(board)
+	if err != nil {
+		return err
+	}
+	h.text.Fprintf(out, "board.status", statusLine(h.text, status))
+	return nil
+}
+
+// sideNames - названия сторон в родительном и именительном падежах
+var sideNames = [2]struct{ genitive, nominative i18n.Key }{
+	{"side.white.genitive", "side.white.nominative"},
+	{"side.black.genitive", "side.black.nominative"},
+}
+
+// statusLine описывает состояние партии одной фразой
+func statusLine(text *i18n.Printer, status domain.GameStatus) string {
+	switch {
+	case status.Checkmate:
+		winner, _ := status.Winner()
+		return text.Sprintf("status.checkmate", sideNames[winner].nominative, status.Result)
+	case status.Stalemate:
+		return text.Sprintf("status.stalemate", status.Result)
+	case status.FivefoldRepetition:
+		return text.Sprintf("status.fivefold_repetition", status.Result)
+	case status.SeventyFiveMoves:
+		return text.Sprintf("status.seventy_five_moves", status.Result)
+	case status.InsufficientMaterial:
+		return text.Sprintf("status.insufficient_material", status.Result)
+	}
+
+	line := text.Sprintf("status.to_move", sideNames[status.SideToMove].genitive)
+	if status.InCheck {
+		line = text.Sprintf("status.check", sideNames[status.SideToMove].genitive)
+	}
+	switch {
+	case status.ThreefoldRepetition:
+		line += text.Sprintf("status.threefold_repetition")
+	case status.FiftyMoves:
+		line += text.Sprintf("status.fifty_moves")
+	}
+	return line
+}
+
+// isTextFormat сообщает, выводится ли доска как текст. Для графических форматов
+// и json пояснительные сообщения не печатаются, чтобы вывод оставался корректным файлом.
+func (h *BoardHandler) isTextFormat() bool {
+	return h.renderOptions.Format == "" || h.renderOptions.Format == domain.FormatText
+}
+
+// runRender выполняет команду render: выводит доску заданного размера или
+// позицию из флага --fen
+func (h *BoardHandler) runRender(args []string) int {
+	opts, err := parseArgs(args)
+	if err != nil {
+		return h.usageError("render", err)
+	}
+	if code := h.applyViewOptions(opts); code != ExitOK {
+		return code
+	}
+
+	if opts.pattern != "" {
+		if err := h.boardService.SetPattern(opts.pattern); err != nil {
+			return h.failErr(err, ExitUsage)
+		}
+	}
+	if opts.setup != "" {
+		if h.setup, err = domain.ParseSetup(opts.setup); err != nil {
+			return h.failErr(err, ExitUsage)
 		}
-		h.CreateAndDisplayBoard(size)
-	} else {
-		fmt.Printf("Используется размер по умолчанию %dx%d\n",
-			domain.DefaultBoardSize, domain.DefaultBoardSize)
-		h.CreateAndDisplayBoard(domain.DefaultBoardSize)
 	}
+
+	if opts.fen != "" {
+		// Размер и фигуры задаются самой позицией, поэтому их нельзя указать отдельно
+		if opts.size != "" || opts.setup != "" {
+			return h.usageError("render", i18n.New("render.fen_conflict"))
+		}
+		return h.LoadAndDisplayFEN(opts.fen)
+	}
+
+	if opts.size == "" {
+		if h.isTextFormat() {
+			h.text.Fprintf(h.out, "render.default_size", domain.DefaultBoardSize, domain.DefaultBoardSize)
+		}
+		return h.CreateAndDisplayBoard(domain.DefaultBoardSize, domain.DefaultBoardSize)
+	}
+
+	// В строгом режиме ошибка прерывает команду. В обычном режиме вместо неверного
+	// размера выводится доска по умолчанию, а вместо невозможной расстановки - пустая
+	// доска; об этом сообщается, и код завершения все равно указывает на ошибку.
+	code := ExitOK
+	width, height, err := h.parseBoardSizeStrict(opts.size)
+	if err != nil {
+		if h.strict {
+			// Размер, который не удалось разобрать как число, - тоже ошибка размера
+			report := h.newMachineError(err, ExitSize)
+			report.Code = ExitSize
+			return h.report(report)
+		}
+		h.text.Fprintf(h.out, "render.size_fallback", err, domain.DefaultBoardSize, domain.DefaultBoardSize)
+		width, height, code = domain.DefaultBoardSize, domain.DefaultBoardSize, ExitSize
+	}
+	if err := h.setup.Validate(width, height); err != nil && !h.strict {
+		h.text.Fprintf(h.out, "render.setup_fallback", err)
+		h.setup, code = domain.SetupEmpty, ExitSize
+	}
+	if result := h.CreateAndDisplayBoard(width, height); result != ExitOK {
+		return result
+	}
+	return code
 }
 
-// parseBoardSizeStrict парсит и валидирует размер доски со строгой проверкой
-func (h *BoardHandler) parseBoardSizeStrict(input string) (int, error) {
-	input = strings.TrimSpace(input)
+// runFEN выполняет команду fen: выводит позицию, заданную в нотации FEN. Поля
+// FEN можно передать одним аргументом в кавычках или отдельными аргументами.
+func (h *BoardHandler) runFEN(args []string) int {
+	var opts cliOptions
+	positional, err := parseFlagSet(fenFlags(&opts), args)
+	if err != nil {
+		return h.usageError("fen", err)
+	}
+	if len(positional) == 0 {
+		return h.usageError("fen", i18n.New("fen.position_required"))
+	}
+	if code := h.applyViewOptions(opts); code != ExitOK {
+		return code
+	}
+	return h.LoadAndDisplayFEN(strings.Join(positional, " "))
+}
+
+// applyViewOptions переносит флаги вида доски в параметры отрисовки. При неверном
+// значении выводит ошибку и возвращает ExitUsage.
+func (h *BoardHandler) applyViewOptions(opts cliOptions) int {
+	h.renderOptions.Coordinates = opts.coords
+	if opts.orientation != "" {
+		orientation, err := domain.ParseOrientation(opts.orientation)
+		if err != nil {
+			return h.failErr(err, ExitUsage)
+		}
+		h.renderOptions.Orientation = orientation
+	}
+
+	h.renderOptions.Theme = resolveTheme(opts.theme, h.out)
+	h.renderOptions.Palette = opts.palette
+	h.renderOptions.LightColor = opts.light
+	h.renderOptions.DarkColor = opts.dark
 
-	if strings.HasPrefix(input, "-") {
-		return 0, fmt.Errorf("отрицательные числа не поддерживаются: '%s'", input)
+	if opts.format != "" {
+		format, err := domain.ParseFormat(opts.format)
+		if err != nil {
+			return h.failErr(err, ExitUsage)
+		}
+		h.renderOptions.Format = format
+	}
+	if opts.pieces != "" {
+		style, err := domain.ParsePieceStyle(opts.pieces)
+		if err != nil {
+			return h.failErr(err, ExitUsage)
+		}
+		h.renderOptions.Pieces = style
 	}
 
-	if strings.Contains(input, ".") || strings.Contains(input, ",") {
-		return 0, fmt.Errorf("дробные числа не поддерживаются: '%s'", input)
+	var err error
+	if h.renderOptions.SquareSize, err = parseOptionalInt(opts.squareSize); err != nil {
+		return h.fail(ExitUsage, "render.square_size", err)
+	}
+	if h.renderOptions.Border, err = parseOptionalInt(opts.border); err != nil {
+		return h.fail(ExitUsage, "render.border", err)
 	}
+	return ExitOK
+}
+
+// cliOptions содержит разобранные аргументы командной строки
+type cliOptions struct {
+	size        string
+	pattern     string
+	orientation string
+	theme       string
+	palette     string
+	light       string
+	dark        string
+	format      string
+	squareSize  string
+	border      string
+	pieces      string
+	setup       string
+	fen         string
+	coords      bool
+}
+
+// renderFlags создает набор флагов команды render
+func renderFlags(opts *cliOptions) *flag.FlagSet {
+	fs := newFlagSet("render")
+	addViewFlags(fs, opts)
+	fs.StringVar(&opts.pattern, "pattern", "", "flag.pattern")
+	fs.StringVar(&opts.setup, "setup", "", "flag.setup")
+	fs.StringVar(&opts.fen, "fen", "", "flag.render_fen")
+	return fs
+}
 
-	size, err := strconv.Atoi(input)
+// fenFlags создает набор флагов команды fen
+func fenFlags(opts *cliOptions) *flag.FlagSet {
+	fs := newFlagSet("fen")
+	addViewFlags(fs, opts)
+	return fs
+}
+
+// addViewFlags добавляет флаги вида доски, общие для команд render и fen
+func addViewFlags(fs *flag.FlagSet, opts *cliOptions) {
+	fs.StringVar(&opts.orientation, "orientation", "", "flag.orientation")
+	fs.StringVar(&opts.theme, "theme", "", "flag.theme")
+	fs.StringVar(&opts.palette, "palette", "", "flag.palette")
+	fs.StringVar(&opts.light, "light", "", "flag.light")
+	fs.StringVar(&opts.dark, "dark", "", "flag.dark")
+	fs.StringVar(&opts.format, "format", "", "flag.format")
+	fs.StringVar(&opts.squareSize, "square-size", "", "flag.square_size")
+	fs.StringVar(&opts.border, "border", "", "flag.border")
+	fs.StringVar(&opts.pieces, "pieces", "", "flag.pieces")
+	fs.BoolVar(&opts.coords, "coords", false, "flag.coords")
+}
+
+// parseArgs разбирает аргументы команды render: флаги и позиционный размер доски
+func parseArgs(args []string) (cliOptions, error) {
+	var opts cliOptions
+	positional, err := parseFlagSet(renderFlags(&opts), args)
 	if err != nil {
-		return 0, fmt.Errorf("неверный формат числа: '%s'", input)
+		return cliOptions{}, err
+	}
+	if len(positional) > 1 {
+		return cliOptions{}, i18n.Errorf("cli.extra_argument", positional[1])
+	}
+	if len(positional) == 1 {
+		opts.size = positional[0]
 	}
+	return opts, nil
+}
 
-	// Валидируем размер через сервис
-	if err := h.boardService.ValidateSize(size); err != nil {
-		return 0, err
+// parseBoardSizeStrict разбирает размер доски "N" или "WxH" и проверяет его
+// пределы через сервис. Ошибка - *domain.NumberError, если измерение не число,
+// или ошибка ValidateSize, если размер вне пределов.
+func (h *BoardHandler) parseBoardSizeStrict(input string) (int, int, error) {
+	width, height, err := domain.ParseSize(input)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := h.boardService.ValidateSize(width, height); err != nil {
+		return 0, 0, err
 	}
+	return width, height, nil
+}
 
-	return size, nil
+// parseOptionalInt парсит необязательное неотрицательное целое; пустая строка дает 0
+func parseOptionalInt(input string) (int, error) {
+	if input == "" {
+		return 0, nil
+	}
+	return domain.ParseNumber(input)
 }
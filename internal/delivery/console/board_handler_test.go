@@ -1,22 +1,80 @@
 package console
 
 import (
+	"bytes"
 	"errors"
+	"io"
+	"reflect"
+	"strings"
 	"testing"
 
 	"chessboard/internal/domain"
+	"chessboard/internal/usecase"
 )
 
 // MockBoardService для тестирования
 type MockBoardService struct {
 	validateError error
+	pattern       string
+	setup         domain.Setup
+	fen           string
+	depth         int
+	status        domain.GameStatus
+	statusError   error
 }
 
-func (m *MockBoardService) CreateBoard(size int) *domain.Board {
-	return &domain.Board{Size: size}
+func (m *MockBoardService) CreateBoard(width, height int, setup domain.Setup) (*domain.Board, error) {
+	m.setup = setup
+	if err := setup.Validate(width, height); err != nil {
+		return nil, err
+	}
+	board := &domain.Board{Width: width, Height: height}
+	if setup == domain.SetupStandard {
+		board.PlaceStandardPieces()
+	}
+	return board, nil
+}
+
+func (m *MockBoardService) LoadFEN(fen string) (*domain.Board, error) {
+	m.fen = fen
+	if m.validateError != nil {
+		return nil, m.validateError
+	}
+	return domain.ParseFEN(fen)
+}
+
+func (m *MockBoardService) LegalMoves(board *domain.Board) ([]domain.Move, error) {
+	return nil, nil
+}
+
+func (m *MockBoardService) Perft(board *domain.Board, depth int) (domain.PerftResult, error) {
+	m.depth = depth
+	if depth > 5 {
+		return domain.PerftResult{}, errors.New("глубина perft должна быть от 1 до 5")
+	}
+	return domain.PerftResult{
+		Depth: depth,
+		Nodes: 3,
+		Divide: []domain.MoveCount{
+			{Move: domain.Move{From: domain.Square{File: 0, Rank: 1}, To: domain.Square{File: 0, Rank: 2}}, Nodes: 1},
+			{Move: domain.Move{From: domain.Square{File: 4, Rank: 1}, To: domain.Square{File: 4, Rank: 3}}, Nodes: 2},
+		},
+	}, nil
+}
+
+func (m *MockBoardService) Status(board *domain.Board) (domain.GameStatus, error) {
+	return m.status, m.statusError
 }
 
-func (m *MockBoardService) ValidateSize(size int) error {
+func (m *MockBoardService) NewGame(board *domain.Board) (domain.Game, error) {
+	return nil, errors.New("партии не поддерживаются")
+}
+
+func (m *MockBoardService) Hash(board *domain.Board) (uint64, error) {
+	return 0, nil
+}
+
+func (m *MockBoardService) ValidateSize(width, height int) error {
 	return m.validateError
 }
 
@@ -24,6 +82,23 @@ func (m *MockBoardService) GeneratePattern() string {
 	return ""
 }
 
+func (m *MockBoardService) Render(w io.Writer, board *domain.Board, opts domain.RenderOptions) error {
+	_, err := io.WriteString(w, "<доска>")
+	return err
+}
+
+func (m *MockBoardService) SetPattern(name string) error {
+	if name != "checker" && name != "rings" {
+		return &domain.UnknownValueError{Label: "unknown.pattern", Value: name, Allowed: m.Patterns()}
+	}
+	m.pattern = name
+	return nil
+}
+
+func (m *MockBoardService) Patterns() []string {
+	return []string{"checker", "rings"}
+}
+
 func TestParseBoardSizeStrict(t *testing.T) {
 	// Создаем мок сервиса без ошибок валидации
 	mockService := &MockBoardService{}
@@ -33,6 +108,7 @@ func TestParseBoardSizeStrict(t *testing.T) {
 		name        string
 		input       string
 		expected    int
+		expectedH   int
 		expectError bool
 		errorMsg    string
 	}{
@@ -68,6 +144,35 @@ func TestParseBoardSizeStrict(t *testing.T) {
 			expectError: false,
 		},
 
+		{
+			name:        "прямоугольная доска",
+			input:       "10x8",
+			expected:    10,
+			expectedH:   8,
+			expectError: false,
+		},
+		{
+			name:        "прямоугольная доска с заглавной X",
+			input:       "9X10",
+			expected:    9,
+			expectedH:   10,
+			expectError: false,
+		},
+		{
+			name:        "прямоугольная доска со знаком умножения",
+			input:       "12×6",
+			expected:    12,
+			expectedH:   6,
+			expectError: false,
+		},
+		{
+			name:        "прямоугольная доска с пробелами",
+			input:       " 10 x 8 ",
+			expected:    10,
+			expectedH:   8,
+			expectError: false,
+		},
+
 		// Невалидные случаи - парсинг
 		{
 			name:        "отрицательное число",
@@ -118,6 +223,30 @@ func TestParseBoardSizeStrict(t *testing.T) {
 			expectError: true,
 			errorMsg:    "неверный формат числа",
 		},
+		{
+			name:        "отрицательная высота",
+			input:       "8x-8",
+			expectError: true,
+			errorMsg:    "отрицательные числа не поддерживаются",
+		},
+		{
+			name:        "дробная ширина",
+			input:       "8.5x8",
+			expectError: true,
+			errorMsg:    "дробные числа не поддерживаются",
+		},
+		{
+			name:        "пропущена высота",
+			input:       "8x",
+			expectError: true,
+			errorMsg:    "неверный формат числа",
+		},
+		{
+			name:        "три измерения",
+			input:       "8x8x8",
+			expectError: true,
+			errorMsg:    "неверный формат числа",
+		},
 		{
 			name:        "специальные символы",
 			input:       "!@#",
@@ -129,7 +258,7 @@ func TestParseBoardSizeStrict(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result, err := handler.parseBoardSizeStrict(tc.input)
+			width, height, err := handler.parseBoardSizeStrict(tc.input)
 
 			if tc.expectError {
 				if err == nil {
@@ -144,9 +273,13 @@ func TestParseBoardSizeStrict(t *testing.T) {
 				if err != nil {
 					t.Errorf("неожиданная ошибка для ввода '%s': %v", tc.input, err)
 				}
-				if result != tc.expected {
-					t.Errorf("для ввода '%s' ожидался размер %d, получен %d",
-						tc.input, tc.expected, result)
+				expectedH := tc.expectedH
+				if expectedH == 0 {
+					expectedH = tc.expected
+				}
+				if width != tc.expected || height != expectedH {
+					t.Errorf("для ввода '%s' ожидался размер %dx%d, получен %dx%d",
+						tc.input, tc.expected, expectedH, width, height)
 				}
 			}
 		})
@@ -174,14 +307,14 @@ func TestParseBoardSizeStrict_WithValidationError(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result, err := handler.parseBoardSizeStrict(tc.input)
+			width, height, err := handler.parseBoardSizeStrict(tc.input)
 
 			if err == nil {
 				t.Errorf("ожидалась ошибка валидации для ввода '%s', но ошибки нет", tc.input)
 			}
 
-			if result != 0 {
-				t.Errorf("при ошибке валидации ожидался результат 0, получен %d", result)
+			if width != 0 || height != 0 {
+				t.Errorf("при ошибке валидации ожидался результат 0x0, получен %dx%d", width, height)
 			}
 
 			if err != nil && !contains(err.Error(), tc.errorMsg) {
@@ -197,12 +330,14 @@ func TestCreateAndDisplayBoard(t *testing.T) {
 
 	// Этот тест проверяет, что функция не паникует при разных размерах
 	testCases := []struct {
-		name string
-		size int
+		name   string
+		width  int
+		height int
 	}{
-		{"маленькая доска", 2},
-		{"стандартная доска", 8},
-		{"большая доска", 20},
+		{"маленькая доска", 2, 2},
+		{"стандартная доска", 8, 8},
+		{"большая доска", 20, 20},
+		{"прямоугольная доска", 10, 8},
 	}
 
 	for _, tc := range testCases {
@@ -210,11 +345,462 @@ func TestCreateAndDisplayBoard(t *testing.T) {
 			// Просто проверяем, что функция выполняется без паники
 			defer func() {
 				if r := recover(); r != nil {
-					t.Errorf("функция CreateAndDisplayBoard запаниковала с размером %d: %v", tc.size, r)
+					t.Errorf("функция CreateAndDisplayBoard запаниковала с размером %dx%d: %v", tc.width, tc.height, r)
 				}
 			}()
 
-			handler.CreateAndDisplayBoard(tc.size)
+			handler.CreateAndDisplayBoard(tc.width, tc.height)
+		})
+	}
+}
+
+func TestCreateAndDisplayBoard_Status(t *testing.T) {
+	testCases := []struct {
+		name     string
+		service  *MockBoardService
+		setup    domain.Setup
+		expected string
+	}{
+		{
+			name:     "пустая доска без статуса",
+			service:  &MockBoardService{},
+			setup:    domain.SetupEmpty,
+			expected: "Шахматная доска 8x8:\n<доска>\n",
+		},
+		{
+			name:     "шах",
+			service:  &MockBoardService{status: domain.GameStatus{SideToMove: domain.Black, InCheck: true}},
+			setup:    domain.SetupStandard,
+			expected: "Шахматная доска 8x8:\n<доска>\nСтатус: шах. Ход черных.\n",
+		},
+		{
+			name: "мат",
+			service: &MockBoardService{status: domain.GameStatus{
+				SideToMove: domain.Black, InCheck: true, Checkmate: true, Result: domain.ResultWhiteWins,
+			}},
+			setup:    domain.SetupStandard,
+			expected: "Шахматная доска 8x8:\n<доска>\nСтатус: мат. Победили белые (1-0).\n",
+		},
+		{
+			name:     "пат",
+			service:  &MockBoardService{status: domain.GameStatus{Stalemate: true, Result: domain.ResultDraw}},
+			setup:    domain.SetupStandard,
+			expected: "Шахматная доска 8x8:\n<доска>\nСтатус: пат. Ничья (1/2-1/2).\n",
+		},
+		{
+			name:     "недостаточно материала",
+			service:  &MockBoardService{status: domain.GameStatus{InsufficientMaterial: true, Result: domain.ResultDraw}},
+			setup:    domain.SetupStandard,
+			expected: "Шахматная доска 8x8:\n<доска>\nСтатус: недостаточно материала для мата. Ничья (1/2-1/2).\n",
+		},
+		{
+			name:     "правило 75 ходов",
+			service:  &MockBoardService{status: domain.GameStatus{FiftyMoves: true, SeventyFiveMoves: true, Result: domain.ResultDraw}},
+			setup:    domain.SetupStandard,
+			expected: "Шахматная доска 8x8:\n<доска>\nСтатус: 75 ходов без взятий и ходов пешками. Ничья (1/2-1/2).\n",
+		},
+		{
+			name:     "пятикратное повторение",
+			service:  &MockBoardService{status: domain.GameStatus{FivefoldRepetition: true, Result: domain.ResultDraw}},
+			setup:    domain.SetupStandard,
+			expected: "Шахматная доска 8x8:\n<доска>\nСтатус: позиция повторилась пять раз. Ничья (1/2-1/2).\n",
+		},
+		{
+			name:     "ничья по требованию после 50 ходов",
+			service:  &MockBoardService{status: domain.GameStatus{FiftyMoves: true}},
+			setup:    domain.SetupStandard,
+			expected: "Шахматная доска 8x8:\n<доска>\nСтатус: ход белых. Можно потребовать ничью по правилу 50 ходов.\n",
+		},
+		{
+			name:     "шах при троекратном повторении",
+			service:  &MockBoardService{status: domain.GameStatus{SideToMove: domain.Black, InCheck: true, ThreefoldRepetition: true}},
+			setup:    domain.SetupStandard,
+			expected: "Шахматная доска 8x8:\n<доска>\nСтатус: шах. Ход черных. Можно потребовать ничью: позиция повторилась трижды.\n",
+		},
+		{
+			name:     "ошибка определения статуса",
+			service:  &MockBoardService{statusError: errors.New("король черных под шахом, хотя ход белых")},
+			setup:    domain.SetupStandard,
+			expected: "Шахматная доска 8x8:\n<доска>\nОшибка: ошибка определения статуса: король черных под шахом, хотя ход белых.\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewBoardHandler(tc.service)
+			handler.setup = tc.setup
+			var buf bytes.Buffer
+			handler.SetOutput(&buf)
+
+			handler.CreateAndDisplayBoard(8, 8)
+
+			if buf.String() != tc.expected {
+				t.Errorf("ожидался вывод %q, получен %q", tc.expected, buf.String())
+			}
+		})
+	}
+
+	t.Run("графический формат без статуса", func(t *testing.T) {
+		handler := NewBoardHandler(&MockBoardService{})
+		handler.setup = domain.SetupStandard
+		handler.renderOptions.Format = domain.FormatSVG
+		var buf bytes.Buffer
+		handler.SetOutput(&buf)
+
+		handler.CreateAndDisplayBoard(8, 8)
+
+		if strings.Contains(buf.String(), "Статус") {
+			t.Errorf("статус не должен попадать в графический вывод, получено: '%s'", buf.String())
+		}
+	})
+}
+
+func TestParseArgs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		args        []string
+		expected    cliOptions
+		expectError bool
+	}{
+		{"без аргументов", nil, cliOptions{}, false},
+		{"только размер", []string{"8"}, cliOptions{size: "8"}, false},
+		{"отрицательный размер", []string{"-5"}, cliOptions{size: "-5"}, false},
+		{"узор через пробел", []string{"--pattern", "rings", "10"}, cliOptions{size: "10", pattern: "rings"}, false},
+		{"узор через равно", []string{"12", "--pattern=stripes"}, cliOptions{size: "12", pattern: "stripes"}, false},
+		{"узор с одним дефисом", []string{"-pattern", "diagonal"}, cliOptions{pattern: "diagonal"}, false},
+		{"координаты", []string{"--coords", "8"}, cliOptions{size: "8", coords: true}, false},
+		{"координаты выключены явно", []string{"--coords=false"}, cliOptions{}, false},
+		{"сторона черных", []string{"--orientation", "black", "--coords"}, cliOptions{orientation: "black", coords: true}, false},
+		{"тема и цвета", []string{"--theme=ansi", "--palette", "blue", "--light", "#fff", "--dark=#000"},
+			cliOptions{theme: "ansi", palette: "blue", light: "#fff", dark: "#000"}, false},
+		{"формат svg", []string{"--format", "svg", "--square-size=32", "--border", "8"},
+			cliOptions{format: "svg", squareSize: "32", border: "8"}, false},
+		{"расстановка и фигуры", []string{"--setup", "standard", "--pieces=unicode"},
+			cliOptions{setup: "standard", pieces: "unicode"}, false},
+		{"позиция FEN", []string{"--fen", domain.StartFEN, "--coords"},
+			cliOptions{fen: domain.StartFEN, coords: true}, false},
+		{"флаг без значения", []string{"--pattern"}, cliOptions{}, true},
+		{"неверное булево значение", []string{"--coords=maybe"}, cliOptions{}, true},
+		{"неизвестный флаг", []string{"--colour"}, cliOptions{}, true},
+		{"лишний аргумент", []string{"8", "9"}, cliOptions{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts, err := parseArgs(tc.args)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("ожидалась ошибка для аргументов %v, но ошибки нет", tc.args)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("неожиданная ошибка для аргументов %v: %v", tc.args, err)
+			}
+			if opts != tc.expected {
+				t.Errorf("для аргументов %v ожидалось %+v, получено %+v", tc.args, tc.expected, opts)
+			}
+		})
+	}
+}
+
+func TestHandleArgs_Pattern(t *testing.T) {
+	t.Run("известный узор передается в сервис", func(t *testing.T) {
+		mockService := &MockBoardService{}
+		handler := NewBoardHandler(mockService)
+
+		handler.HandleArgs([]string{"--pattern", "rings", "8"})
+
+		if mockService.pattern != "rings" {
+			t.Errorf("ожидался узор 'rings', получен '%s'", mockService.pattern)
+		}
+	})
+
+	t.Run("неизвестный узор не меняет выбор", func(t *testing.T) {
+		mockService := &MockBoardService{}
+		handler := NewBoardHandler(mockService)
+
+		handler.HandleArgs([]string{"--pattern", "zigzag"})
+
+		if mockService.pattern != "" {
+			t.Errorf("узор не должен был измениться, получен '%s'", mockService.pattern)
+		}
+	})
+
+	t.Run("узор задается маской", func(t *testing.T) {
+		handler := NewBoardHandler(usecase.NewBoardUsecase(usecase.NewBoardRepository()))
+		var buf bytes.Buffer
+		handler.SetOutput(&buf)
+
+		code := handler.HandleArgs([]string{"--pattern", "mask:##../##../..##/..##", "4"})
+
+		expected := "Шахматная доска 4x4:\n##  \n##  \n  ##\n  ##\n"
+		if code != ExitOK || buf.String() != expected {
+			t.Errorf("ожидался код 0 и вывод %q, получено %d и %q", expected, code, buf.String())
+		}
+	})
+
+	t.Run("неверная маска - ошибка использования", func(t *testing.T) {
+		handler := NewBoardHandler(usecase.NewBoardUsecase(usecase.NewBoardRepository()))
+		handler.SetOutput(io.Discard)
+
+		if code := handler.HandleArgs([]string{"--pattern", "mask:#x", "4"}); code != ExitUsage {
+			t.Errorf("ожидался код %d, получено %d", ExitUsage, code)
+		}
+	})
+}
+
+func TestDisplay_RenderError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"текст", []string{"--theme", "ansi", "--palette", "sepia"}, "Шахматная доска 4x4:\n\nОшибка: ошибка отрисовки"},
+		{"svg", []string{"--format", "svg", "--palette", "sepia"}, "Ошибка: ошибка отрисовки"},
+		{"png", []string{"--format", "png", "--palette", "sepia"}, "Ошибка: ошибка отрисовки"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewBoardHandler(usecase.NewBoardUsecase(usecase.NewBoardRepository()))
+			var buf bytes.Buffer
+			handler.SetOutput(&buf)
+
+			// Перевод строки перед сообщением нужен только незаконченной текстовой доске
+			code := handler.HandleArgs(append(tc.args, "4"))
+			if code != ExitUsage || !strings.HasPrefix(buf.String(), tc.expected) {
+				t.Errorf("ожидался код %d и вывод с %q, получено %d и %q", ExitUsage, tc.expected, code, buf.String())
+			}
+		})
+	}
+}
+
+func TestCreateAndDisplayBoard_Output(t *testing.T) {
+	mockService := &MockBoardService{}
+	handler := NewBoardHandler(mockService)
+
+	var buf bytes.Buffer
+	handler.SetOutput(&buf)
+	handler.CreateAndDisplayBoard(10, 8)
+
+	expected := "Шахматная доска 10x8:\n<доска>\n"
+	if buf.String() != expected {
+		t.Errorf("ожидался вывод %q, получен %q", expected, buf.String())
+	}
+}
+
+func TestHandleArgs_RenderOptions(t *testing.T) {
+	t.Run("координаты и сторона черных", func(t *testing.T) {
+		handler := NewBoardHandler(&MockBoardService{})
+		handler.SetOutput(io.Discard)
+
+		handler.HandleArgs([]string{"--coords", "--orientation", "b", "8"})
+
+		expected := domain.RenderOptions{Coordinates: true, Orientation: domain.OrientationBlack}
+		if !reflect.DeepEqual(handler.renderOptions, expected) {
+			t.Errorf("ожидались параметры %+v, получено %+v", expected, handler.renderOptions)
+		}
+	})
+
+	t.Run("тема auto при выводе не в терминал", func(t *testing.T) {
+		handler := NewBoardHandler(&MockBoardService{})
+		handler.SetOutput(io.Discard)
+
+		handler.HandleArgs([]string{"--theme", "auto", "--palette", "green", "--dark", "#333"})
+
+		expected := domain.RenderOptions{Theme: "ascii", Palette: "green", DarkColor: "#333"}
+		if !reflect.DeepEqual(handler.renderOptions, expected) {
+			t.Errorf("ожидались параметры %+v, получено %+v", expected, handler.renderOptions)
+		}
+	})
+
+	t.Run("неизвестная сторона", func(t *testing.T) {
+		handler := NewBoardHandler(&MockBoardService{})
+		var buf bytes.Buffer
+		handler.SetOutput(&buf)
+
+		handler.HandleArgs([]string{"--orientation", "red"})
+
+		if !strings.Contains(buf.String(), "неизвестная сторона") {
+			t.Errorf("ожидалось сообщение о неизвестной стороне, получено: '%s'", buf.String())
+		}
+	})
+}
+
+func TestHandleArgs_Format(t *testing.T) {
+	t.Run("svg выводится без пояснений", func(t *testing.T) {
+		handler := NewBoardHandler(&MockBoardService{})
+		var buf bytes.Buffer
+		handler.SetOutput(&buf)
+
+		handler.HandleArgs([]string{"--format", "svg", "--square-size", "32", "--border", "8"})
+
+		if buf.String() != "<доска>" {
+			t.Errorf("ожидался только вывод рендерера, получено %q", buf.String())
+		}
+		if handler.renderOptions.SquareSize != 32 || handler.renderOptions.Border != 8 {
+			t.Errorf("неверные параметры изображения: %+v", handler.renderOptions)
+		}
+	})
+
+	t.Run("json выводится без пояснений", func(t *testing.T) {
+		handler := NewBoardHandler(usecase.NewBoardUsecase(usecase.NewBoardRepository()))
+		var buf bytes.Buffer
+		handler.SetOutput(&buf)
+
+		code := handler.HandleArgs([]string{"render", "--format", "json", "--setup", "standard", "8"})
+
+		board, err := usecase.ReadJSON(&buf)
+		if code != ExitOK || err != nil {
+			t.Fatalf("ожидалось описание доски с кодом 0, получено %d: %v", code, err)
+		}
+		if board.FEN() != domain.StartFEN {
+			t.Errorf("ожидалась начальная позиция, получено %q", board.FEN())
+		}
+	})
+
+	errorCases := []struct {
+		name     string
+		args     []string
+		errorMsg string
+	}{
+		{"неизвестный формат", []string{"--format", "pdf"}, "неизвестный формат"},
+		{"нечисловой размер клетки", []string{"--square-size", "big"}, "неверный формат числа"},
+		{"отрицательная рамка", []string{"--border=-1"}, "отрицательные числа не поддерживаются"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewBoardHandler(&MockBoardService{})
+			var buf bytes.Buffer
+			handler.SetOutput(&buf)
+
+			handler.HandleArgs(tc.args)
+
+			if !strings.Contains(buf.String(), tc.errorMsg) {
+				t.Errorf("ожидалась ошибка с текстом '%s', получено: '%s'", tc.errorMsg, buf.String())
+			}
+		})
+	}
+}
+
+func TestHandleArgs_Setup(t *testing.T) {
+	t.Run("стандартная расстановка передается в сервис", func(t *testing.T) {
+		mockService := &MockBoardService{}
+		handler := NewBoardHandler(mockService)
+		handler.SetOutput(io.Discard)
+
+		handler.HandleArgs([]string{"--setup", "standard", "--pieces", "unicode", "8"})
+
+		if mockService.setup != domain.SetupStandard {
+			t.Errorf("ожидалась стандартная расстановка, получено '%s'", mockService.setup)
+		}
+		if handler.renderOptions.Pieces != domain.PieceStyleUnicode {
+			t.Errorf("ожидался стиль фигур unicode, получено '%s'", handler.renderOptions.Pieces)
+		}
+	})
+
+	t.Run("стандартная расстановка на доске 10x8", func(t *testing.T) {
+		mockService := &MockBoardService{}
+		handler := NewBoardHandler(mockService)
+		var buf bytes.Buffer
+		handler.SetOutput(&buf)
+
+		code := handler.HandleArgs([]string{"--setup", "standard", "10x8"})
+
+		if code != ExitSize {
+			t.Errorf("ожидался код завершения %d, получено %d", ExitSize, code)
+		}
+		if mockService.setup != domain.SetupEmpty {
+			t.Errorf("ожидалась пустая доска, получено '%s'", mockService.setup)
+		}
+		if !strings.Contains(buf.String(), "Фигуры не расставлены") {
+			t.Errorf("ожидалось сообщение о невозможной расстановке, получено: '%s'", buf.String())
+		}
+	})
+
+	t.Run("стандартная расстановка на доске 10x8 в строгом режиме", func(t *testing.T) {
+		handler := NewBoardHandler(&MockBoardService{})
+		handler.SetStrict(true)
+		var out, errOut bytes.Buffer
+		handler.SetOutput(&out)
+		handler.SetErrorOutput(&errOut)
+
+		code := handler.HandleArgs([]string{"--setup", "standard", "10x8"})
+
+		if code != ExitSize || out.Len() != 0 {
+			t.Errorf("ожидался код %d без вывода доски, получено %d и %q", ExitSize, code, out.String())
+		}
+		if !strings.Contains(errOut.String(), "стандартная расстановка возможна только на доске 8x8") {
+			t.Errorf("ожидалась ошибка о расстановке в потоке ошибок, получено: '%s'", errOut.String())
+		}
+	})
+
+	errorCases := []struct {
+		name     string
+		args     []string
+		errorMsg string
+	}{
+		{"неизвестная расстановка", []string{"--setup", "fischer"}, "неизвестная расстановка"},
+		{"неизвестный стиль фигур", []string{"--pieces", "emoji"}, "неизвестный стиль фигур"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewBoardHandler(&MockBoardService{})
+			var buf bytes.Buffer
+			handler.SetOutput(&buf)
+
+			handler.HandleArgs(tc.args)
+
+			if !strings.Contains(buf.String(), tc.errorMsg) {
+				t.Errorf("ожидалась ошибка с текстом '%s', получено: '%s'", tc.errorMsg, buf.String())
+			}
+		})
+	}
+}
+
+func TestHandleArgs_FEN(t *testing.T) {
+	t.Run("позиция передается в сервис", func(t *testing.T) {
+		mockService := &MockBoardService{}
+		handler := NewBoardHandler(mockService)
+		var buf bytes.Buffer
+		handler.SetOutput(&buf)
+
+		handler.HandleArgs([]string{"--fen", domain.StartFEN})
+
+		if mockService.fen != domain.StartFEN {
+			t.Errorf("ожидалась позиция %q, получено %q", domain.StartFEN, mockService.fen)
+		}
+		expected := "Шахматная доска 8x8:\n<доска>\nСтатус: ход белых.\n"
+		if buf.String() != expected {
+			t.Errorf("ожидался вывод %q, получен %q", expected, buf.String())
+		}
+	})
+
+	errorCases := []struct {
+		name     string
+		args     []string
+		errorMsg string
+	}{
+		{"ошибка в позиции", []string{"--fen", "8/8/8/8/8/8/8/7 w - - 0 1"}, "поле 1 (расстановка)"},
+		{"размер вместе с позицией", []string{"--fen", domain.StartFEN, "10"}, "задаются в FEN"},
+		{"расстановка вместе с позицией", []string{"--fen", domain.StartFEN, "--setup", "standard"}, "задаются в FEN"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewBoardHandler(&MockBoardService{})
+			var buf bytes.Buffer
+			handler.SetOutput(&buf)
+
+			handler.HandleArgs(tc.args)
+
+			if !strings.Contains(buf.String(), tc.errorMsg) {
+				t.Errorf("ожидалась ошибка с текстом '%s', получено: '%s'", tc.errorMsg, buf.String())
+			}
+			if strings.Contains(buf.String(), "<доска>") {
+				t.Errorf("при ошибке доска не должна выводиться, получено: '%s'", buf.String())
+			}
 		})
 	}
 }
@@ -0,0 +1,548 @@
+package console
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+// Коды завершения программы. Каждому классу ошибок соответствует свой код,
+// чтобы скрипты могли различать причины неудачи.
+const (
+	// ExitOK - команда выполнена
+	ExitOK = 0
+	// ExitError - внутренняя ошибка: не удалось создать партию или вывести доску
+	ExitError = 1
+	// ExitUsage - неверные аргументы: неизвестная команда или флаг, неверное значение
+	ExitUsage = 2
+	// ExitSize - неверный размер доски или расстановка, невозможная на доске такого размера
+	ExitSize = 3
+	// ExitFEN - неверная позиция в нотации FEN
+	ExitFEN = 4
+	// ExitMove - неверный или недопустимый ход в интерактивном режиме
+	ExitMove = 5
+	// ExitPGN - неверная партия в файле PGN
+	ExitPGN = 6
+	// ExitIO - ошибка чтения файла, ввода или терминала
+	ExitIO = 7
+)
+
+// errorClasses - имена классов ошибок в машиночитаемом выводе. Классы
+// предметной области называются так же, как domain.ErrorClass.
+var errorClasses = map[int]string{
+	ExitError: "internal",
+	ExitUsage: string(domain.ClassUsage),
+	ExitSize:  string(domain.ClassSize),
+	ExitFEN:   string(domain.ClassFEN),
+	ExitMove:  string(domain.ClassMove),
+	ExitPGN:   "pgn",
+	ExitIO:    "io",
+}
+
+// exitCodes - коды завершения для классов ошибок предметной области
+var exitCodes = map[domain.ErrorClass]int{
+	domain.ClassUsage: ExitUsage,
+	domain.ClassSize:  ExitSize,
+	domain.ClassFEN:   ExitFEN,
+	domain.ClassMove:  ExitMove,
+}
+
+// machineError - ошибка строгого режима, которая выводится в поток ошибок одной
+// строкой JSON. Для ошибок предметной области добавляются ошибочное значение,
+// пределы и допустимые значения.
+type machineError struct {
+	Class   string   `json:"class"`
+	Code    int      `json:"code"`
+	Message string   `json:"message"`
+	Value   string   `json:"value,omitempty"`
+	Min     *int     `json:"min,omitempty"`
+	Max     *int     `json:"max,omitempty"`
+	Allowed []string `json:"allowed,omitempty"`
+}
+
+// newMachineError описывает ошибку err с кодом завершения по ее классу; для
+// ошибок, не относящихся к предметной области, используется код fallback
+func (h *BoardHandler) newMachineError(err error, fallback int) machineError {
+	report := machineError{Code: errorCode(err, fallback), Message: h.text.Error(err)}
+
+	var sizeErr *domain.SizeError
+	var numberErr *domain.NumberError
+	var unknownErr *domain.UnknownValueError
+	switch {
+	case errors.As(err, &sizeErr):
+		report.Value = strconv.Itoa(sizeErr.Value)
+		report.Min, report.Max = &sizeErr.Min, &sizeErr.Max
+	case errors.As(err, &numberErr):
+		report.Value = numberErr.Input
+	case errors.As(err, &unknownErr):
+		report.Value = unknownErr.Value
+		report.Allowed = unknownErr.Allowed
+	}
+	return report
+}
+
+// errorCode возвращает код завершения для класса ошибки предметной области
+// (см. domain.Classify). Для остальных ошибок возвращается fallback: смысл такой
+// ошибки знает только вызывающий код.
+func errorCode(err error, fallback int) int {
+	if class, ok := domain.Classify(err); ok {
+		return exitCodes[class]
+	}
+	return fallback
+}
+
+// programName - имя программы в справке и сообщениях
+const programName = "chessboard"
+
+// BuildInfo - сведения о сборке, которые выводит команда version
+type BuildInfo struct {
+	Version string
+	Commit  string
+	Date    string
+}
+
+// command - подкоманда программы. Тексты справки - ключи каталога сообщений.
+type command struct {
+	name string
+	// args - позиционные аргументы в строке использования
+	args    i18n.Key
+	summary i18n.Key
+	// description - подробное описание для справки по команде
+	description i18n.Key
+	// flags создает набор флагов команды для справки; nil, если флагов нет
+	flags func() *flag.FlagSet
+	run   func(h *BoardHandler, args []string) int
+}
+
+// commands возвращает подкоманды в порядке вывода в справке
+func commands() []command {
+	return []command{
+		{
+			name:        "render",
+			args:        "command.render.args",
+			summary:     "command.render.summary",
+			description: "command.render.description",
+			flags:       func() *flag.FlagSet { return renderFlags(&cliOptions{}) },
+			run:         (*BoardHandler).runRender,
+		},
+		{
+			name:        "fen",
+			args:        "command.fen.args",
+			summary:     "command.fen.summary",
+			description: "command.fen.description",
+			flags:       func() *flag.FlagSet { return fenFlags(&cliOptions{}) },
+			run:         (*BoardHandler).runFEN,
+		},
+		{
+			name:        "perft",
+			args:        "command.perft.args",
+			summary:     "command.perft.summary",
+			description: "command.perft.description",
+			flags:       func() *flag.FlagSet { return perftFlags(new(string)) },
+			run:         (*BoardHandler).HandlePerft,
+		},
+		{
+			name:        "play",
+			summary:     "command.play.summary",
+			description: "command.play.description",
+			flags:       func() *flag.FlagSet { return playFlags(&playOptions{}) },
+			run:         (*BoardHandler).HandlePlay,
+		},
+		{
+			name:        "pgn",
+			args:        "command.pgn.args",
+			summary:     "command.pgn.summary",
+			description: "command.pgn.description",
+			flags:       func() *flag.FlagSet { return pgnFlags(&pgnOptions{}) },
+			run:         (*BoardHandler).HandlePGN,
+		},
+		{
+			name:        "serve",
+			summary:     "command.serve.summary",
+			description: "command.serve.description",
+			flags:       func() *flag.FlagSet { return serveFlags(&serveOptions{}) },
+			run:         (*BoardHandler).HandleServe,
+		},
+		{
+			name:    "version",
+			summary: "command.version.summary",
+			run:     (*BoardHandler).runVersion,
+		},
+		{
+			name:        "help",
+			args:        "command.help.args",
+			summary:     "command.help.summary",
+			description: "command.help.description",
+			run:         (*BoardHandler).runHelp,
+		},
+	}
+}
+
+// findCommand возвращает подкоманду по имени
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// SetStrict включает строгий режим: ошибка во входных данных прерывает команду
+// без подстановки значений по умолчанию и выводится в поток ошибок в формате JSON
+func (h *BoardHandler) SetStrict(strict bool) {
+	h.strict = strict
+}
+
+// SetLanguage задает язык сообщений
+func (h *BoardHandler) SetLanguage(lang i18n.Lang) {
+	h.text = i18n.NewPrinter(lang)
+}
+
+// SetErrorOutput задает поток, в который строгий режим выводит ошибки
+func (h *BoardHandler) SetErrorOutput(w io.Writer) {
+	h.errOut = w
+}
+
+// SetBuildInfo задает сведения о сборке для команды version
+func (h *BoardHandler) SetBuildInfo(info BuildInfo) {
+	h.buildInfo = info
+}
+
+// HandleUserInput выполняет команду из аргументов программы и возвращает код
+// завершения. Если ввод или вывод перенаправлен, по умолчанию включается строгий
+// режим: программу запускает скрипт, и ему нужна машиночитаемая ошибка. Язык
+// сообщений выбирается по переменным окружения LC_ALL, LC_MESSAGES и LANG.
+func (h *BoardHandler) HandleUserInput() int {
+	h.strict = !isTerminal(h.in) || !isTerminal(h.out)
+	h.SetLanguage(i18n.Detect(os.Getenv))
+	return h.HandleArgs(os.Args[1:])
+}
+
+// HandleArgs выполняет команду, заданную аргументами командной строки (без имени
+// программы), и возвращает код завершения. Если первый аргумент - не имя
+// зарегистрированной команды и не слово из одних букв, а размер доски или флаг,
+// выполняется команда render. Общие флаги --strict и --lang могут стоять в любом
+// месте.
+func (h *BoardHandler) HandleArgs(args []string) int {
+	args, common, err := cutCommonFlags(args)
+	if common.lang != nil {
+		h.SetLanguage(*common.lang)
+	}
+	if common.strict != nil {
+		h.strict = *common.strict
+	}
+	if err != nil {
+		return h.failErr(err, ExitUsage)
+	}
+
+	if len(args) == 0 {
+		return h.runRender(nil)
+	}
+
+	switch args[0] {
+	case "-h", "-help", "--help":
+		return h.runHelp(args[1:])
+	case "-version", "--version":
+		return h.runVersion(args[1:])
+	}
+	if cmd, ok := findCommand(args[0]); ok {
+		return cmd.run(h, args[1:])
+	}
+
+	if looksLikeCommand(args[0]) {
+		return h.fail(ExitUsage, "cli.unknown_command", args[0], programName)
+	}
+	return h.runRender(args)
+}
+
+// looksLikeCommand сообщает, что аргумент, не совпавший с именем команды, похож
+// на опечатку в ней: состоит только из букв. Размер доски вроде "x8" или "8a"
+// содержит цифры и передается команде render, которая сообщит об ошибке размера.
+func looksLikeCommand(arg string) bool {
+	if arg == "" {
+		return false
+	}
+	for _, r := range arg {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// fail выводит сообщение key об ошибке и возвращает код завершения code
+func (h *BoardHandler) fail(code int, key i18n.Key, args ...any) int {
+	return h.report(machineError{Code: code, Message: h.text.Sprintf(key, args...)})
+}
+
+// failErr выводит ошибку err и возвращает код завершения по ее классу (см. errorCode)
+func (h *BoardHandler) failErr(err error, fallback int) int {
+	return h.report(h.newMachineError(err, fallback))
+}
+
+// report выводит ошибку и возвращает ее код завершения. В строгом режиме ошибка
+// выводится в поток ошибок одной строкой JSON, в обычном - текстом со списком
+// допустимых значений, если он есть.
+func (h *BoardHandler) report(e machineError) int {
+	e.Class = errorClasses[e.Code]
+	if h.strict {
+		// Ошибку записи в поток ошибок сообщить уже некуда
+		_ = json.NewEncoder(h.errOut).Encode(e)
+		return e.Code
+	}
+	message := e.Message
+	if len(e.Allowed) > 0 {
+		message += h.text.Sprintf("cli.allowed_values", strings.Join(e.Allowed, ", "))
+	}
+	h.text.Fprintf(h.out, "cli.error", message)
+	return e.Code
+}
+
+// commonFlags - общие флаги всех команд; nil означает, что флаг не указан
+type commonFlags struct {
+	strict *bool
+	lang   *i18n.Lang
+}
+
+// cutCommonFlags извлекает из аргументов общие флаги --strict (или --strict=BOOL)
+// и --lang LANG (или --lang=LANG). Язык из флага возвращается и при ошибке в
+// другом флаге, чтобы сообщение о ней было на выбранном языке.
+func cutCommonFlags(args []string) ([]string, commonFlags, error) {
+	var rest []string
+	var flags commonFlags
+	var err error
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || (name != "strict" && name != "lang") {
+			rest = append(rest, arg)
+			continue
+		}
+
+		if name == "lang" {
+			if !hasValue {
+				if i+1 >= len(args) {
+					err = i18n.Errorf("cli.flag_value_required", arg)
+					continue
+				}
+				i++
+				value = args[i]
+			}
+			lang, ok := i18n.LookupLang(value)
+			if !ok {
+				err = &domain.UnknownValueError{Label: "unknown.language", Value: value, Allowed: languageNames()}
+				continue
+			}
+			flags.lang = &lang
+			continue
+		}
+
+		enabled := true
+		if hasValue {
+			var parseErr error
+			if enabled, parseErr = strconv.ParseBool(value); parseErr != nil {
+				err = i18n.Errorf("cli.invalid_flag_value", name, value)
+				continue
+			}
+		}
+		flags.strict = &enabled
+	}
+	if err != nil {
+		return nil, flags, err
+	}
+	return rest, flags, nil
+}
+
+// languageNames возвращает коды поддерживаемых языков
+func languageNames() []string {
+	var names []string
+	for _, lang := range i18n.Languages() {
+		names = append(names, string(lang))
+	}
+	return names
+}
+
+// usageError выводит ошибку в аргументах команды name с подсказкой, где найти
+// справку. Для запроса справки (--help) выводит справку по команде и возвращает ExitOK.
+func (h *BoardHandler) usageError(name string, err error) int {
+	cmd, _ := findCommand(name)
+	if errors.Is(err, flag.ErrHelp) {
+		writeCommandHelp(h.out, h.text, cmd)
+		return ExitOK
+	}
+	code := h.failErr(err, ExitUsage)
+	if !h.strict {
+		h.text.Fprintf(h.out, "cli.help_hint", programName, cmd.name)
+	}
+	return code
+}
+
+// runVersion выводит версию программы
+func (h *BoardHandler) runVersion(args []string) int {
+	if len(args) > 0 {
+		return h.usageError("version", i18n.Errorf("cli.extra_argument", args[0]))
+	}
+	info := h.buildInfo
+	fmt.Fprintf(h.out, "%s version %s, commit %s, built %s\n", programName, info.Version, info.Commit, info.Date)
+	return ExitOK
+}
+
+// runHelp выводит список команд или справку по команде
+func (h *BoardHandler) runHelp(args []string) int {
+	switch len(args) {
+	case 0:
+		writeUsage(h.out, h.text)
+		return ExitOK
+	case 1:
+		cmd, ok := findCommand(args[0])
+		if !ok {
+			return h.fail(ExitUsage, "cli.unknown_command", args[0], programName)
+		}
+		writeCommandHelp(h.out, h.text, cmd)
+		return ExitOK
+	default:
+		return h.usageError("help", i18n.Errorf("cli.extra_argument", args[1]))
+	}
+}
+
+// writeUsage выводит общую справку со списком команд
+func writeUsage(w io.Writer, text *i18n.Printer) {
+	text.Fprintf(w, "help.title", programName)
+	text.Fprintf(w, "help.usage", programName)
+	for _, cmd := range commands() {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, text.Sprintf(cmd.summary))
+	}
+	text.Fprintf(w, "help.common_flags")
+	text.Fprintf(w, "help.command_help", programName, programName)
+	text.Fprintf(w, "help.exit_codes")
+	for _, code := range []int{ExitOK, ExitError, ExitUsage, ExitSize, ExitFEN, ExitMove, ExitPGN, ExitIO} {
+		fmt.Fprintf(w, "  %d  %s\n", code, text.Sprintf(exitCodeDescriptions[code]))
+	}
+}
+
+// exitCodeDescriptions - описания кодов завершения для справки
+var exitCodeDescriptions = map[int]i18n.Key{
+	ExitOK:    "exit.ok",
+	ExitError: "exit.error",
+	ExitUsage: "exit.usage",
+	ExitSize:  "exit.size",
+	ExitFEN:   "exit.fen",
+	ExitMove:  "exit.move",
+	ExitPGN:   "exit.pgn",
+	ExitIO:    "exit.io",
+}
+
+// writeCommandHelp выводит справку по команде: строку использования, описание и флаги
+func writeCommandHelp(w io.Writer, text *i18n.Printer, cmd command) {
+	var args string
+	if cmd.args != "" {
+		args = text.Sprintf(cmd.args)
+	}
+	usage := strings.TrimSpace(strings.Join([]string{programName, cmd.name, args}, " "))
+	if cmd.flags != nil {
+		usage += text.Sprintf("help.flags_placeholder")
+	}
+	text.Fprintf(w, "help.command_usage", usage, text.Sprintf(cmd.summary))
+	if cmd.description != "" {
+		fmt.Fprintf(w, "\n%s\n", text.Sprintf(cmd.description))
+	}
+	if cmd.flags != nil {
+		text.Fprintf(w, "help.flags")
+		writeFlags(w, text, cmd.flags())
+	}
+}
+
+// writeFlags выводит флаги набора по алфавиту: имя, значение и описание.
+// Описание флага - ключ каталога сообщений; имя значения берется из описания
+// в обратных кавычках, как в пакете flag.
+func writeFlags(w io.Writer, text *i18n.Printer, fs *flag.FlagSet) {
+	type line struct{ name, usage string }
+	var lines []line
+	width := 0
+	fs.VisitAll(func(f *flag.Flag) {
+		translated := *f
+		translated.Usage = text.Sprintf(i18n.Key(f.Usage))
+		valueName, usage := flag.UnquoteUsage(&translated)
+		name := "--" + f.Name
+		if !isBoolFlag(f) {
+			name += " " + strings.ToUpper(valueName)
+		}
+		lines = append(lines, line{name, usage})
+		width = max(width, utf8.RuneCountInString(name))
+	})
+	sort.Slice(lines, func(i, j int) bool { return lines[i].name < lines[j].name })
+	for _, l := range lines {
+		padding := strings.Repeat(" ", width-utf8.RuneCountInString(l.name))
+		fmt.Fprintf(w, "  %s%s  %s\n", l.name, padding, l.usage)
+	}
+}
+
+// newFlagSet создает набор флагов команды, который не печатает ошибки сам:
+// их выводит обработчик команды
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parseFlagSet разбирает флаги в любом месте среди аргументов: --name VALUE,
+// --name=VALUE или -name; булевы флаги - --name или --name=false. Возвращает
+// позиционные аргументы. Отрицательные числа считаются позиционными аргументами,
+// чтобы обработчик мог сообщить о них понятной ошибкой. Для --help возвращается
+// flag.ErrHelp.
+func parseFlagSet(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		isFlag := strings.HasPrefix(arg, "-") && name != "" && !unicode.IsDigit(rune(name[0]))
+
+		if !isFlag {
+			positional = append(positional, arg)
+			continue
+		}
+		if name == "help" || name == "h" {
+			return nil, flag.ErrHelp
+		}
+
+		f := fs.Lookup(name)
+		if f == nil {
+			return nil, i18n.Errorf("cli.unknown_flag", arg)
+		}
+		if !hasValue {
+			if isBoolFlag(f) {
+				value = "true"
+			} else {
+				if i+1 >= len(args) {
+					return nil, i18n.Errorf("cli.flag_value_required", arg)
+				}
+				i++
+				value = args[i]
+			}
+		}
+		if err := fs.Set(name, value); err != nil {
+			return nil, i18n.Errorf("cli.invalid_flag_value", name, value)
+		}
+	}
+
+	return positional, nil
+}
+
+// isBoolFlag сообщает, что флаг не требует значения
+func isBoolFlag(f *flag.Flag) bool {
+	boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && boolFlag.IsBoolFlag()
+}
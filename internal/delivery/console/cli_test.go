@@ -0,0 +1,460 @@
+package console
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+	"chessboard/internal/usecase"
+)
+
+// runCommand выполняет аргументы командной строки с тестовым сервисом и
+// возвращает код завершения и вывод
+func runCommand(service domain.BoardService, args ...string) (int, string) {
+	handler := NewBoardHandler(service)
+	handler.SetBuildInfo(BuildInfo{Version: "1.2.3", Commit: "abc123", Date: "2026-01-01"})
+	var buf bytes.Buffer
+	handler.SetOutput(&buf)
+	code := handler.HandleArgs(args)
+	return code, buf.String()
+}
+
+func TestHandleArgs_Commands(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		code     int
+		expected string
+	}{
+		{"без аргументов", nil, ExitOK, "Шахматная доска 8x8:"},
+		{"размер без команды", []string{"10x6"}, ExitOK, "Шахматная доска 10x6:"},
+		{"флаг без команды", []string{"--coords", "4"}, ExitOK, "Шахматная доска 4x4:"},
+		{"команда render", []string{"render", "5"}, ExitOK, "Шахматная доска 5x5:"},
+		{"команда fen", []string{"fen", domain.StartFEN}, ExitOK, "Статус: ход белых."},
+		{"поля fen отдельными аргументами", append([]string{"fen"}, strings.Fields(domain.StartFEN)...),
+			ExitOK, "Статус: ход белых."},
+		{"команда perft", []string{"perft", "1"}, ExitOK, "Узлов: 3"},
+		{"команда version", []string{"version"}, ExitOK, "chessboard version 1.2.3, commit abc123, built 2026-01-01\n"},
+		{"флаг --version", []string{"--version"}, ExitOK, "chessboard version 1.2.3"},
+		{"справка", []string{"help"}, ExitOK, "Команды:"},
+		{"флаг --help", []string{"--help"}, ExitOK, "Команды:"},
+		{"справка по команде", []string{"help", "perft"}, ExitOK, "Использование: chessboard perft <глубина> [флаги]"},
+		{"флаг --help команды", []string{"perft", "--help"}, ExitOK, "--fen ПОЗИЦИЯ"},
+		{"флаг -h без команды размера", []string{"8", "-h"}, ExitOK, "Использование: chessboard render [размер]"},
+		{"неизвестная команда", []string{"draw"}, ExitUsage, "Ошибка: неизвестная команда: 'draw'"},
+		{"справка по неизвестной команде", []string{"help", "draw"}, ExitUsage, "неизвестная команда: 'draw'"},
+		{"лишний аргумент справки", []string{"help", "fen", "perft"}, ExitUsage, "лишний аргумент: 'perft'"},
+		{"лишний аргумент version", []string{"version", "2"}, ExitUsage, "лишний аргумент: '2'"},
+		{"неизвестный флаг", []string{"--colour"}, ExitUsage, "Справка: chessboard help render\n"},
+		{"fen без позиции", []string{"fen"}, ExitUsage, "укажите позицию в нотации FEN"},
+		{"неверный fen", []string{"fen", "8/8/8/8/8/8/8/9 w - - 0 1"}, ExitFEN, "неверный FEN"},
+		{"флаг узора в команде fen", []string{"fen", domain.StartFEN, "--pattern", "rings"}, ExitUsage, "неизвестный флаг"},
+		{"неверная глубина", []string{"perft", "глубоко"}, ExitUsage, "неверный формат числа"},
+		{"нет файла pgn", []string{"pgn", "show", filepath.Join(t.TempDir(), "нет.pgn")}, ExitIO, "не удалось открыть файл"},
+		{"неверный размер", []string{"8a"}, ExitSize, "Используется размер по умолчанию 8x8"},
+		{"размер без ширины", []string{"x8"}, ExitSize, "Используется размер по умолчанию 8x8"},
+		{"размер без высоты", []string{"8x"}, ExitSize, "Используется размер по умолчанию 8x8"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, output := runCommand(&MockBoardService{}, tc.args...)
+			if code != tc.code {
+				t.Errorf("ожидался код завершения %d, получено %d; вывод:\n%s", tc.code, code, output)
+			}
+			if !strings.Contains(output, tc.expected) {
+				t.Errorf("вывод должен содержать %q, получено:\n%s", tc.expected, output)
+			}
+		})
+	}
+}
+
+// runStrict выполняет аргументы в строгом режиме и возвращает код завершения,
+// стандартный вывод и поток ошибок
+func runStrict(service domain.BoardService, args ...string) (int, string, string) {
+	handler := NewBoardHandler(service)
+	handler.SetStrict(true)
+	var out, errOut bytes.Buffer
+	handler.SetOutput(&out)
+	handler.SetErrorOutput(&errOut)
+	code := handler.HandleArgs(args)
+	return code, out.String(), errOut.String()
+}
+
+func TestHandleArgs_ImpossiblePosition(t *testing.T) {
+	// Позиции записаны верно, но генератор ходов их не принимает. Каждая команда
+	// сообщает об этом одинаково: класс fen и код ExitFEN.
+	positions := []struct {
+		name     string
+		fen      string
+		errorMsg string
+	}{
+		{"два белых короля", "4k3/8/8/8/8/8/8/K3K3 w - - 0 1", "у белых больше одного короля"},
+		{"шах стороне без хода", "4k3/4R3/8/8/8/8/8/4K3 w - - 0 1", "король черных под шахом"},
+	}
+	commands := []struct {
+		name string
+		args func(fen string) []string
+	}{
+		{"fen", func(fen string) []string { return []string{"fen", fen} }},
+		{"perft", func(fen string) []string { return []string{"perft", "1", "--fen", fen} }},
+		{"play", func(fen string) []string { return []string{"play", "--fen", fen} }},
+		{"render", func(fen string) []string { return []string{"render", "--fen", fen} }},
+	}
+
+	for _, position := range positions {
+		for _, command := range commands {
+			t.Run(position.name+"/"+command.name, func(t *testing.T) {
+				service := usecase.NewBoardUsecase(usecase.NewBoardRepository())
+				code, _, errOutput := runStrict(service, command.args(position.fen)...)
+				if code != ExitFEN {
+					t.Errorf("ожидался код %d, получено %d: %s", ExitFEN, code, errOutput)
+				}
+				var report machineError
+				if err := json.Unmarshal([]byte(errOutput), &report); err != nil {
+					t.Fatalf("ошибка должна быть в JSON: %v\n%s", err, errOutput)
+				}
+				if report.Class != "fen" || !strings.Contains(report.Message, position.errorMsg) {
+					t.Errorf("ожидалась ошибка класса fen с %q, получено %+v", position.errorMsg, report)
+				}
+			})
+		}
+	}
+}
+
+func TestHandleArgs_InvalidSize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		service  *MockBoardService
+		errorMsg string
+	}{
+		{"нечисловой размер", []string{"8a"}, &MockBoardService{}, "неверный формат числа: '8a'"},
+		{"отрицательный размер", []string{"-8"}, &MockBoardService{}, "отрицательные числа не поддерживаются: '-8'"},
+		{"дробный размер", []string{"render", "8.5"}, &MockBoardService{}, "дробные числа не поддерживаются: '8.5'"},
+		{"размер вне пределов", []string{"100"},
+			&MockBoardService{validateError: errors.New("размер доски должен быть от 1 до 50")}, "от 1 до 50"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, output := runCommand(tc.service, tc.args...)
+			if code != ExitSize {
+				t.Errorf("ожидался код завершения %d, получено %d", ExitSize, code)
+			}
+			// В обычном режиме вместо неверного размера выводится доска по умолчанию с сообщением
+			if !strings.Contains(output, tc.errorMsg+". Используется размер по умолчанию 8x8.\n") ||
+				!strings.Contains(output, "Шахматная доска 8x8:") {
+				t.Errorf("ожидались ошибка и доска по умолчанию, получено: '%s'", output)
+			}
+		})
+
+		t.Run(tc.name+" в строгом режиме", func(t *testing.T) {
+			code, output, errOutput := runStrict(tc.service, tc.args...)
+			if code != ExitSize {
+				t.Errorf("ожидался код завершения %d, получено %d", ExitSize, code)
+			}
+			if output != "" {
+				t.Errorf("в строгом режиме доска по умолчанию не выводится, получено: '%s'", output)
+			}
+			if !strings.Contains(errOutput, tc.errorMsg) {
+				t.Errorf("ожидалась ошибка с текстом '%s' в потоке ошибок, получено: '%s'", tc.errorMsg, errOutput)
+			}
+		})
+	}
+}
+
+func TestHandleArgs_StrictErrors(t *testing.T) {
+	dir := t.TempDir()
+	broken := filepath.Join(dir, "broken.pgn")
+	if err := os.WriteFile(broken, []byte("1. e4 (e5"), 0o600); err != nil {
+		t.Fatalf("не удалось записать файл: %v", err)
+	}
+
+	testCases := []struct {
+		name  string
+		args  []string
+		class string
+		code  int
+	}{
+		{"неизвестная команда", []string{"draw"}, "usage", ExitUsage},
+		{"размер с буквы", []string{"x8"}, "size", ExitSize},
+		{"неизвестный флаг", []string{"perft", "1", "--coords"}, "usage", ExitUsage},
+		{"неверный размер", []string{"3"}, "size", ExitSize},
+		{"неверный FEN", []string{"fen", "8/8 w"}, "fen", ExitFEN},
+		{"неверный PGN", []string{"pgn", "show", broken}, "pgn", ExitPGN},
+		{"нет файла", []string{"pgn", "show", filepath.Join(dir, "нет.pgn")}, "io", ExitIO},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := &MockBoardService{}
+			if tc.class == "size" {
+				service.validateError = errors.New("размер доски не может быть меньше 4")
+			}
+			code, output, errOutput := runStrict(service, tc.args...)
+			if code != tc.code || output != "" {
+				t.Errorf("ожидался код %d без вывода, получено %d и %q", tc.code, code, output)
+			}
+
+			var got machineError
+			if err := json.Unmarshal([]byte(errOutput), &got); err != nil {
+				t.Fatalf("ошибка должна быть одной строкой JSON, получено %q: %v", errOutput, err)
+			}
+			if got.Class != tc.class || got.Code != tc.code || got.Message == "" {
+				t.Errorf("ожидался класс %q с кодом %d, получено %+v", tc.class, tc.code, got)
+			}
+			if strings.Count(errOutput, "\n") != 1 {
+				t.Errorf("ошибка должна занимать одну строку: %q", errOutput)
+			}
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"размер", &domain.SizeError{Dimension: domain.DimensionWidth, Value: 3, Min: 4, Max: 10}, ExitSize},
+		{"расстановка", &domain.SetupError{Setup: domain.SetupStandard, Width: 10, Height: 8}, ExitSize},
+		{"FEN", &domain.FENError{Reason: errors.New("пустая строка")}, ExitFEN},
+		{"позиция", fmt.Errorf("%w: два короля", domain.ErrInvalidPosition), ExitFEN},
+		{"ход", fmt.Errorf("%w: 'e5'", domain.ErrIllegalMove), ExitMove},
+		{"число", &domain.NumberError{Input: "x", Kind: domain.ErrNotANumber}, ExitUsage},
+		{"неизвестное значение", &domain.UnknownValueError{Label: "unknown.theme", Value: "neon"}, ExitUsage},
+		{"узор", fmt.Errorf("%w: 'rings'", domain.ErrInvalidPattern), ExitUsage},
+		{"обернутая ошибка", fmt.Errorf("позиция: %w", &domain.FENError{Reason: errors.New("пустая строка")}), ExitFEN},
+		{"прочая ошибка", errors.New("сбой"), ExitIO},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := errorCode(tc.err, ExitIO); got != tc.expected {
+				t.Errorf("ожидался код %d, получено %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestHandleArgs_StrictDetails(t *testing.T) {
+	t.Run("размер вне пределов", func(t *testing.T) {
+		service := &MockBoardService{validateError: &domain.SizeError{Dimension: "ширина", Value: 3, Min: 4, Max: 10}}
+		_, _, errOutput := runStrict(service, "3")
+
+		var got machineError
+		if err := json.Unmarshal([]byte(errOutput), &got); err != nil {
+			t.Fatalf("ошибка должна быть JSON: %v", err)
+		}
+		if got.Value != "3" || got.Min == nil || *got.Min != 4 || got.Max == nil || *got.Max != 10 {
+			t.Errorf("ожидались значение 3 и пределы 4..10, получено %s", errOutput)
+		}
+	})
+
+	t.Run("неизвестный узор", func(t *testing.T) {
+		code, _, errOutput := runStrict(&MockBoardService{}, "8", "--pattern", "zigzag")
+
+		var got machineError
+		if err := json.Unmarshal([]byte(errOutput), &got); err != nil {
+			t.Fatalf("ошибка должна быть JSON: %v", err)
+		}
+		if code != ExitUsage || got.Value != "zigzag" || strings.Join(got.Allowed, ",") != "checker,rings" {
+			t.Errorf("ожидались значение и допустимые узоры, получено %d и %s", code, errOutput)
+		}
+	})
+
+	t.Run("в обычном режиме допустимые значения выводятся текстом", func(t *testing.T) {
+		code, output := runCommand(&MockBoardService{}, "8", "--pattern", "zigzag")
+		expected := "Ошибка: неизвестный узор: 'zigzag'. Допустимые значения: checker, rings.\n"
+		if code != ExitUsage || output != expected {
+			t.Errorf("ожидалось %q с кодом %d, получено %q с кодом %d", expected, ExitUsage, output, code)
+		}
+	})
+}
+
+func TestHandleArgs_StrictFlag(t *testing.T) {
+	testCases := []struct {
+		name   string
+		args   []string
+		strict bool
+	}{
+		{"флаг перед командой", []string{"--strict", "3"}, true},
+		{"флаг после размера", []string{"3", "-strict"}, true},
+		{"флаг с значением", []string{"render", "--strict=true", "3"}, true},
+		{"строгий режим выключен", []string{"--strict=false", "3"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewBoardHandler(&MockBoardService{validateError: errors.New("размер доски не может быть меньше 4")})
+			var out, errOut bytes.Buffer
+			handler.SetOutput(&out)
+			handler.SetErrorOutput(&errOut)
+
+			code := handler.HandleArgs(tc.args)
+			if code != ExitSize {
+				t.Errorf("ожидался код %d, получено %d", ExitSize, code)
+			}
+			if strict := errOut.Len() > 0; strict != tc.strict || handler.strict != tc.strict {
+				t.Errorf("ожидался строгий режим %v; поток ошибок %q, вывод %q", tc.strict, errOut.String(), out.String())
+			}
+		})
+	}
+
+	code, output := runCommand(&MockBoardService{}, "--strict=maybe")
+	if code != ExitUsage || !strings.Contains(output, "неверное значение флага 'strict': 'maybe'") {
+		t.Errorf("ожидалась ошибка значения флага, получено %d: %q", code, output)
+	}
+}
+
+func TestHandleArgs_Language(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		code     int
+		expected string
+	}{
+		{"английский", []string{"--lang", "en", "render", "5"}, ExitOK, "Chessboard 5x5:"},
+		{"флаг со значением", []string{"render", "5", "--lang=en"}, ExitOK, "Chessboard 5x5:"},
+		{"имя локали", []string{"--lang=en_US.UTF-8", "fen", domain.StartFEN}, ExitOK, "Status: white to move."},
+		{"русский", []string{"--lang", "ru", "4"}, ExitOK, "Шахматная доска 4x4:"},
+		{"справка", []string{"--lang", "en", "help"}, ExitOK, "Exit codes:"},
+		{"справка по команде", []string{"--lang", "en", "help", "perft"}, ExitOK, "--fen POSITION"},
+		{"ошибка на английском", []string{"--lang", "en", "fen"}, ExitUsage, "Error: specify a position in FEN"},
+		{"ошибка предметной области", []string{"--lang", "en", "fen", "8/8/8/8/8/8/8/9 w - - 0 1"}, ExitFEN, "Error: invalid FEN"},
+		{"неизвестный язык", []string{"--lang", "de", "4"}, ExitUsage, "неизвестный язык: 'de'. Допустимые значения: ru, en."},
+		{"язык без значения", []string{"4", "--lang"}, ExitUsage, "флаг '--lang' требует значения"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, output := runCommand(&MockBoardService{}, tc.args...)
+			if code != tc.code {
+				t.Errorf("ожидался код завершения %d, получено %d; вывод:\n%s", tc.code, code, output)
+			}
+			if !strings.Contains(output, tc.expected) {
+				t.Errorf("вывод должен содержать %q, получено:\n%s", tc.expected, output)
+			}
+		})
+	}
+}
+
+func TestHandleArgs_LanguagePrecedence(t *testing.T) {
+	handler := NewBoardHandler(&MockBoardService{})
+	var buf bytes.Buffer
+	handler.SetOutput(&buf)
+	// Язык из окружения задается через SetLanguage, флаг --lang важнее него
+	handler.SetLanguage(i18n.English)
+	if handler.HandleArgs([]string{"3"}); !strings.Contains(buf.String(), "Chessboard 3x3:") {
+		t.Errorf("ожидался вывод на английском, получено:\n%s", buf.String())
+	}
+	buf.Reset()
+	if handler.HandleArgs([]string{"--lang", "ru", "3"}); !strings.Contains(buf.String(), "Шахматная доска 3x3:") {
+		t.Errorf("флаг --lang должен переопределять язык окружения, получено:\n%s", buf.String())
+	}
+}
+
+func TestHandleArgs_StrictLanguage(t *testing.T) {
+	code, _, errOutput := runStrict(&MockBoardService{}, "--lang", "en", "fen", "8/8/8/8/8/8/8/9 w - - 0 1")
+	var report machineError
+	if err := json.Unmarshal([]byte(errOutput), &report); err != nil {
+		t.Fatalf("ошибка должна быть в формате JSON: %v; %q", err, errOutput)
+	}
+	if code != ExitFEN || report.Class != "fen" || !strings.HasPrefix(report.Message, "invalid FEN") {
+		t.Errorf("ожидалась ошибка FEN на английском, получено %d: %+v", code, report)
+	}
+}
+
+func TestHandleArgs_HelpForEveryCommand(t *testing.T) {
+	for _, cmd := range commands() {
+		t.Run(cmd.name, func(t *testing.T) {
+			code, output := runCommand(&MockBoardService{}, "help", cmd.name)
+			if code != ExitOK || !strings.Contains(output, "Использование: chessboard "+cmd.name) {
+				t.Errorf("неверная справка по команде (код %d):\n%s", code, output)
+			}
+			if cmd.flags == nil {
+				return
+			}
+			cmd.flags().VisitAll(func(f *flag.Flag) {
+				if !strings.Contains(output, "--"+f.Name) {
+					t.Errorf("справка должна описывать флаг --%s:\n%s", f.Name, output)
+				}
+			})
+		})
+	}
+}
+
+func TestWriteUsage(t *testing.T) {
+	var buf bytes.Buffer
+	writeUsage(&buf, i18n.NewPrinter(i18n.Default))
+
+	for _, cmd := range commands() {
+		if !strings.Contains(buf.String(), "  "+cmd.name+" ") {
+			t.Errorf("в списке команд нет %q:\n%s", cmd.name, buf.String())
+		}
+	}
+}
+
+func TestParseFlagSet(t *testing.T) {
+	testCases := []struct {
+		name       string
+		args       []string
+		positional []string
+		fen        string
+		coords     bool
+		err        string
+	}{
+		{"без аргументов", nil, nil, "", false, ""},
+		{"флаги среди аргументов", []string{"a", "--fen", "x", "b", "--coords"}, []string{"a", "b"}, "x", true, ""},
+		{"значение через равно", []string{"--fen=x=y"}, nil, "x=y", false, ""},
+		{"один дефис", []string{"-coords"}, nil, "", true, ""},
+		{"булево значение через равно", []string{"--coords=false"}, nil, "", false, ""},
+		{"отрицательное число", []string{"-5", "--coords"}, []string{"-5"}, "", true, ""},
+		{"флаг без значения", []string{"--fen"}, nil, "", false, "флаг '--fen' требует значения"},
+		{"неверное булево значение", []string{"--coords=maybe"}, nil, "", false, "неверное значение флага 'coords': 'maybe'"},
+		{"неизвестный флаг", []string{"--colour"}, nil, "", false, "неизвестный флаг: '--colour'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var fen string
+			var coords bool
+			fs := newFlagSet("test")
+			fs.StringVar(&fen, "fen", "", "")
+			fs.BoolVar(&coords, "coords", false, "")
+
+			positional, err := parseFlagSet(fs, tc.args)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Errorf("ожидалась ошибка %q, получено %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if !reflect.DeepEqual(positional, tc.positional) || fen != tc.fen || coords != tc.coords {
+				t.Errorf("ожидалось %v, fen=%q, coords=%v; получено %v, fen=%q, coords=%v",
+					tc.positional, tc.fen, tc.coords, positional, fen, coords)
+			}
+		})
+	}
+}
+
+func TestParseFlagSet_Help(t *testing.T) {
+	for _, arg := range []string{"-h", "--help", "-help"} {
+		if _, err := parseFlagSet(newFlagSet("test"), []string{"8", arg}); !errors.Is(err, flag.ErrHelp) {
+			t.Errorf("для %q ожидался запрос справки, получено %v", arg, err)
+		}
+	}
+}
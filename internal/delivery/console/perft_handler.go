@@ -0,0 +1,68 @@
+package console
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"time"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+// HandlePerft обрабатывает команду "perft <глубина> [--fen FEN]": считает листья
+// дерева легальных ходов, выводит разбивку по первым ходам, время и скорость подсчета
+func (h *BoardHandler) HandlePerft(args []string) int {
+	var fen string
+	positional, err := parseFlagSet(perftFlags(&fen), args)
+	if err != nil {
+		return h.usageError("perft", err)
+	}
+	if len(positional) != 1 {
+		return h.usageError("perft", i18n.New("perft.depth_required"))
+	}
+
+	depth, err := domain.ParseNumber(positional[0])
+	if err != nil {
+		return h.fail(ExitUsage, "perft.depth_error", err)
+	}
+	if fen == "" {
+		fen = domain.StartFEN
+	}
+	board, err := h.boardService.LoadFEN(fen)
+	if err != nil {
+		return h.failErr(err, ExitFEN)
+	}
+
+	start := time.Now()
+	result, err := h.boardService.Perft(board, depth)
+	elapsed := time.Since(start)
+	if err != nil {
+		return h.failErr(err, ExitUsage)
+	}
+
+	out := bufio.NewWriter(h.out)
+	defer out.Flush()
+
+	for _, entry := range result.Divide {
+		fmt.Fprintf(out, "%s: %d\n", entry.Move, entry.Nodes)
+	}
+	h.text.Fprintf(out, "perft.summary", result.Depth, result.Nodes,
+		elapsed.Round(time.Microsecond), nodesPerSecond(result.Nodes, elapsed))
+	return ExitOK
+}
+
+// perftFlags создает набор флагов команды perft
+func perftFlags(fen *string) *flag.FlagSet {
+	fs := newFlagSet("perft")
+	fs.StringVar(fen, "fen", "", "flag.perft_fen")
+	return fs
+}
+
+// nodesPerSecond возвращает скорость подсчета; для нулевого времени возвращает 0
+func nodesPerSecond(nodes int64, elapsed time.Duration) int64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return int64(float64(nodes) / elapsed.Seconds())
+}
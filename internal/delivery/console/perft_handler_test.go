@@ -0,0 +1,92 @@
+package console
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"chessboard/internal/domain"
+)
+
+func TestHandlePerft(t *testing.T) {
+	t.Run("начальная позиция по умолчанию", func(t *testing.T) {
+		mockService := &MockBoardService{}
+		handler := NewBoardHandler(mockService)
+		var buf bytes.Buffer
+		handler.SetOutput(&buf)
+
+		handler.HandleArgs([]string{"perft", "3"})
+
+		if mockService.fen != domain.StartFEN || mockService.depth != 3 {
+			t.Errorf("ожидался perft 3 начальной позиции, получено %d для %q", mockService.depth, mockService.fen)
+		}
+		for _, part := range []string{"a2a3: 1\ne2e4: 2\n", "Глубина: 3\n", "Узлов: 3\n", "Время: ", "узлов/с\n"} {
+			if !strings.Contains(buf.String(), part) {
+				t.Errorf("вывод должен содержать %q, получено:\n%s", part, buf.String())
+			}
+		}
+	})
+
+	t.Run("позиция из FEN", func(t *testing.T) {
+		mockService := &MockBoardService{}
+		handler := NewBoardHandler(mockService)
+		var buf bytes.Buffer
+		handler.SetOutput(&buf)
+
+		fen := "4k3/8/8/8/8/8/8/4K3 w - - 0 1"
+		handler.HandleArgs([]string{"perft", "--fen", fen, "2"})
+
+		if mockService.fen != fen || mockService.depth != 2 {
+			t.Errorf("ожидался perft 2 позиции %q, получено %d для %q", fen, mockService.depth, mockService.fen)
+		}
+	})
+
+	errorCases := []struct {
+		name     string
+		args     []string
+		errorMsg string
+	}{
+		{"без глубины", []string{"perft"}, "укажите глубину"},
+		{"две глубины", []string{"perft", "2", "3"}, "укажите глубину"},
+		{"отрицательная глубина", []string{"perft", "-2"}, "отрицательные числа не поддерживаются"},
+		{"нечисловая глубина", []string{"perft", "deep"}, "неверный формат числа"},
+		{"слишком большая глубина", []string{"perft", "6"}, "глубина perft должна быть от 1 до 5"},
+		{"неверный FEN", []string{"perft", "1", "--fen", "8/8/8/8/8/8/8/9 w - - 0 1"}, "неверный FEN"},
+		{"флаг отрисовки", []string{"perft", "1", "--coords"}, "неизвестный флаг"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewBoardHandler(&MockBoardService{})
+			var buf bytes.Buffer
+			handler.SetOutput(&buf)
+
+			handler.HandleArgs(tc.args)
+
+			if !strings.Contains(buf.String(), tc.errorMsg) {
+				t.Errorf("ожидалась ошибка с текстом '%s', получено: '%s'", tc.errorMsg, buf.String())
+			}
+		})
+	}
+}
+
+func TestNodesPerSecond(t *testing.T) {
+	testCases := []struct {
+		name     string
+		nodes    int64
+		elapsed  time.Duration
+		expected int64
+	}{
+		{"секунда", 1000, time.Second, 1000},
+		{"полсекунды", 1000, 500 * time.Millisecond, 2000},
+		{"нулевое время", 1000, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := nodesPerSecond(tc.nodes, tc.elapsed); actual != tc.expected {
+				t.Errorf("ожидалось %d, получено %d", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package console
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+	"chessboard/internal/pgn"
+)
+
+// pgnOptions содержит флаги команды pgn
+type pgnOptions struct {
+	game   string
+	ply    string
+	pieces string
+	coords bool
+}
+
+// pgnFlags создает набор флагов команды pgn
+func pgnFlags(opts *pgnOptions) *flag.FlagSet {
+	fs := newFlagSet("pgn")
+	fs.StringVar(&opts.game, "game", "", "flag.game")
+	fs.StringVar(&opts.ply, "ply", "", "flag.ply")
+	fs.StringVar(&opts.pieces, "pieces", "", "flag.pieces")
+	fs.BoolVar(&opts.coords, "coords", false, "flag.coords")
+	return fs
+}
+
+// HandlePGN обрабатывает команду "pgn show <файл> [--game N] [--ply M]": выводит
+// доску в позиции после M-го полухода N-й партии файла. По умолчанию показывается
+// конечная позиция первой партии.
+func (h *BoardHandler) HandlePGN(args []string) int {
+	var opts pgnOptions
+	positional, err := parseFlagSet(pgnFlags(&opts), args)
+	if err != nil {
+		return h.usageError("pgn", err)
+	}
+	if len(positional) != 2 || positional[0] != "show" {
+		return h.usageError("pgn", i18n.Errorf("pgn.file_required", programName))
+	}
+
+	number := 1
+	if opts.game != "" {
+		if number, err = domain.ParseNumber(opts.game); err != nil {
+			return h.fail(ExitUsage, "pgn.game_number_error", err)
+		}
+		if number < 1 {
+			return h.fail(ExitUsage, "pgn.game_number_min")
+		}
+	}
+	ply := -1
+	if opts.ply != "" {
+		if ply, err = domain.ParseNumber(opts.ply); err != nil {
+			return h.fail(ExitUsage, "pgn.ply_number_error", err)
+		}
+	}
+	h.renderOptions.Coordinates = opts.coords
+	if opts.pieces != "" {
+		if h.renderOptions.Pieces, err = domain.ParsePieceStyle(opts.pieces); err != nil {
+			return h.failErr(err, ExitUsage)
+		}
+	}
+
+	pgnGame, err := readPGNGame(positional[1], number)
+	if err != nil {
+		return h.failErr(err, pgnErrorCode(err))
+	}
+	if ply < 0 {
+		ply = pgnGame.Plies()
+	}
+
+	start, err := pgnGame.StartBoard()
+	if err != nil {
+		return h.fail(ExitPGN, "pgn.game_error", number, i18n.Errorf("pgn.invalid_fen_tag", err))
+	}
+	game, err := h.boardService.NewGame(start)
+	if err != nil {
+		return h.failErr(err, ExitError)
+	}
+	if err := pgnGame.Play(game, ply); err != nil {
+		// Полуход за концом партии - ошибка в аргументах, остальное - ошибка в файле
+		code := ExitPGN
+		if ply > pgnGame.Plies() {
+			code = ExitUsage
+		}
+		return h.fail(code, "pgn.game_error", number, err)
+	}
+
+	h.text.Fprintf(h.out, "pgn.game_header", number,
+		tagOrUnknown(pgnGame, "White"), tagOrUnknown(pgnGame, "Black"), pgnGame.Result)
+	h.text.Fprintf(h.out, "pgn.ply_header", ply, pgnGame.Plies(), lastMoveLabel(h.text, pgnGame, start, ply))
+	return h.displayGame(game)
+}
+
+// readPGNGame читает из файла партию с номером number, начиная с 1. Партии
+// до нее разбираются по одной и не хранятся в памяти.
+func readPGNGame(path string, number int) (*pgn.Game, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, i18n.Errorf("pgn.open_failed", path, err)
+	}
+	defer file.Close()
+
+	reader := pgn.NewReader(file)
+	for read := 0; ; read++ {
+		game, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, &gameNotFoundError{path: path, games: read, number: number}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if read+1 == number {
+			return game, nil
+		}
+	}
+}
+
+// gameNotFoundError сообщает, что в файле меньше партий, чем запрошено
+type gameNotFoundError struct {
+	path   string
+	games  int
+	number int
+}
+
+func (e *gameNotFoundError) Error() string {
+	return e.Localize(i18n.DefaultPrinter())
+}
+
+func (e *gameNotFoundError) Localize(p *i18n.Printer) string {
+	return p.Plural("pgn.game_not_found", e.games, e.path, e.games, e.number)
+}
+
+// pgnErrorCode возвращает код завершения для ошибки чтения партии: в файле нет
+// партии с запрошенным номером, партия разобрана с ошибкой или файл не прочитан
+func pgnErrorCode(err error) int {
+	var notFound *gameNotFoundError
+	var pgnErr *pgn.Error
+	switch {
+	case errors.As(err, &notFound):
+		return ExitUsage
+	case errors.As(err, &pgnErr):
+		return ExitPGN
+	default:
+		return ExitIO
+	}
+}
+
+// tagOrUnknown возвращает значение тега или "?", если тег не задан
+func tagOrUnknown(game *pgn.Game, name string) string {
+	if value := game.Tag(name); value != "" {
+		return value
+	}
+	return "?"
+}
+
+// lastMoveLabel описывает последний сделанный полуход с номером хода: "3. d4"
+// или "3... Bg4"
+func lastMoveLabel(text *i18n.Printer, game *pgn.Game, start *domain.Board, ply int) string {
+	if ply == 0 {
+		return text.Sprintf("pgn.initial_position")
+	}
+	// Номер полухода от начала партии с учетом начальной позиции из тега FEN
+	index := (start.FullmoveNumber-1)*2 + int(start.SideToMove) + ply - 1
+	return numberedMove(index/2+1, domain.Color(index%2), game.MainLine.Moves[ply-1].SAN)
+}
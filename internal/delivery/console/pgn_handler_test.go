@@ -0,0 +1,150 @@
+package console
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"chessboard/internal/usecase"
+)
+
+const pgnFile = `[Event "Первая"]
+[White "Paul Morphy"]
+[Black "Duke Karl"]
+[Result "1-0"]
+
+1. e4 e5 2. Nf3 d6 {Защита Филидора} 3. d4 (3. Bc4) Bg4 1-0
+
+[Event "Вторая"]
+[FEN "4k3/8/8/8/8/8/8/R3K3 b Q - 0 40"]
+
+40... Kd7 41. O-O-O+ *
+`
+
+// writePGN записывает текст во временный файл и возвращает путь к нему
+func writePGN(t *testing.T, text string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "games.pgn")
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatalf("не удалось записать файл: %v", err)
+	}
+	return path
+}
+
+// runPGN выполняет команду pgn с настоящим сервисом, чтобы ходы партии проверялись
+func runPGN(args ...string) string {
+	handler := NewBoardHandler(usecase.NewBoardUsecase(usecase.NewBoardRepository()))
+	var buf bytes.Buffer
+	handler.SetOutput(&buf)
+
+	handler.HandleArgs(append([]string{"pgn"}, args...))
+	return buf.String()
+}
+
+func TestHandlePGN(t *testing.T) {
+	path := writePGN(t, pgnFile)
+
+	testCases := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			"конец первой партии по умолчанию",
+			[]string{"show", path},
+			[]string{
+				"Партия 1: Paul Morphy - Duke Karl (1-0)\n",
+				"Полуход 6 из 6: 3... Bg4\n",
+				"Шахматная доска 8x8:\n",
+				"Статус: ход белых.\n",
+			},
+		},
+		{
+			"полуход первой партии",
+			[]string{"show", path, "--ply", "3"},
+			[]string{"Полуход 3 из 6: 2. Nf3\n", "Статус: ход черных.\n"},
+		},
+		{
+			"начальная позиция",
+			[]string{"show", path, "--ply=0"},
+			[]string{"Полуход 0 из 6: начальная позиция\n"},
+		},
+		{
+			"вторая партия с позиции FEN",
+			[]string{"show", path, "--game", "2"},
+			[]string{"Партия 2: ? - ? (*)\n", "Полуход 2 из 2: 41. O-O-O+\n", "Статус: шах. Ход черных.\n"},
+		},
+		{
+			"ход черных из позиции FEN",
+			[]string{"show", path, "--game", "2", "--ply", "1"},
+			[]string{"Полуход 1 из 2: 40... Kd7\n"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output := runPGN(tc.args...)
+			for _, part := range tc.expected {
+				if !strings.Contains(output, part) {
+					t.Errorf("вывод должен содержать %q, получено:\n%s", part, output)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlePGN_Board(t *testing.T) {
+	path := writePGN(t, pgnFile)
+
+	output := runPGN("show", path, "--ply", "1", "--coords")
+	// После 1. e4 на e4 стоит белая пешка, а на e2 ее больше нет
+	for _, rank := range []string{"4  # #P# # 4\n", "2 PPPP PPP 2\n"} {
+		if !strings.Contains(output, rank) {
+			t.Errorf("ожидалась горизонталь %q после 1. e4:\n%s", rank, output)
+		}
+	}
+	if !strings.Contains(output, "  abcdefgh\n") {
+		t.Errorf("ожидались координаты:\n%s", output)
+	}
+}
+
+func TestHandlePGN_Errors(t *testing.T) {
+	path := writePGN(t, pgnFile)
+	broken := writePGN(t, "1. e4 e5 (1... c5\n*")
+	illegal := writePGN(t, "1. e4 e4 *")
+
+	testCases := []struct {
+		name     string
+		args     []string
+		errorMsg string
+	}{
+		{"без подкоманды", nil, "укажите файл"},
+		{"без файла", []string{"show"}, "укажите файл"},
+		{"неизвестная подкоманда", []string{"list", path}, "укажите файл"},
+		{"нет файла", []string{"show", filepath.Join(t.TempDir(), "нет.pgn")}, "не удалось открыть файл"},
+		{"партии нет в файле", []string{"show", path, "--game", "3"}, "2 партии, запрошена партия 3"},
+		{"нулевая партия", []string{"show", path, "--game", "0"}, "номер партии начинается с 1"},
+		{"неверный номер партии", []string{"show", path, "--game", "вторая"}, "номер партии: неверный формат числа"},
+		{"полуход за концом партии", []string{"show", path, "--ply", "7"}, "в партии 6 полуходов, запрошен полуход 7"},
+		{"отрицательный полуход", []string{"show", path, "--ply", "-1"}, "отрицательные числа не поддерживаются"},
+		{"ошибка разбора", []string{"show", broken}, "неверный PGN: партия 1, строка 2: результат '*' внутри варианта"},
+		{"недопустимый ход", []string{"show", illegal}, "партия 1: полуход 2: недопустимый ход: 'e4'"},
+		{"неизвестный флаг", []string{"show", path, "--fen", "8/8"}, "неизвестный флаг"},
+		{"неверный стиль фигур", []string{"show", path, "--pieces", "emoji"}, "Допустимые значения: letters, unicode"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output := runPGN(tc.args...)
+			if !strings.Contains(output, "Ошибка: ") || !strings.Contains(output, tc.errorMsg) {
+				t.Errorf("ожидалась ошибка с текстом '%s', получено: '%s'", tc.errorMsg, output)
+			}
+			if strings.Contains(output, "Шахматная доска") {
+				t.Errorf("при ошибке доска не должна выводиться:\n%s", output)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package console
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"strings"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+	"chessboard/internal/notation"
+)
+
+// playSession - партия интерактивного режима и позиция, с которой она начата
+type playSession struct {
+	game  domain.Game
+	start *domain.Board
+}
+
+// playOptions содержит флаги команды play
+type playOptions struct {
+	fen string
+	// view - флаги вида доски; размер, формат и расстановка в play не задаются
+	view       cliOptions
+	fullScreen bool
+}
+
+// playFlags создает набор флагов команды play
+func playFlags(opts *playOptions) *flag.FlagSet {
+	fs := newFlagSet("play")
+	fs.StringVar(&opts.fen, "fen", "", "flag.play_fen")
+	fs.StringVar(&opts.view.pattern, "pattern", "", "flag.pattern")
+	fs.StringVar(&opts.view.orientation, "orientation", "", "flag.orientation")
+	fs.StringVar(&opts.view.theme, "theme", "", "flag.theme")
+	fs.StringVar(&opts.view.palette, "palette", "", "flag.palette")
+	fs.StringVar(&opts.view.light, "light", "", "flag.light")
+	fs.StringVar(&opts.view.dark, "dark", "", "flag.dark")
+	fs.StringVar(&opts.view.pieces, "pieces", "", "flag.pieces")
+	fs.BoolVar(&opts.view.coords, "coords", false, "flag.coords")
+	fs.BoolVar(&opts.fullScreen, "tui", false, "flag.tui")
+	return fs
+}
+
+// HandlePlay обрабатывает команду "play [--fen FEN] [--tui]": читает ходы и команды
+// по одной на строку и перерисовывает доску после каждой. Ввод может быть
+// перенаправлен из файла или другой программы; тогда приглашение не выводится,
+// а пустые строки и строки, начинающиеся с '#', пропускаются. С флагом --tui
+// запускается полноэкранный режим с управлением курсором. Ошибки в ходах не
+// прерывают партию и не влияют на код завершения, кроме строгого режима: в нем
+// первая ошибка завершает партию с кодом ExitMove.
+func (h *BoardHandler) HandlePlay(args []string) int {
+	var opts playOptions
+	positional, err := parseFlagSet(playFlags(&opts), args)
+	if err != nil {
+		return h.usageError("play", err)
+	}
+	if len(positional) > 0 {
+		return h.usageError("play", i18n.Errorf("cli.extra_argument", positional[0]))
+	}
+	if code := h.applyViewOptions(opts.view); code != ExitOK {
+		return code
+	}
+	if opts.view.pattern != "" {
+		if err := h.boardService.SetPattern(opts.view.pattern); err != nil {
+			return h.failErr(err, ExitUsage)
+		}
+	}
+	if opts.fen == "" {
+		opts.fen = domain.StartFEN
+	}
+
+	start, err := h.boardService.LoadFEN(opts.fen)
+	if err != nil {
+		return h.failErr(err, ExitFEN)
+	}
+	session := &playSession{start: start}
+	if session.game, err = h.boardService.NewGame(start); err != nil {
+		return h.failErr(err, ExitError)
+	}
+
+	if opts.fullScreen {
+		return h.HandleTUI(session.game)
+	}
+
+	interactive := isTerminal(h.in)
+	if interactive {
+		h.text.Fprintf(h.out, "play.prompt")
+	}
+	h.displayGame(session.game)
+
+	scanner := bufio.NewScanner(h.in)
+	for {
+		if interactive {
+			fmt.Fprint(h.out, "> ")
+		}
+		if !scanner.Scan() {
+			break
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if done, code := h.playCommand(session, line); done {
+			return code
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return h.fail(ExitIO, "play.read_error", err)
+	}
+	return ExitOK
+}
+
+// playCommand выполняет одну команду или ход. Возвращает true и код завершения,
+// если нужно выйти.
+func (h *BoardHandler) playCommand(session *playSession, line string) (bool, int) {
+	game := session.game
+	switch line {
+	case "quit", "exit":
+		return true, ExitOK
+	case "help":
+		h.text.Fprintf(h.out, "play.help")
+	case "fen":
+		fmt.Fprintln(h.out, game.Board().FEN())
+	case "flip":
+		if h.renderOptions.Orientation == domain.OrientationWhite {
+			h.renderOptions.Orientation = domain.OrientationBlack
+		} else {
+			h.renderOptions.Orientation = domain.OrientationWhite
+		}
+		h.displayGame(game)
+	case "new":
+		newGame, err := h.boardService.NewGame(session.start)
+		if err != nil {
+			return h.playError(h.failErr(err, ExitError))
+		}
+		session.game = newGame
+		h.displayGame(newGame)
+	case "undo":
+		move, ok := game.Undo()
+		if !ok {
+			return h.playError(h.fail(ExitMove, "play.no_undo"))
+		}
+		h.text.Fprintf(h.out, "play.undone", moveLabel(game, move))
+		h.displayGame(game)
+	default:
+		move, err := notation.ParseMove(game, line)
+		if err != nil {
+			if h.strict {
+				return h.playError(h.failErr(err, ExitMove))
+			}
+			return h.playError(h.fail(ExitMove, "play.move_error_hint", err))
+		}
+		label := moveLabel(game, move)
+		if err := game.Play(move); err != nil {
+			return h.playError(h.failErr(err, ExitMove))
+		}
+		h.text.Fprintf(h.out, "play.move", label)
+		h.displayGame(game)
+	}
+	return false, ExitOK
+}
+
+// playError завершает команду после ошибки, уже выведенной через fail или
+// failErr с кодом code. В обычном режиме партия продолжается, в строгом -
+// завершается с этим кодом.
+func (h *BoardHandler) playError(code int) (bool, int) {
+	if h.strict {
+		return true, code
+	}
+	return false, ExitOK
+}
+
+// moveLabel записывает легальный ход партии в нотации SAN с номером хода:
+// "1. e4" или "1... e5". Если записать ход не удалось, используется UCI.
+func moveLabel(game domain.Game, move domain.Move) string {
+	san, err := notation.FormatSAN(game, move)
+	if err != nil {
+		san = notation.FormatUCI(move)
+	}
+	board := game.Board()
+	return numberedMove(board.FullmoveNumber, board.SideToMove, san)
+}
+
+// numberedMove добавляет к записи хода номер: "N." для хода белых и "N..." для черных
+func numberedMove(fullmove int, side domain.Color, san string) string {
+	if side == domain.Black {
+		return fmt.Sprintf("%d... %s", fullmove, san)
+	}
+	return fmt.Sprintf("%d. %s", fullmove, san)
+}
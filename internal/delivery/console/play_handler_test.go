@@ -0,0 +1,210 @@
+package console
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/usecase"
+)
+
+// runPlay выполняет команду play с настоящим сервисом, подавая input на вход
+func runPlay(input string, args ...string) string {
+	handler := NewBoardHandler(usecase.NewBoardUsecase(usecase.NewBoardRepository()))
+	var buf bytes.Buffer
+	handler.SetOutput(&buf)
+	handler.SetInput(strings.NewReader(input))
+
+	handler.HandleArgs(append([]string{"play"}, args...))
+	return buf.String()
+}
+
+func TestHandlePlay(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		args     []string
+		expected []string
+	}{
+		{
+			"ходы в SAN и UCI",
+			"e4\ne7e5\nNf3\n",
+			nil,
+			[]string{"Ход: 1. e4\n", "Ход: 1... e5\n", "Ход: 2. Nf3\n", "Статус: ход черных.\n"},
+		},
+		{
+			"отмена хода",
+			"e4\ne5\nundo\nfen\n",
+			nil,
+			[]string{"Отменен ход: 1... e5\n", "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1\n"},
+		},
+		{
+			"новая партия с позиции FEN",
+			"Kd7\nnew\nfen\n",
+			[]string{"--fen", "4k3/8/8/8/8/8/8/R3K3 b Q - 0 40"},
+			[]string{"Ход: 40... Kd7\n", "4k3/8/8/8/8/8/8/R3K3 b Q - 0 40\n"},
+		},
+		{
+			"мат",
+			"f3\ne5\ng4\nQh4#\n",
+			nil,
+			[]string{"Ход: 2... Qh4#\n", "Статус: мат. Победили черные (0-1).\n"},
+		},
+		{
+			"троекратное повторение",
+			"Nf3\nNf6\nNg1\nNg8\nNf3\nNf6\nNg1\nNg8\n",
+			nil,
+			[]string{"Можно потребовать ничью: позиция повторилась трижды."},
+		},
+		{
+			"комментарии и пустые строки",
+			"# сценарий\n\n  d4  \n",
+			nil,
+			[]string{"Ход: 1. d4\n"},
+		},
+		{
+			"справка",
+			"help\n",
+			nil,
+			[]string{"undo   отменить последний ход"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output := runPlay(tc.input, tc.args...)
+			for _, part := range tc.expected {
+				if !strings.Contains(output, part) {
+					t.Errorf("вывод должен содержать %q, получено:\n%s", part, output)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlePlay_Redraw(t *testing.T) {
+	output := runPlay("e4\nfen\nflip\n")
+
+	// Начальная доска, доска после хода и перевернутая доска
+	if count := strings.Count(output, "Шахматная доска 8x8:\n"); count != 3 {
+		t.Errorf("ожидалось 3 доски, получено %d:\n%s", count, output)
+	}
+	if strings.Contains(output, "> ") || strings.Contains(output, "help - список команд") {
+		t.Errorf("при вводе не из терминала приглашение не выводится:\n%s", output)
+	}
+
+	boards := strings.Split(output, "Шахматная доска 8x8:\n")
+	if !strings.HasPrefix(boards[3], "RNBKQBNR\n") {
+		t.Errorf("после flip доска должна быть видна со стороны черных:\n%s", boards[3])
+	}
+}
+
+func TestHandlePlay_Quit(t *testing.T) {
+	output := runPlay("quit\ne4\n")
+	if strings.Contains(output, "Ход:") {
+		t.Errorf("после quit команды не выполняются:\n%s", output)
+	}
+
+	output = runPlay("exit\ne4\n")
+	if strings.Contains(output, "Ход:") {
+		t.Errorf("после exit команды не выполняются:\n%s", output)
+	}
+}
+
+func TestHandlePlay_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		args     []string
+		errorMsg string
+	}{
+		{"недопустимый ход", "e5\n", nil, "Ошибка: недопустимый ход: 'e5'. Список команд: help.\n"},
+		{"неизвестная команда", "draw\n", nil, "Ошибка: неверная запись хода: 'draw'"},
+		{"отмена без ходов", "undo\n", nil, "Ошибка: нет ходов для отмены.\n"},
+		{"неверный FEN", "", []string{"--fen", "8/8/8/8/8/8/8/9 w - - 0 1"}, "неверный FEN"},
+		{"лишний аргумент", "", []string{"8"}, "Ошибка: лишний аргумент: '8'.\n"},
+		{"неизвестный флаг", "", []string{"--size", "8"}, "неизвестный флаг"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output := runPlay(tc.input, tc.args...)
+			if !strings.Contains(output, tc.errorMsg) {
+				t.Errorf("ожидалась ошибка с текстом '%s', получено: '%s'", tc.errorMsg, output)
+			}
+		})
+	}
+}
+
+func TestHandlePlay_Strict(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  string
+		code   int
+		output string
+	}{
+		{"сценарий без ошибок", "e4\ne5\n", ExitOK, "Ход: 1... e5\n"},
+		{"недопустимый ход прерывает партию", "e4\ne4\nd5\n", ExitMove, `"class":"move"`},
+		{"отмена без ходов", "undo\n", ExitMove, "нет ходов для отмены"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewBoardHandler(usecase.NewBoardUsecase(usecase.NewBoardRepository()))
+			handler.SetStrict(true)
+			var out, errOut bytes.Buffer
+			handler.SetOutput(&out)
+			handler.SetErrorOutput(&errOut)
+			handler.SetInput(strings.NewReader(tc.input))
+
+			code := handler.HandleArgs([]string{"play"})
+			if code != tc.code {
+				t.Errorf("ожидался код завершения %d, получено %d", tc.code, code)
+			}
+			if !strings.Contains(out.String()+errOut.String(), tc.output) {
+				t.Errorf("ожидалось %q, получено:\n%s\n%s", tc.output, out.String(), errOut.String())
+			}
+			if strings.Contains(out.String(), "d5") {
+				t.Errorf("после ошибки ходы не выполняются:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestHandlePlay_ContinuesAfterError(t *testing.T) {
+	output := runPlay("e5\ne4\n")
+	if !strings.Contains(output, "Ошибка: недопустимый ход") || !strings.Contains(output, "Ход: 1. e4\n") {
+		t.Errorf("после ошибки ввод должен продолжаться:\n%s", output)
+	}
+}
+
+// errorReader возвращает ошибку при первом чтении
+type errorReader struct{ err error }
+
+func (r errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func TestHandlePlay_ReadError(t *testing.T) {
+	handler := NewBoardHandler(usecase.NewBoardUsecase(usecase.NewBoardRepository()))
+	var buf bytes.Buffer
+	handler.SetOutput(&buf)
+	handler.SetInput(errorReader{errors.New("обрыв связи")})
+
+	handler.HandleArgs([]string{"play"})
+
+	if !strings.Contains(buf.String(), "Ошибка: ошибка чтения ввода: обрыв связи.\n") {
+		t.Errorf("ожидалась ошибка чтения, получено:\n%s", buf.String())
+	}
+}
+
+func TestNumberedMove(t *testing.T) {
+	if got := numberedMove(12, domain.White, "Nf3"); got != "12. Nf3" {
+		t.Errorf("ожидалось %q, получено %q", "12. Nf3", got)
+	}
+	if got := numberedMove(12, domain.Black, "O-O"); got != "12... O-O" {
+		t.Errorf("ожидалось %q, получено %q", "12... O-O", got)
+	}
+}
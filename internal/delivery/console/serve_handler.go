@@ -0,0 +1,72 @@
+package console
+
+import (
+	"context"
+	"flag"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	httpdelivery "chessboard/internal/delivery/http"
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+// serveOptions содержит флаги команды serve
+type serveOptions struct {
+	addr    string
+	maxSize string
+}
+
+// serveFlags создает набор флагов команды serve
+func serveFlags(opts *serveOptions) *flag.FlagSet {
+	fs := newFlagSet("serve")
+	fs.StringVar(&opts.addr, "addr", "localhost:8080", "flag.addr")
+	fs.StringVar(&opts.maxSize, "max-size", "", "flag.max_size")
+	return fs
+}
+
+// serveSignals - сигналы, по которым сервер останавливается
+var serveSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// HandleServe обрабатывает команду "serve [--addr АДРЕС]": запускает сервер HTTP
+// с тем же сервисом досок и останавливает его по SIGINT или SIGTERM, дождавшись
+// завершения начатых запросов
+func (h *BoardHandler) HandleServe(args []string) int {
+	var opts serveOptions
+	positional, err := parseFlagSet(serveFlags(&opts), args)
+	if err != nil {
+		return h.usageError("serve", err)
+	}
+	if len(positional) > 0 {
+		return h.usageError("serve", i18n.Errorf("cli.extra_argument", positional[0]))
+	}
+	config := httpdelivery.DefaultConfig()
+	if opts.maxSize != "" {
+		if config.MaxBoardSize, err = domain.ParseNumber(opts.maxSize); err != nil {
+			return h.fail(ExitUsage, "serve.max_size_error", err)
+		}
+		if err := domain.ValidateSize(config.MaxBoardSize, config.MaxBoardSize); err != nil {
+			return h.failErr(err, ExitUsage)
+		}
+	}
+
+	// Сигналы перехватываются до сообщения о запуске: после него сервер уже
+	// можно останавливать
+	ctx, stop := signal.NotifyContext(context.Background(), serveSignals...)
+	defer stop()
+
+	listener, err := net.Listen("tcp", opts.addr)
+	if err != nil {
+		return h.fail(ExitIO, "serve.listen_error", opts.addr, err)
+	}
+	h.text.Fprintf(h.out, "serve.listening", listener.Addr())
+
+	server := httpdelivery.NewServer(h.boardService, config)
+	if err := server.Serve(ctx, listener); err != nil {
+		return h.fail(ExitIO, "serve.error", err)
+	}
+	h.text.Fprintf(h.out, "serve.stopped")
+	return ExitOK
+}
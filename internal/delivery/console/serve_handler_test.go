@@ -0,0 +1,105 @@
+package console
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"chessboard/internal/usecase"
+)
+
+// syncBuffer - буфер, в который можно писать из одной горутины и читать из другой
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestHandleServe(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("сигнал остановки нельзя отправить своему процессу в Windows")
+	}
+	handler := NewBoardHandler(usecase.NewBoardUsecase(usecase.NewBoardRepository()))
+	var out syncBuffer
+	handler.SetOutput(&out)
+
+	done := make(chan int, 1)
+	go func() {
+		done <- handler.HandleArgs([]string{"serve", "--addr", "127.0.0.1:0"})
+	}()
+
+	// Адрес с выбранным системой портом выводится после запуска сервера
+	var addr string
+	for deadline := time.Now().Add(5 * time.Second); addr == ""; {
+		if line, found := strings.CutPrefix(out.String(), "Сервер запущен: "); found {
+			addr = strings.TrimSpace(line)
+		} else if time.Now().After(deadline) {
+			t.Fatalf("сервер не запустился, вывод: %q", out.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	response, err := http.Get(addr + "/board?size=4")
+	if err != nil {
+		t.Fatalf("сервер не ответил: %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("ожидался статус 200, получено %d", response.StatusCode)
+	}
+
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case code := <-done:
+		if code != ExitOK || !strings.HasSuffix(out.String(), "Сервер остановлен.\n") {
+			t.Errorf("ожидалась остановка с кодом 0, получено %d; вывод: %q", code, out.String())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("сервер не остановился по сигналу")
+	}
+}
+
+func TestHandleServe_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		code     int
+		expected string
+	}{
+		{"лишний аргумент", []string{"serve", "8080"}, ExitUsage, "лишний аргумент: '8080'"},
+		{"неверный размер", []string{"serve", "--max-size", "много"}, ExitUsage, "неверный наибольший размер доски"},
+		{"размер вне пределов", []string{"serve", "--max-size", "2"}, ExitSize, "не может быть меньше 4"},
+		{"неверный адрес", []string{"serve", "--addr", "127.0.0.1:99999"}, ExitIO, "не удалось открыть адрес '127.0.0.1:99999'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, output := runCommand(&MockBoardService{}, tc.args...)
+			if code != tc.code || !strings.Contains(output, tc.expected) {
+				t.Errorf("ожидались код %d и %q, получено %d:\n%s", tc.code, tc.expected, code, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package console
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// themeAuto - псевдотема, выбирающая оформление по возможностям терминала
+const themeAuto = "auto"
+
+// resolveTheme заменяет тему "auto" на цветную для терминала и на ASCII,
+// если вывод перенаправлен или задана переменная окружения NO_COLOR
+func resolveTheme(name string, out io.Writer) string {
+	if !strings.EqualFold(name, themeAuto) {
+		return name
+	}
+	if os.Getenv("NO_COLOR") != "" || !isTerminal(out) {
+		return "ascii"
+	}
+	return "ansi"
+}
+
+// isTerminal сообщает, является ли поток ввода или вывода терминалом. Признака
+// символьного устройства недостаточно: им является и /dev/null, - поэтому
+// у терминала запрашиваются его параметры (см. isTerminalFD).
+func isTerminal(stream any) bool {
+	file, ok := stream.(*os.File)
+	if !ok {
+		return false
+	}
+	return isTerminalFD(file.Fd())
+}
@@ -0,0 +1,16 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package console
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// isTerminalFD сообщает, что дескриптор fd - терминал: только терминал
+// отвечает на запрос параметров TIOCGETA
+func isTerminalFD(fd uintptr) bool {
+	var termios syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TIOCGETA, uintptr(unsafe.Pointer(&termios)))
+	return errno == 0
+}
@@ -0,0 +1,15 @@
+//go:build linux
+
+package console
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// isTerminalFD сообщает, что дескриптор fd - терминал: только терминал
+// отвечает на запрос параметров TCGETS
+func isTerminalFD(fd uintptr) bool {
+	var termios syscall.Termios
+	return ioctl(int(fd), syscall.TCGETS, unsafe.Pointer(&termios)) == nil
+}
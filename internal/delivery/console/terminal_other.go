@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd && !windows
+
+package console
+
+// isTerminalFD на остальных системах считает, что дескриптор не терминал:
+// без проверки надежнее строгий режим и вывод без цвета
+func isTerminalFD(fd uintptr) bool {
+	return false
+}
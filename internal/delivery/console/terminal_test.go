@@ -0,0 +1,64 @@
+package console
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestResolveTheme(t *testing.T) {
+	t.Run("явная тема не меняется", func(t *testing.T) {
+		if theme := resolveTheme("unicode", &bytes.Buffer{}); theme != "unicode" {
+			t.Errorf("ожидалась тема 'unicode', получено '%s'", theme)
+		}
+	})
+
+	t.Run("auto без терминала", func(t *testing.T) {
+		if theme := resolveTheme(themeAuto, &bytes.Buffer{}); theme != "ascii" {
+			t.Errorf("для вывода не в терминал ожидалась тема 'ascii', получено '%s'", theme)
+		}
+	})
+
+	t.Run("AUTO в другом регистре", func(t *testing.T) {
+		if theme := resolveTheme("AUTO", &bytes.Buffer{}); theme != "ascii" {
+			t.Errorf("для вывода не в терминал ожидалась тема 'ascii', получено '%s'", theme)
+		}
+	})
+
+	t.Run("auto с NO_COLOR", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		if theme := resolveTheme(themeAuto, &bytes.Buffer{}); theme != "ascii" {
+			t.Errorf("при NO_COLOR ожидалась тема 'ascii', получено '%s'", theme)
+		}
+	})
+}
+
+func TestIsTerminal(t *testing.T) {
+	// /dev/null - символьное устройство, но не терминал
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatalf("не удалось открыть %s: %v", os.DevNull, err)
+	}
+	defer devNull.Close()
+	file, err := os.CreateTemp(t.TempDir(), "board")
+	if err != nil {
+		t.Fatalf("не удалось создать файл: %v", err)
+	}
+	defer file.Close()
+
+	testCases := []struct {
+		name   string
+		stream any
+	}{
+		{"буфер", &bytes.Buffer{}},
+		{"пустое устройство", devNull},
+		{"файл", file},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if isTerminal(tc.stream) {
+				t.Error("поток не должен считаться терминалом")
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+//go:build windows
+
+package console
+
+import "syscall"
+
+// isTerminalFD сообщает, что дескриптор fd - консоль: режим удается
+// получить только у консоли
+func isTerminalFD(fd uintptr) bool {
+	var mode uint32
+	return syscall.GetConsoleMode(syscall.Handle(fd), &mode) == nil
+}
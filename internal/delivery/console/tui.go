@@ -0,0 +1,476 @@
+package console
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+// tuiKey - клавиша полноэкранного режима
+type tuiKey int
+
+const (
+	keyUp tuiKey = iota
+	keyDown
+	keyLeft
+	keyRight
+	// keySelect выбирает фигуру или подтверждает ход на клетку под курсором
+	keySelect
+	// keyCancel снимает выбор фигуры
+	keyCancel
+	keyUndo
+	keyFlip
+	// keyQuit завершает режим, а при выборе превращения выбирает ферзя
+	keyQuit
+	// keyInterrupt - Ctrl+C: завершает режим всегда
+	keyInterrupt
+	// keyRook, keyBishop и keyKnight выбирают фигуру превращения
+	keyRook
+	keyBishop
+	keyKnight
+)
+
+// Escape-последовательности терминала для полноэкранного режима
+const (
+	ansiClearScreen     = "\x1b[H\x1b[2J"
+	ansiAlternateScreen = "\x1b[?1049h\x1b[?25l"
+	ansiMainScreen      = "\x1b[?25h\x1b[?1049l"
+	ansiResetColors     = "\x1b[0m"
+)
+
+// Цвета подсветки клеток поверх узора доски
+const (
+	tuiCursorColor   = "#ffd700"
+	tuiSelectedColor = "#5faf5f"
+	tuiTargetColor   = "#5fafd7"
+)
+
+// tuiScreen - состояние полноэкранного режима: партия, параметры отрисовки,
+// курсор, выбранная фигура и размер окна терминала
+type tuiScreen struct {
+	text    *i18n.Printer
+	service domain.BoardService
+	game    domain.Game
+	// opts - параметры отрисовки доски; подсветка клеток добавляется при выводе
+	opts   domain.RenderOptions
+	cursor domain.Square
+	// selected - клетка выбранной фигуры; действительна, если hasSelection
+	selected     domain.Square
+	hasSelection bool
+	// promotions - ходы-превращения выбранной пешки на клетку под курсором,
+	// пока игрок выбирает фигуру; nil, если выбора нет
+	promotions []domain.Move
+	message    string
+	// width и height - размер окна в символах; 0 означает, что размер неизвестен
+	width, height int
+}
+
+// newTUIScreen создает экран с курсором на клетке короля стороны, имеющей очередь
+// хода, или в левом нижнем углу, если короля нет. Доска рисуется сервисом service
+// с параметрами opts в цветной теме ansi с координатами: подсветка курсора
+// и ходов видна только в цвете. Сообщения выводятся через text.
+func newTUIScreen(text *i18n.Printer, service domain.BoardService, game domain.Game, opts domain.RenderOptions) *tuiScreen {
+	opts.Format = domain.FormatText
+	opts.Theme = "ansi"
+	opts.Coordinates = true
+	s := &tuiScreen{text: text, service: service, game: game, opts: opts}
+	board := game.Board()
+	king := domain.Piece{Color: board.SideToMove, Kind: domain.King}
+	for i, piece := range board.Squares {
+		if piece == king {
+			s.cursor = domain.Square{File: i % board.Width, Rank: i / board.Width}
+		}
+	}
+	return s
+}
+
+// decodeKeys переводит байты, прочитанные из терминала, в клавиши. Стрелки
+// приходят escape-последовательностями "ESC [ A" или "ESC O A"; одиночный ESC
+// означает отмену. Неизвестные байты пропускаются.
+func decodeKeys(data []byte) []tuiKey {
+	var keys []tuiKey
+	for i := 0; i < len(data); i++ {
+		switch c := data[i]; c {
+		case 0x1b:
+			if i+2 < len(data) && (data[i+1] == '[' || data[i+1] == 'O') {
+				if key, ok := arrowKeys[data[i+2]]; ok {
+					keys = append(keys, key)
+				}
+				i += 2
+				continue
+			}
+			keys = append(keys, keyCancel)
+		case '\r', '\n', ' ':
+			keys = append(keys, keySelect)
+		case 'k', 'w':
+			keys = append(keys, keyUp)
+		case 'j', 's':
+			keys = append(keys, keyDown)
+		case 'h', 'a':
+			keys = append(keys, keyLeft)
+		case 'l', 'd':
+			keys = append(keys, keyRight)
+		case 'u':
+			keys = append(keys, keyUndo)
+		case 'f':
+			keys = append(keys, keyFlip)
+		case 'q':
+			keys = append(keys, keyQuit)
+		case 0x03:
+			// Ctrl+C: в сыром режиме он не превращается в сигнал
+			keys = append(keys, keyInterrupt)
+		case 'r':
+			keys = append(keys, keyRook)
+		case 'b':
+			keys = append(keys, keyBishop)
+		case 'n':
+			keys = append(keys, keyKnight)
+		}
+	}
+	return keys
+}
+
+// arrowKeys - последние байты escape-последовательностей стрелок
+var arrowKeys = map[byte]tuiKey{'A': keyUp, 'B': keyDown, 'C': keyRight, 'D': keyLeft}
+
+// handleKey выполняет действие клавиши. Возвращает true, если нужно выйти.
+func (s *tuiScreen) handleKey(key tuiKey) bool {
+	if s.promotions != nil {
+		return s.choosePromotion(key)
+	}
+	switch key {
+	case keyQuit, keyInterrupt:
+		return true
+	case keyUp:
+		s.moveCursor(0, 1)
+	case keyDown:
+		s.moveCursor(0, -1)
+	case keyLeft:
+		s.moveCursor(-1, 0)
+	case keyRight:
+		s.moveCursor(1, 0)
+	case keyCancel:
+		s.hasSelection = false
+		s.message = ""
+	case keyFlip:
+		if s.opts.Orientation == domain.OrientationWhite {
+			s.opts.Orientation = domain.OrientationBlack
+		} else {
+			s.opts.Orientation = domain.OrientationWhite
+		}
+	case keyUndo:
+		s.hasSelection = false
+		move, ok := s.game.Undo()
+		if !ok {
+			s.message = s.text.Sprintf("tui.no_undo")
+			return false
+		}
+		s.message = s.text.Sprintf("tui.undone", moveLabel(s.game, move))
+	case keySelect:
+		s.selectSquare()
+	}
+	return false
+}
+
+// moveCursor сдвигает курсор на экране: вверх, вниз, влево или вправо с точки
+// зрения игрока. Для доски, перевернутой к черным, направления меняются.
+func (s *tuiScreen) moveCursor(right, up int) {
+	if s.opts.Orientation == domain.OrientationBlack {
+		right, up = -right, -up
+	}
+	board := s.game.Board()
+	s.cursor.File = min(max(s.cursor.File+right, 0), board.Width-1)
+	s.cursor.Rank = min(max(s.cursor.Rank+up, 0), board.Height-1)
+}
+
+// selectSquare выбирает фигуру под курсором или делает выбранной фигурой ход
+// на клетку под курсором
+func (s *tuiScreen) selectSquare() {
+	board := s.game.Board()
+	piece := board.PieceAt(s.cursor.File, s.cursor.Rank)
+
+	switch {
+	case s.hasSelection && s.cursor == s.selected:
+		s.hasSelection = false
+		s.message = ""
+		return
+	case !piece.IsEmpty() && piece.Color == board.SideToMove:
+		if len(s.targets(s.cursor)) == 0 {
+			s.message = s.text.Sprintf("tui.no_moves")
+			return
+		}
+		s.selected, s.hasSelection = s.cursor, true
+		s.message = ""
+		return
+	case !s.hasSelection:
+		s.message = s.text.Sprintf("tui.select_piece", sideNames[board.SideToMove].genitive)
+		return
+	}
+
+	var moves []domain.Move
+	for _, move := range s.game.LegalMoves() {
+		if move.From == s.selected && move.To == s.cursor {
+			moves = append(moves, move)
+		}
+	}
+	switch len(moves) {
+	case 0:
+		s.message = s.text.Sprintf("tui.illegal_move")
+	case 1:
+		s.playMove(moves[0])
+	default:
+		// Несколько ходов между одними клетками бывают только у превращения
+		s.promotions = moves
+		s.message = s.text.Sprintf("tui.promotion")
+	}
+}
+
+// choosePromotion обрабатывает клавишу во время выбора фигуры превращения:
+// q или Enter - ферзь, r, b и n - ладья, слон и конь, Esc отменяет ход.
+// Ctrl+C завершает режим, остальные клавиши не действуют. Возвращает true,
+// если нужно выйти.
+func (s *tuiScreen) choosePromotion(key tuiKey) bool {
+	var kind domain.PieceKind
+	switch key {
+	case keyInterrupt:
+		return true
+	case keyCancel:
+		s.promotions = nil
+		s.message = ""
+		return false
+	case keyQuit, keySelect:
+		kind = domain.Queen
+	case keyRook:
+		kind = domain.Rook
+	case keyBishop:
+		kind = domain.Bishop
+	case keyKnight:
+		kind = domain.Knight
+	default:
+		return false
+	}
+	for _, move := range s.promotions {
+		if move.Promotion == kind {
+			s.playMove(move)
+			return false
+		}
+	}
+	return false
+}
+
+// playMove делает ход и сообщает о нем
+func (s *tuiScreen) playMove(move domain.Move) {
+	s.promotions = nil
+	label := moveLabel(s.game, move)
+	if err := s.game.Play(move); err != nil {
+		s.message = s.text.Error(err)
+		return
+	}
+	s.hasSelection = false
+	s.message = s.text.Sprintf("tui.move", label)
+}
+
+// targets возвращает клетки, на которые может пойти фигура с клетки from
+func (s *tuiScreen) targets(from domain.Square) map[domain.Square]bool {
+	targets := make(map[domain.Square]bool)
+	for _, move := range s.game.LegalMoves() {
+		if move.From == from {
+			targets[move.To] = true
+		}
+	}
+	return targets
+}
+
+// renderBoard рисует доску с подсветкой курсора, выбранной фигуры и клеток,
+// куда она может пойти. Строки доски заканчиваются "\r\n": в сыром режиме
+// терминал не переводит "\n" в возврат каретки.
+func (s *tuiScreen) renderBoard() (string, error) {
+	highlights := make(map[domain.Square]string)
+	if s.hasSelection {
+		for square := range s.targets(s.selected) {
+			highlights[square] = tuiTargetColor
+		}
+		highlights[s.selected] = tuiSelectedColor
+	}
+	highlights[s.cursor] = tuiCursorColor
+
+	opts := s.opts
+	opts.Highlights = highlights
+	var board strings.Builder
+	if err := s.service.Render(&board, s.game.Board(), opts); err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(board.String(), "\n", "\r\n") + "\r\n", nil
+}
+
+// requiredSize возвращает размер окна, в который помещается экран с доской
+// board: строка состояния, доска, сообщение и подсказка. Ширина считается по
+// строкам доски без escape-последовательностей цвета.
+func requiredSize(board string) (int, int) {
+	lines := strings.Split(strings.TrimSuffix(board, "\r\n"), "\r\n")
+	width := 0
+	for _, line := range lines {
+		width = max(width, visibleWidth(line))
+	}
+	return width, len(lines) + 3
+}
+
+// visibleWidth возвращает число символов строки, видимых в терминале:
+// escape-последовательности вида "ESC [ ... m" не учитываются
+func visibleWidth(line string) int {
+	width := 0
+	for i := 0; i < len(line); {
+		if strings.HasPrefix(line[i:], "\x1b[") {
+			end := strings.IndexByte(line[i:], 'm')
+			if end < 0 {
+				break
+			}
+			i += end + 1
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(line[i:])
+		width++
+		i += size
+	}
+	return width
+}
+
+// render рисует экран целиком. В сыром режиме терминал не переводит "\n"
+// в возврат каретки, поэтому строки заканчиваются "\r\n".
+func (s *tuiScreen) render(w io.Writer) error {
+	board, err := s.renderBoard()
+	if err != nil {
+		return err
+	}
+
+	var frame strings.Builder
+	frame.WriteString(ansiClearScreen)
+	needWidth, needHeight := requiredSize(board)
+	if s.width > 0 && s.height > 0 && (s.width < needWidth || s.height < needHeight) {
+		s.text.Fprintf(&frame, "tui.window_too_small", needWidth, needHeight, s.width, s.height)
+		_, err := io.WriteString(w, frame.String())
+		return err
+	}
+
+	s.text.Fprintf(&frame, "tui.status", statusLine(s.text, s.game.Status()))
+	frame.WriteString(board)
+	fmt.Fprintf(&frame, "%s\r\n%s\r\n", s.message, s.text.Sprintf("tui.help"))
+	_, err = io.WriteString(w, frame.String())
+	return err
+}
+
+// tuiTerminal - терминал в сыром режиме: восстановление прежнего режима,
+// уведомления об изменении размера окна, запрос текущего размера и сигналы
+// завершения процесса
+type tuiTerminal struct {
+	restore func() error
+	resize  <-chan struct{}
+	size    func() (int, int, error)
+	// stop получает сигналы SIGTERM, SIGHUP и SIGINT: без перехвата они
+	// завершили бы процесс, не вернув терминал в обычный режим
+	stop <-chan os.Signal
+}
+
+// errNotTerminal сообщает, что полноэкранный режим запущен не в терминале
+var errNotTerminal = i18n.New("tui.not_terminal")
+
+// runTUI выводит экран и обрабатывает клавиши и изменения размера окна, пока
+// пользователь не выйдет, не закончится ввод или процесс не получит сигнал
+// завершения
+func (h *BoardHandler) runTUI(screen *tuiScreen, term *tuiTerminal) error {
+	if term.size != nil {
+		screen.width, screen.height, _ = term.size()
+	}
+
+	input := make(chan []byte)
+	readErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := h.in.Read(buf)
+			if n > 0 {
+				select {
+				case input <- append([]byte(nil), buf[:n]...):
+				case <-done:
+					return
+				}
+			}
+			if err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		if err := screen.render(h.out); err != nil {
+			return err
+		}
+		select {
+		case data := <-input:
+			for _, key := range decodeKeys(data) {
+				if screen.handleKey(key) {
+					return nil
+				}
+			}
+		case sig := <-term.stop:
+			return i18n.Errorf("tui.terminated", sig)
+		case <-term.resize:
+			if term.size != nil {
+				screen.width, screen.height, _ = term.size()
+			}
+		case err := <-readErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// HandleTUI запускает полноэкранный режим для партии game: переводит терминал
+// в сырой режим, переключается на дополнительный экран и восстанавливает
+// терминал при выходе. Доска рисуется с узором и параметрами отрисовки
+// обработчика; неверные параметры проверяются до включения сырого режима.
+func (h *BoardHandler) HandleTUI(game domain.Game) int {
+	screen := newTUIScreen(h.text, h.boardService, game, h.renderOptions)
+	if _, err := screen.renderBoard(); err != nil {
+		return h.failErr(err, ExitUsage)
+	}
+	term, err := openTerminal(h.in, h.out)
+	if err != nil {
+		return h.failErr(err, ExitIO)
+	}
+
+	if err := h.runRaw(term, func() error { return h.runTUI(screen, term) }); err != nil {
+		return h.failErr(err, ExitIO)
+	}
+	return ExitOK
+}
+
+// runRaw выполняет run на дополнительном экране терминала term в сыром режиме,
+// затем возвращает основной экран и восстанавливает режим терминала. Терминал
+// восстанавливается и при панике в run: паника пробрасывается дальше уже после
+// восстановления.
+func (h *BoardHandler) runRaw(term *tuiTerminal, run func() error) (err error) {
+	fmt.Fprint(h.out, ansiAlternateScreen)
+	defer func() {
+		p := recover()
+		fmt.Fprint(h.out, ansiResetColors+ansiMainScreen)
+		if restoreErr := term.restore(); restoreErr != nil && err == nil {
+			err = restoreErr
+		}
+		if p != nil {
+			panic(p)
+		}
+	}()
+	return run()
+}
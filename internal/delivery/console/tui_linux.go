@@ -0,0 +1,92 @@
+//go:build linux
+
+package console
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"unsafe"
+)
+
+// openTerminal переводит терминал ввода в сырой режим: без эха, построчной
+// буферизации и обработки Ctrl+C, - и подписывается на сигнал SIGWINCH
+// об изменении размера окна и на сигналы завершения, чтобы успеть восстановить
+// терминал. Режим задается системными вызовами ioctl без сторонних библиотек.
+func openTerminal(in io.Reader, out io.Writer) (*tuiTerminal, error) {
+	inFile, ok := in.(*os.File)
+	if !ok || !isTerminal(in) || !isTerminal(out) {
+		return nil, errNotTerminal
+	}
+	fd := int(inFile.Fd())
+
+	var saved syscall.Termios
+	if err := ioctl(fd, syscall.TCGETS, unsafe.Pointer(&saved)); err != nil {
+		return nil, err
+	}
+	// Сигналы завершения перехватываются до включения сырого режима, чтобы
+	// сигнал, пришедший сразу после включения, не оставил терминал в нем
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGINT)
+
+	raw := saved
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Oflag &^= syscall.OPOST
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := ioctl(fd, syscall.TCSETS, unsafe.Pointer(&raw)); err != nil {
+		signal.Stop(stop)
+		return nil, err
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGWINCH)
+	resize := make(chan struct{}, 1)
+	go func() {
+		for range signals {
+			select {
+			case resize <- struct{}{}:
+			default:
+				// Предыдущее изменение еще не обработано: размер все равно
+				// запрашивается заново
+			}
+		}
+	}()
+
+	return &tuiTerminal{
+		restore: func() error {
+			signal.Stop(signals)
+			close(signals)
+			err := ioctl(fd, syscall.TCSETS, unsafe.Pointer(&saved))
+			// Перехват снимается после восстановления режима: сигнал, пришедший
+			// позже, завершит процесс как обычно
+			signal.Stop(stop)
+			return err
+		},
+		resize: resize,
+		size:   func() (int, int, error) { return terminalSize(fd) },
+		stop:   stop,
+	}, nil
+}
+
+// terminalSize возвращает ширину и высоту окна терминала в символах
+func terminalSize(fd int) (int, int, error) {
+	var size struct{ rows, cols, xPixels, yPixels uint16 }
+	if err := ioctl(fd, syscall.TIOCGWINSZ, unsafe.Pointer(&size)); err != nil {
+		return 0, 0, err
+	}
+	return int(size.cols), int(size.rows), nil
+}
+
+// ioctl выполняет системный вызов ioctl над дескриптором терминала
+func ioctl(fd int, request uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), request, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
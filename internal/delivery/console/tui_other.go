@@ -0,0 +1,14 @@
+//go:build !linux
+
+package console
+
+import (
+	"io"
+
+	"chessboard/internal/i18n"
+)
+
+// openTerminal сообщает, что сырой режим терминала на этой системе не поддерживается
+func openTerminal(in io.Reader, out io.Writer) (*tuiTerminal, error) {
+	return nil, i18n.New("tui.linux_only")
+}
@@ -0,0 +1,485 @@
+package console
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+	"chessboard/internal/usecase"
+)
+
+// mustScreen создает экран для партии с позиции FEN или прерывает тест
+func mustScreen(t *testing.T, fen string) *tuiScreen {
+	t.Helper()
+
+	board, err := domain.ParseFEN(fen)
+	if err != nil {
+		t.Fatalf("неверный FEN %q: %v", fen, err)
+	}
+	game, err := usecase.NewGame(board)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	service := usecase.NewBoardUsecase(usecase.NewBoardRepository())
+	return newTUIScreen(i18n.NewPrinter(i18n.Default), service, game, domain.RenderOptions{})
+}
+
+// mustSquare разбирает клетку или прерывает тест
+func mustSquare(t *testing.T, name string) domain.Square {
+	t.Helper()
+
+	square, err := domain.ParseSquare(name)
+	if err != nil {
+		t.Fatalf("неверная клетка %q: %v", name, err)
+	}
+	return square
+}
+
+// pressKeys передает экрану клавиши, записанные так же, как их присылает терминал
+func pressKeys(screen *tuiScreen, input string) bool {
+	for _, key := range decodeKeys([]byte(input)) {
+		if screen.handleKey(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDecodeKeys(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []tuiKey
+	}{
+		{"стрелки", "\x1b[A\x1b[B\x1b[C\x1b[D", []tuiKey{keyUp, keyDown, keyRight, keyLeft}},
+		{"стрелки в режиме приложения", "\x1bOA\x1bOD", []tuiKey{keyUp, keyLeft}},
+		{"буквы vi", "hjkl", []tuiKey{keyLeft, keyDown, keyUp, keyRight}},
+		{"выбор", "\r \n", []tuiKey{keySelect, keySelect, keySelect}},
+		{"одиночный escape", "\x1b", []tuiKey{keyCancel}},
+		{"команды", "ufq\x03", []tuiKey{keyUndo, keyFlip, keyQuit, keyInterrupt}},
+		{"фигуры превращения", "rbn", []tuiKey{keyRook, keyBishop, keyKnight}},
+		{"неизвестные байты", "xyz\x1b[Z", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := decodeKeys([]byte(tc.input)); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("ожидалось %v, получено %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestTUIScreen_Cursor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		flipped  bool
+		keys     string
+		expected string
+	}{
+		{"курсор начинает с короля", false, "", "e1"},
+		{"вверх и вправо", false, "kkl", "f3"},
+		{"курсор не выходит за доску", false, "jjjhhhhhhhh", "a1"},
+		{"перевернутая доска", true, "kkl", "d1"},
+		{"перевернутая доска вниз", true, "jjh", "f3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			screen := mustScreen(t, domain.StartFEN)
+			if tc.flipped {
+				pressKeys(screen, "f")
+			}
+			pressKeys(screen, tc.keys)
+			if screen.cursor != mustSquare(t, tc.expected) {
+				t.Errorf("ожидался курсор на %s, получено %s", tc.expected, screen.cursor)
+			}
+		})
+	}
+}
+
+func TestTUIScreen_Move(t *testing.T) {
+	screen := mustScreen(t, domain.StartFEN)
+
+	// Курсор с e1 на e2, выбор пешки, затем на e4 и подтверждение хода
+	pressKeys(screen, "k ")
+	if !screen.hasSelection || screen.selected != mustSquare(t, "e2") {
+		t.Fatalf("ожидалась выбранная пешка e2")
+	}
+	targets := screen.targets(screen.selected)
+	if len(targets) != 2 || !targets[mustSquare(t, "e3")] || !targets[mustSquare(t, "e4")] {
+		t.Errorf("ожидались клетки e3 и e4, получено %v", targets)
+	}
+
+	pressKeys(screen, "kk\r")
+	if screen.hasSelection {
+		t.Error("после хода выбор должен сниматься")
+	}
+	if screen.message != "Ход: 1. e4" {
+		t.Errorf("ожидалось сообщение о ходе, получено %q", screen.message)
+	}
+	if got := screen.game.Board().FEN(); got != "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1" {
+		t.Errorf("неверная позиция после хода: %q", got)
+	}
+
+	pressKeys(screen, "u")
+	if screen.game.Board().FEN() != domain.StartFEN || screen.message != "Отменен ход: 1. e4" {
+		t.Errorf("ход не отменен: %q, %q", screen.game.Board().FEN(), screen.message)
+	}
+}
+
+func TestTUIScreen_Selection(t *testing.T) {
+	testCases := []struct {
+		name     string
+		keys     string
+		selected bool
+		message  string
+	}{
+		{"пустая клетка без выбора", "kk ", false, "Выберите фигуру белых."},
+		{"фигура противника", "kkkkkkk ", false, "Выберите фигуру белых."},
+		{"фигура без ходов", " ", false, "У этой фигуры нет ходов."},
+		{"повторный выбор снимает выбор", "k  ", false, ""},
+		{"escape снимает выбор", "k \x1b", false, ""},
+		{"выбор другой фигуры", "k h ", true, ""},
+		{"недопустимый ход", "k kkk ", true, "Недопустимый ход."},
+		{"отмена без ходов", "u", false, "Нет ходов для отмены."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			screen := mustScreen(t, domain.StartFEN)
+			pressKeys(screen, tc.keys)
+			if screen.hasSelection != tc.selected || screen.message != tc.message {
+				t.Errorf("ожидалось выбрано=%v, %q; получено %v, %q",
+					tc.selected, tc.message, screen.hasSelection, screen.message)
+			}
+		})
+	}
+}
+
+func TestTUIScreen_Promotion(t *testing.T) {
+	testCases := []struct {
+		name    string
+		keys    string
+		piece   domain.PieceKind
+		message string
+	}{
+		{"ферзь клавишей q", "q", domain.Queen, "Ход: 1. e8=Q+"},
+		{"ферзь клавишей Enter", "\r", domain.Queen, "Ход: 1. e8=Q+"},
+		{"ладья", "r", domain.Rook, "Ход: 1. e8=R+"},
+		{"слон", "b", domain.Bishop, "Ход: 1. e8=B"},
+		{"конь", "n", domain.Knight, "Ход: 1. e8=N"},
+		{"курсор не выбирает фигуру", "hn", domain.Knight, "Ход: 1. e8=N"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			screen := mustScreen(t, "7k/4P3/8/8/8/8/8/K7 w - - 0 1")
+			screen.cursor = mustSquare(t, "e7")
+
+			// Ход на e8 не делается сразу: сначала предлагается выбрать фигуру
+			pressKeys(screen, " k ")
+			if screen.promotions == nil || !strings.HasPrefix(screen.message, "Превращение пешки") {
+				t.Fatalf("ожидался выбор фигуры превращения, получено %q", screen.message)
+			}
+			if pressKeys(screen, tc.keys) {
+				t.Fatal("выбор фигуры не должен завершать режим")
+			}
+			if got := screen.game.Board().PieceAt(4, 7); got != (domain.Piece{Color: domain.White, Kind: tc.piece}) {
+				t.Errorf("пешка должна превратиться в %v, получено %v", tc.piece, got)
+			}
+			if screen.message != tc.message || screen.promotions != nil || screen.hasSelection {
+				t.Errorf("неверное состояние после хода: %q", screen.message)
+			}
+		})
+	}
+}
+
+func TestTUIScreen_PromotionCancel(t *testing.T) {
+	screen := mustScreen(t, "7k/4P3/8/8/8/8/8/K7 w - - 0 1")
+	screen.cursor = mustSquare(t, "e7")
+	pressKeys(screen, " k \x1b")
+
+	if screen.promotions != nil || screen.message != "" {
+		t.Errorf("Esc должен отменять превращение, получено %q", screen.message)
+	}
+	if !screen.hasSelection || screen.game.Board().FEN() != "7k/4P3/8/8/8/8/8/K7 w - - 0 1" {
+		t.Errorf("после отмены пешка остается выбранной, а позиция не меняется: %q", screen.game.Board().FEN())
+	}
+	// Во время выбора Ctrl+C по-прежнему завершает режим
+	pressKeys(screen, " ")
+	if !pressKeys(screen, "\x03") {
+		t.Error("Ctrl+C должен завершать режим и во время выбора превращения")
+	}
+}
+
+func TestTUIScreen_Quit(t *testing.T) {
+	for _, input := range []string{"q", "\x03"} {
+		if !pressKeys(mustScreen(t, domain.StartFEN), input) {
+			t.Errorf("клавиша %q должна завершать режим", input)
+		}
+	}
+}
+
+func TestTUIScreen_Render(t *testing.T) {
+	screen := mustScreen(t, domain.StartFEN)
+	// Выбрана пешка e2, курсор сдвинут на f2, чтобы не закрывать выбранную клетку
+	pressKeys(screen, "k l")
+
+	var buf bytes.Buffer
+	if err := screen.render(&buf); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	output := buf.String()
+
+	for _, part := range []string{
+		ansiClearScreen,
+		"Статус: ход белых.\r\n",
+		"  a b c d e f g h\r\n",
+		// Выбранная пешка e2 и курсор на f2 подсвечены поверх узора доски
+		"\x1b[48;2;95;175;95m\x1b[38;2;255;255;255mP ",
+		"\x1b[48;2;255;215;0m\x1b[38;2;255;255;255mP ",
+		"Стрелки или hjkl - курсор, Enter или пробел - выбрать и сходить, Esc - отмена выбора, u - отменить ход, f - перевернуть, q - выход\r\n",
+	} {
+		if !strings.Contains(output, part) {
+			t.Errorf("экран должен содержать %q, получено:\n%q", part, output)
+		}
+	}
+	if strings.Count(output, "\x1b[48;2;95;175;215m  ") != 2 {
+		t.Errorf("ожидалось 2 подсвеченные клетки хода:\n%q", output)
+	}
+	if strings.Contains(strings.ReplaceAll(output, "\r\n", ""), "\n") {
+		t.Error("в сыром режиме строки должны заканчиваться \\r\\n")
+	}
+}
+
+func TestTUIScreen_RenderFlipped(t *testing.T) {
+	screen := mustScreen(t, domain.StartFEN)
+	pressKeys(screen, "f")
+
+	var buf bytes.Buffer
+	if err := screen.render(&buf); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if !strings.Contains(buf.String(), "  h g f e d c b a\r\n") {
+		t.Errorf("вертикали перевернутой доски должны идти от h к a:\n%q", buf.String())
+	}
+	lines := strings.Split(buf.String(), "\r\n")
+	if !strings.HasPrefix(lines[2], "1 ") {
+		t.Errorf("первой сверху должна быть первая горизонталь, получено %q", lines[2])
+	}
+}
+
+func TestTUIScreen_RenderViewOptions(t *testing.T) {
+	// Экран рисуется общим отрисовщиком: узор сервиса и цвета клеток из параметров
+	board, err := domain.ParseFEN(domain.StartFEN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, err := usecase.NewGame(board)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := usecase.NewBoardUsecase(usecase.NewBoardRepository())
+	if err := service.SetPattern("stripes"); err != nil {
+		t.Fatal(err)
+	}
+	opts := domain.RenderOptions{LightColor: "#ffffff", DarkColor: "#000000"}
+	screen := newTUIScreen(i18n.NewPrinter(i18n.Default), service, game, opts)
+
+	var buf bytes.Buffer
+	if err := screen.render(&buf); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	testCases := []struct {
+		name       string
+		line       string
+		background string
+	}{
+		{"светлая полоса", "6 ", "\x1b[48;2;255;255;255m"},
+		{"темная полоса", "5 ", "\x1b[48;2;0;0;0m"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expected := tc.line + strings.Repeat(tc.background+"  ", 8) + ansiResetColors + " " + tc.line[:1] + "\r\n"
+			if !strings.Contains(buf.String(), expected) {
+				t.Errorf("ожидалась строка %q, получено:\n%q", expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestHandleTUI_InvalidOptions(t *testing.T) {
+	// Неверные параметры отрисовки сообщаются до проверки терминала
+	output := runPlay("", "--tui", "--light", "#12")
+	if !strings.Contains(output, "Ошибка: ") || strings.Contains(output, "терминале") {
+		t.Errorf("ожидалась ошибка цвета, получено:\n%q", output)
+	}
+}
+
+func TestTUIScreen_RenderSmallWindow(t *testing.T) {
+	screen := mustScreen(t, domain.StartFEN)
+	board, err := screen.renderBoard()
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	// Доска 8x8 с координатами: клетки по два символа и номера горизонталей с двух сторон
+	width, height := requiredSize(board)
+	if width != 20 || height != 13 {
+		t.Errorf("ожидался размер 20x13, получено %dx%d", width, height)
+	}
+
+	testCases := []struct {
+		name          string
+		width, height int
+		small         bool
+	}{
+		{"размер неизвестен", 0, 0, false},
+		{"достаточный размер", width, height, false},
+		{"узкое окно", width - 1, height, true},
+		{"низкое окно", width, height - 1, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			screen.width, screen.height = tc.width, tc.height
+			var buf bytes.Buffer
+			if err := screen.render(&buf); err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if small := strings.Contains(buf.String(), "Окно слишком маленькое"); small != tc.small {
+				t.Errorf("ожидалось сообщение о размере: %v, получено:\n%q", tc.small, buf.String())
+			}
+		})
+	}
+}
+
+func TestRunTUI(t *testing.T) {
+	handler := NewBoardHandler(&MockBoardService{})
+	var buf bytes.Buffer
+	handler.SetOutput(&buf)
+	handler.SetInput(strings.NewReader("k kk\r"))
+
+	screen := mustScreen(t, domain.StartFEN)
+	term := &tuiTerminal{size: func() (int, int, error) { return 80, 24, nil }}
+	if err := handler.runTUI(screen, term); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	if screen.width != 80 || screen.height != 24 {
+		t.Errorf("размер окна должен запрашиваться при запуске, получено %dx%d", screen.width, screen.height)
+	}
+	if screen.game.Board().PieceAt(4, 3).Kind != domain.Pawn {
+		t.Error("ожидался ход e2-e4")
+	}
+	if !strings.Contains(buf.String(), "Ход: 1. e4") {
+		t.Errorf("экран должен перерисовываться после хода:\n%q", buf.String())
+	}
+}
+
+func TestRunTUI_Resize(t *testing.T) {
+	handler := NewBoardHandler(&MockBoardService{})
+	var buf bytes.Buffer
+	handler.SetOutput(&buf)
+	reader, writer := io.Pipe()
+	handler.SetInput(reader)
+
+	// Ширина окна передается через канал, чтобы тест не гонялся с runTUI за переменной
+	widths := make(chan int, 1)
+	widths <- 80
+	resize := make(chan struct{})
+	term := &tuiTerminal{resize: resize, size: func() (int, int, error) { return <-widths, 24, nil }}
+	screen := mustScreen(t, domain.StartFEN)
+
+	done := make(chan error)
+	go func() { done <- handler.runTUI(screen, term) }()
+
+	widths <- 10
+	resize <- struct{}{}
+	writer.Close()
+	if err := <-done; err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if screen.width != 10 {
+		t.Errorf("после изменения размера ожидалась ширина 10, получено %d", screen.width)
+	}
+	if !strings.Contains(buf.String(), "Окно слишком маленькое") {
+		t.Errorf("после уменьшения окна ожидалось сообщение о размере:\n%q", buf.String())
+	}
+}
+
+func TestRunTUI_Signal(t *testing.T) {
+	handler := NewBoardHandler(&MockBoardService{})
+	handler.SetOutput(io.Discard)
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	handler.SetInput(reader)
+
+	stop := make(chan os.Signal, 1)
+	stop <- os.Interrupt
+	err := handler.runTUI(mustScreen(t, domain.StartFEN), &tuiTerminal{stop: stop})
+	if err == nil || !strings.Contains(err.Error(), "прерван сигналом 'interrupt'") {
+		t.Errorf("сигнал должен завершать режим с ошибкой, получено %v", err)
+	}
+}
+
+func TestRunRaw(t *testing.T) {
+	errRestore := errors.New("restore")
+
+	testCases := []struct {
+		name       string
+		run        func() error
+		restoreErr error
+		expected   error
+		panics     bool
+	}{
+		{"обычный выход", func() error { return nil }, nil, nil, false},
+		{"ошибка режима", func() error { return io.ErrUnexpectedEOF }, errRestore, io.ErrUnexpectedEOF, false},
+		{"ошибка восстановления", func() error { return nil }, errRestore, errRestore, false},
+		{"паника", func() error { panic("сбой") }, nil, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewBoardHandler(&MockBoardService{})
+			var buf bytes.Buffer
+			handler.SetOutput(&buf)
+			restored := false
+			term := &tuiTerminal{restore: func() error {
+				restored = true
+				return tc.restoreErr
+			}}
+
+			var err error
+			panicked := func() (panicked bool) {
+				defer func() { panicked = recover() != nil }()
+				err = handler.runRaw(term, tc.run)
+				return false
+			}()
+
+			if panicked != tc.panics {
+				t.Errorf("паника: ожидалось %v, получено %v", tc.panics, panicked)
+			}
+			if !restored || !strings.HasSuffix(buf.String(), ansiMainScreen) {
+				t.Errorf("терминал должен восстанавливаться, вывод %q", buf.String())
+			}
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("ожидалась ошибка %v, получено %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestHandleTUI_NotTerminal(t *testing.T) {
+	output := runPlay("", "--tui")
+	if !strings.Contains(output, "Ошибка: ") || strings.Contains(output, ansiAlternateScreen) {
+		t.Errorf("без терминала полноэкранный режим не запускается, получено:\n%q", output)
+	}
+}
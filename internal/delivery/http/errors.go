@@ -0,0 +1,154 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+// errorResponse - тело ответа с ошибкой. Поля совпадают с ошибкой строгого
+// режима консоли; вместо кода завершения передается статус HTTP.
+type errorResponse struct {
+	Class   string   `json:"class"`
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Value   string   `json:"value,omitempty"`
+	Min     *int     `json:"min,omitempty"`
+	Max     *int     `json:"max,omitempty"`
+	Allowed []string `json:"allowed,omitempty"`
+	// Field и Position указывают место ошибки в строке FEN
+	Field    int `json:"field,omitempty"`
+	Position int `json:"position,omitempty"`
+}
+
+// requestError - ошибка в самом запросе, а не в данных предметной области:
+// неверный JSON или параметр запроса
+type requestError struct {
+	err error
+}
+
+// badRequest помечает err как ошибку запроса (статус 400)
+func badRequest(err error) error {
+	return &requestError{err: err}
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *requestError) Localize(p *i18n.Printer) string {
+	return p.Error(e.err)
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+// classStatuses - статусы HTTP для классов ошибок предметной области
+var classStatuses = map[domain.ErrorClass]int{
+	domain.ClassUsage: nethttp.StatusBadRequest,
+	domain.ClassSize:  nethttp.StatusUnprocessableEntity,
+	domain.ClassFEN:   nethttp.StatusUnprocessableEntity,
+	domain.ClassMove:  nethttp.StatusUnprocessableEntity,
+}
+
+// classify возвращает класс ошибки и статус HTTP. Класс ошибки предметной
+// области определяется так же, как код завершения в консоли, - через
+// domain.Classify. Ошибки самого запроса относятся к классу usage, остальные
+// считаются внутренними.
+func classify(err error) (string, int) {
+	var reqErr *requestError
+	var tooLarge *nethttp.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return string(domain.ClassUsage), nethttp.StatusRequestEntityTooLarge
+	}
+	if errors.As(err, &reqErr) {
+		return string(domain.ClassUsage), nethttp.StatusBadRequest
+	}
+	if class, ok := domain.Classify(err); ok {
+		return string(class), classStatuses[class]
+	}
+	return "internal", nethttp.StatusInternalServerError
+}
+
+// newErrorResponse описывает ошибку err на языке text. Для ошибок предметной
+// области добавляются ошибочное значение, пределы, допустимые значения и место
+// ошибки в FEN.
+func newErrorResponse(text *i18n.Printer, err error) errorResponse {
+	class, status := classify(err)
+	response := errorResponse{Class: class, Status: status, Message: text.Error(err)}
+
+	var sizeErr *domain.SizeError
+	var numberErr *domain.NumberError
+	var unknownErr *domain.UnknownValueError
+	var fenErr *domain.FENError
+	var tooLarge *nethttp.MaxBytesError
+	switch {
+	case errors.As(err, &sizeErr):
+		response.Value = strconv.Itoa(sizeErr.Value)
+		response.Min, response.Max = &sizeErr.Min, &sizeErr.Max
+	case errors.As(err, &numberErr):
+		response.Value = numberErr.Input
+	case errors.As(err, &unknownErr):
+		response.Value = unknownErr.Value
+		response.Allowed = unknownErr.Allowed
+	case errors.As(err, &fenErr):
+		response.Field, response.Position = fenErr.Field, fenErr.Pos
+	case errors.As(err, &tooLarge):
+		response.Message = text.Sprintf("http.body_too_large", tooLarge.Limit)
+	}
+	return response
+}
+
+// writeError отвечает ошибкой err
+func writeError(w nethttp.ResponseWriter, text *i18n.Printer, err error) {
+	response := newErrorResponse(text, err)
+	writeJSON(w, response.Status, response)
+}
+
+// writeJSON отвечает статусом status и значением value в JSON
+func writeJSON(w nethttp.ResponseWriter, status int, value any) {
+	body, err := encodeJSON(value)
+	if err != nil {
+		nethttp.Error(w, err.Error(), nethttp.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	// Ошибку записи ответа сообщить уже некуда
+	_, _ = w.Write(body)
+}
+
+// encodeJSON кодирует value в JSON без экранирования HTML: ответы содержат
+// позиции FEN и описания ошибок, а не разметку
+func encodeJSON(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// printer выбирает язык ответа по заголовку Accept-Language: берется первый
+// поддерживаемый язык в порядке перечисления, веса q не учитываются. Если
+// подходящего языка нет, используется язык по умолчанию.
+func printer(r *nethttp.Request) *i18n.Printer {
+	for _, header := range r.Header.Values("Accept-Language") {
+		for _, tag := range strings.Split(header, ",") {
+			tag, _, _ = strings.Cut(tag, ";")
+			tag, _, _ = strings.Cut(strings.TrimSpace(tag), "-")
+			if lang, ok := i18n.LookupLang(tag); ok {
+				return i18n.NewPrinter(lang)
+			}
+		}
+	}
+	return i18n.DefaultPrinter()
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+func TestClassify(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		class  string
+		status int
+	}{
+		{"размер", &domain.SizeError{Dimension: domain.DimensionWidth, Value: 3, Min: 4, Max: 64}, "size", nethttp.StatusUnprocessableEntity},
+		{"расстановка", &domain.SetupError{Setup: domain.SetupStandard, Width: 10, Height: 8}, "size", nethttp.StatusUnprocessableEntity},
+		{"FEN", &domain.FENError{Reason: errors.New("пустая строка")}, "fen", nethttp.StatusUnprocessableEntity},
+		{"позиция", fmt.Errorf("%w: два короля", domain.ErrInvalidPosition), "fen", nethttp.StatusUnprocessableEntity},
+		{"ход", fmt.Errorf("%w: 'e5'", domain.ErrIllegalMove), "move", nethttp.StatusUnprocessableEntity},
+		{"число", &domain.NumberError{Input: "x", Kind: domain.ErrNotANumber}, "usage", nethttp.StatusBadRequest},
+		{"неизвестное значение", &domain.UnknownValueError{Label: "unknown.theme", Value: "neon"}, "usage", nethttp.StatusBadRequest},
+		{"узор", fmt.Errorf("%w: 'rings'", domain.ErrInvalidPattern), "usage", nethttp.StatusBadRequest},
+		{"ошибка запроса", badRequest(errors.New("неверный JSON")), "usage", nethttp.StatusBadRequest},
+		{"большое тело", &nethttp.MaxBytesError{Limit: 10}, "usage", nethttp.StatusRequestEntityTooLarge},
+		{"обернутая ошибка", fmt.Errorf("позиция: %w", &domain.FENError{Reason: errors.New("пустая строка")}), "fen", nethttp.StatusUnprocessableEntity},
+		{"прочая ошибка", errors.New("сбой"), "internal", nethttp.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			class, status := classify(tc.err)
+			if class != tc.class || status != tc.status {
+				t.Errorf("ожидались %q и %d, получено %q и %d", tc.class, tc.status, class, status)
+			}
+		})
+	}
+}
+
+func TestNewErrorResponse_Language(t *testing.T) {
+	err := &domain.UnknownValueError{Label: "unknown.theme", Value: "neon", Allowed: []string{"ascii"}}
+
+	russian := newErrorResponse(i18n.NewPrinter(i18n.Russian), err)
+	english := newErrorResponse(i18n.NewPrinter(i18n.English), err)
+	if russian.Message != "неизвестная тема: 'neon'" || english.Message != "unknown theme: 'neon'" {
+		t.Errorf("неверные сообщения: %q, %q", russian.Message, english.Message)
+	}
+	if english.Value != "neon" || len(english.Allowed) != 1 {
+		t.Errorf("ожидались значение и список допустимых значений: %+v", english)
+	}
+}
+
+func TestPrinter_AcceptLanguage(t *testing.T) {
+	testCases := []struct {
+		header   string
+		expected i18n.Lang
+	}{
+		{"", i18n.Default},
+		{"en", i18n.English},
+		{"en-US,en;q=0.9", i18n.English},
+		{"de-DE, en;q=0.5", i18n.English},
+		{"ru-RU,ru;q=0.9,en;q=0.8", i18n.Russian},
+		{"fr, de", i18n.Default},
+		{"*", i18n.Default},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.header, func(t *testing.T) {
+			request := httptest.NewRequest(nethttp.MethodGet, "/board", nil)
+			if tc.header != "" {
+				request.Header.Set("Accept-Language", tc.header)
+			}
+			if got := printer(request).Lang(); got != tc.expected {
+				t.Errorf("ожидался язык %q, получено %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestHandleBoard_AcceptLanguage(t *testing.T) {
+	s := newTestServer(Config{})
+	request := httptest.NewRequest(nethttp.MethodGet, "/board?size=3", nil)
+	request.Header.Set("Accept-Language", "en-GB")
+	recorder := httptest.NewRecorder()
+	s.Handler().ServeHTTP(recorder, request)
+
+	got := decode[errorResponse](t, recorder)
+	if got.Message != "board size cannot be less than 4 (width 3)" {
+		t.Errorf("ожидалось сообщение на английском, получено %q", got.Message)
+	}
+}
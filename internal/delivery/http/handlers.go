@@ -0,0 +1,290 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+	"chessboard/internal/notation"
+)
+
+// contentTypes - типы содержимого ответа для форматов вывода доски
+var contentTypes = map[domain.Format]string{
+	domain.FormatText: "text/plain; charset=utf-8",
+	domain.FormatSVG:  "image/svg+xml",
+	domain.FormatPNG:  "image/png",
+	domain.FormatJSON: "application/json; charset=utf-8",
+}
+
+// positionRequest - тело запросов "POST /fen/validate" и "POST /moves"
+type positionRequest struct {
+	FEN string `json:"fen"`
+}
+
+// positionResponse - проверенная позиция: FEN в каноническом виде, размер доски
+// и состояние партии
+type positionResponse struct {
+	FEN        string         `json:"fen"`
+	Width      int            `json:"width"`
+	Height     int            `json:"height"`
+	SideToMove string         `json:"sideToMove"`
+	Status     statusResponse `json:"status"`
+}
+
+// statusResponse - состояние партии; Result - результат в записи PGN ("*", если
+// партия продолжается)
+type statusResponse struct {
+	InCheck              bool   `json:"inCheck"`
+	Checkmate            bool   `json:"checkmate"`
+	Stalemate            bool   `json:"stalemate"`
+	InsufficientMaterial bool   `json:"insufficientMaterial"`
+	Result               string `json:"result"`
+}
+
+// movesResponse - легальные ходы стороны, имеющей очередь хода
+type movesResponse struct {
+	FEN   string         `json:"fen"`
+	Moves []moveResponse `json:"moves"`
+}
+
+// moveResponse - ход в нотациях UCI и SAN
+type moveResponse struct {
+	UCI string `json:"uci"`
+	SAN string `json:"san"`
+}
+
+// handleBoard отвечает на "GET /board": доска размера size ("N" или "WxH",
+// по умолчанию 8x8) с расстановкой setup или позиция fen в формате format
+// (text, svg, png или json - описание по схеме usecase.JSONSchema). Параметры
+// pattern, theme, palette, light, dark, orientation, pieces, coords, square-size
+// и border задают вид доски, как одноименные флаги консоли.
+func (s *Server) handleBoard(w nethttp.ResponseWriter, r *nethttp.Request) {
+	text := printer(r)
+	query := r.URL.Query()
+
+	board, err := s.queryBoard(query)
+	if err != nil {
+		writeError(w, text, err)
+		return
+	}
+	opts, err := renderOptions(query)
+	if err != nil {
+		writeError(w, text, err)
+		return
+	}
+
+	// Доска выводится в буфер: ошибка отрисовки должна стать ответом с ошибкой,
+	// а не оборванным изображением. Запись в буфер не прерывается, поэтому
+	// ошибка отрисовки - это неверный параметр вида: тема, палитра, цвет клетки
+	// или размер клетки, которые проверяются только при отрисовке.
+	var buf bytes.Buffer
+	if err := s.service.Render(&buf, board, opts); err != nil {
+		writeError(w, text, badRequest(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypes[opts.Format])
+	_, _ = w.Write(buf.Bytes())
+}
+
+// queryBoard создает доску по параметрам запроса
+func (s *Server) queryBoard(query url.Values) (*domain.Board, error) {
+	if fen := query.Get("fen"); fen != "" {
+		// Размер и фигуры задаются самой позицией, поэтому их нельзя указать отдельно
+		if query.Has("size") || query.Has("setup") {
+			return nil, badRequest(i18n.New("render.fen_conflict"))
+		}
+		return s.loadFEN(fen)
+	}
+
+	width, height := domain.DefaultBoardSize, domain.DefaultBoardSize
+	if size := query.Get("size"); size != "" {
+		var err error
+		if width, height, err = domain.ParseSize(size); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkSize(width, height); err != nil {
+		return nil, err
+	}
+
+	setup := domain.SetupEmpty
+	if value := query.Get("setup"); value != "" {
+		var err error
+		if setup, err = domain.ParseSetup(value); err != nil {
+			return nil, err
+		}
+	}
+	return s.service.CreateBoard(width, height, setup)
+}
+
+// themeAuto - тема, которую консоль выбирает по терминалу. Ответ сервера
+// не выводится в терминал напрямую, поэтому она заменяется на ASCII, как
+// в консоли при перенаправленном выводе.
+const themeAuto = "auto"
+
+// renderOptions разбирает параметры вида доски. Тема, палитра и цвета клеток
+// проверяются при отрисовке.
+func renderOptions(query url.Values) (domain.RenderOptions, error) {
+	opts := domain.RenderOptions{
+		Format:     domain.FormatText,
+		Pattern:    query.Get("pattern"),
+		Theme:      query.Get("theme"),
+		Palette:    query.Get("palette"),
+		LightColor: query.Get("light"),
+		DarkColor:  query.Get("dark"),
+	}
+	if strings.EqualFold(opts.Theme, themeAuto) {
+		opts.Theme = "ascii"
+	}
+	var err error
+
+	if value := query.Get("format"); value != "" {
+		if opts.Format, err = domain.ParseFormat(value); err != nil {
+			return opts, err
+		}
+	}
+	if value := query.Get("orientation"); value != "" {
+		if opts.Orientation, err = domain.ParseOrientation(value); err != nil {
+			return opts, err
+		}
+	}
+	if value := query.Get("pieces"); value != "" {
+		if opts.Pieces, err = domain.ParsePieceStyle(value); err != nil {
+			return opts, err
+		}
+	}
+	if value := query.Get("square-size"); value != "" {
+		if opts.SquareSize, err = domain.ParseNumber(value); err != nil {
+			return opts, i18n.Errorf("render.square_size", err)
+		}
+	}
+	if value := query.Get("border"); value != "" {
+		if opts.Border, err = domain.ParseNumber(value); err != nil {
+			return opts, i18n.Errorf("render.border", err)
+		}
+	}
+	if query.Has("coords") {
+		// Параметр без значения ("?coords") включает координаты
+		value := query.Get("coords")
+		opts.Coordinates = true
+		if value != "" {
+			if opts.Coordinates, err = strconv.ParseBool(value); err != nil {
+				return opts, badRequest(i18n.Errorf("http.invalid_param", "coords", value))
+			}
+		}
+	}
+	return opts, nil
+}
+
+// handleValidateFEN отвечает на "POST /fen/validate": проверяет позицию из поля
+// fen тела запроса и возвращает ее в каноническом виде вместе с состоянием партии
+func (s *Server) handleValidateFEN(w nethttp.ResponseWriter, r *nethttp.Request) {
+	text := printer(r)
+	board, err := s.readPosition(w, r)
+	if err != nil {
+		writeError(w, text, err)
+		return
+	}
+	status, err := s.service.Status(board)
+	if err != nil {
+		writeError(w, text, err)
+		return
+	}
+
+	writeJSON(w, nethttp.StatusOK, positionResponse{
+		FEN:        board.FEN(),
+		Width:      board.Width,
+		Height:     board.Height,
+		SideToMove: board.SideToMove.String(),
+		Status: statusResponse{
+			InCheck:              status.InCheck,
+			Checkmate:            status.Checkmate,
+			Stalemate:            status.Stalemate,
+			InsufficientMaterial: status.InsufficientMaterial,
+			Result:               status.Result.String(),
+		},
+	})
+}
+
+// handleMoves отвечает на "POST /moves": легальные ходы в позиции из поля fen
+// тела запроса в нотациях UCI и SAN
+func (s *Server) handleMoves(w nethttp.ResponseWriter, r *nethttp.Request) {
+	text := printer(r)
+	board, err := s.readPosition(w, r)
+	if err != nil {
+		writeError(w, text, err)
+		return
+	}
+	game, err := s.service.NewGame(board)
+	if err != nil {
+		writeError(w, text, err)
+		return
+	}
+
+	moves := game.LegalMoves()
+	response := movesResponse{FEN: board.FEN(), Moves: make([]moveResponse, 0, len(moves))}
+	for _, move := range moves {
+		san, err := notation.FormatSAN(game, move)
+		if err != nil {
+			writeError(w, text, err)
+			return
+		}
+		response.Moves = append(response.Moves, moveResponse{UCI: notation.FormatUCI(move), SAN: san})
+	}
+	writeJSON(w, nethttp.StatusOK, response)
+}
+
+// readPosition читает из тела запроса JSON с полем fen и загружает позицию.
+// Размер тела ограничен MaxBodyBytes.
+func (s *Server) readPosition(w nethttp.ResponseWriter, r *nethttp.Request) (*domain.Board, error) {
+	r.Body = nethttp.MaxBytesReader(w, r.Body, s.config.MaxBodyBytes)
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	var request positionRequest
+	if err := decoder.Decode(&request); err != nil {
+		var tooLarge *nethttp.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, err
+		}
+		return nil, badRequest(i18n.Errorf("http.invalid_json", err.Error()))
+	}
+	if strings.TrimSpace(request.FEN) == "" {
+		return nil, badRequest(i18n.New("http.fen_required"))
+	}
+	return s.loadFEN(request.FEN)
+}
+
+// loadFEN загружает позицию и проверяет, что доска не больше MaxBoardSize
+func (s *Server) loadFEN(fen string) (*domain.Board, error) {
+	board, err := s.service.LoadFEN(fen)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkSize(board.Width, board.Height); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// checkSize проверяет, что ширина и высота доски от domain.MinBoardSize до
+// MaxBoardSize. Ошибка сообщает предел сервера, а не domain.MaxBoardSize.
+func (s *Server) checkSize(width, height int) error {
+	dimensions := []struct {
+		name  i18n.Key
+		value int
+	}{{domain.DimensionWidth, width}, {domain.DimensionHeight, height}}
+	for _, d := range dimensions {
+		if d.value < domain.MinBoardSize || d.value > s.config.MaxBoardSize {
+			return &domain.SizeError{Dimension: d.name, Value: d.value, Min: domain.MinBoardSize, Max: s.config.MaxBoardSize}
+		}
+	}
+	return nil
+}
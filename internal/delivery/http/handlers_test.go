@@ -0,0 +1,277 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/usecase"
+)
+
+// newTestServer создает сервер с настоящим сервисом досок
+func newTestServer(config Config) *Server {
+	return NewServer(usecase.NewBoardUsecase(usecase.NewBoardRepository()), config)
+}
+
+// do выполняет запрос к обработчику сервера и возвращает ответ
+func do(t *testing.T, s *Server, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	recorder := httptest.NewRecorder()
+	s.Handler().ServeHTTP(recorder, httptest.NewRequest(method, target, reader))
+	return recorder
+}
+
+// decode разбирает тело ответа в JSON
+func decode[T any](t *testing.T, recorder *httptest.ResponseRecorder) T {
+	t.Helper()
+	var value T
+	if err := json.Unmarshal(recorder.Body.Bytes(), &value); err != nil {
+		t.Fatalf("ответ должен быть в формате JSON: %v; %q", err, recorder.Body.String())
+	}
+	return value
+}
+
+func TestHandleBoard_Formats(t *testing.T) {
+	testCases := []struct {
+		name        string
+		target      string
+		contentType string
+		prefix      string
+	}{
+		{"по умолчанию", "/board", "text/plain; charset=utf-8", " # # # #\n"},
+		{"текст", "/board?size=4&format=text", "text/plain; charset=utf-8", " # #\n"},
+		{"прямоугольная доска", "/board?size=6x4", "text/plain; charset=utf-8", " # # #\n"},
+		{"svg", "/board?size=4&format=svg", "image/svg+xml", "<?xml"},
+		{"png", "/board?size=4&format=png", "image/png", "\x89PNG"},
+		{"координаты", "/board?size=4&coords", "text/plain; charset=utf-8", "  abcd\n4  # # 4\n"},
+		{"json", "/board?size=4&format=json", "application/json; charset=utf-8", "{"},
+		{"узор маской", "/board?size=4&pattern=mask:%23./.%23", "text/plain; charset=utf-8", "# # \n # #\n"},
+		{"тема auto", "/board?size=4&theme=auto", "text/plain; charset=utf-8", " # #\n"},
+		{"тема Auto в другом регистре", "/board?size=4&theme=Auto", "text/plain; charset=utf-8", " # #\n"},
+		{"цвета клеток", "/board?size=4&theme=ansi&light=%23ffffff&dark=%23000000", "text/plain; charset=utf-8", "\x1b[48;2;255;255;255m"},
+	}
+
+	s := newTestServer(Config{})
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := do(t, s, nethttp.MethodGet, tc.target, "")
+			if recorder.Code != nethttp.StatusOK {
+				t.Fatalf("ожидался статус 200, получено %d: %s", recorder.Code, recorder.Body.String())
+			}
+			if got := recorder.Header().Get("Content-Type"); got != tc.contentType {
+				t.Errorf("ожидался тип %q, получено %q", tc.contentType, got)
+			}
+			if !strings.HasPrefix(recorder.Body.String(), tc.prefix) {
+				t.Errorf("ответ должен начинаться с %q, получено:\n%s", tc.prefix, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleBoard_ImageOptions(t *testing.T) {
+	s := newTestServer(Config{})
+
+	// Четыре клетки по 10 пикселей и рамка по 5 пикселей с каждой стороны
+	recorder := do(t, s, nethttp.MethodGet, "/board?size=4&format=svg&square-size=10&border=5", "")
+	if recorder.Code != nethttp.StatusOK {
+		t.Fatalf("ожидался статус 200, получено %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if !strings.Contains(recorder.Body.String(), `width="50" height="50"`) {
+		t.Errorf("ожидалось изображение 50x50, получено:\n%s", recorder.Body.String())
+	}
+}
+
+func TestHandleBoard_JSON(t *testing.T) {
+	s := newTestServer(Config{})
+
+	recorder := do(t, s, nethttp.MethodGet, "/board?setup=standard&format=json", "")
+	board, err := usecase.ReadJSON(recorder.Body)
+	if err != nil {
+		t.Fatalf("ответ должен читаться как описание доски: %v", err)
+	}
+	if board.Width != 8 || board.Height != 8 || board.FEN() != domain.StartFEN {
+		t.Errorf("неверная доска: %dx%d, %q", board.Width, board.Height, board.FEN())
+	}
+
+	recorder = do(t, s, nethttp.MethodGet, "/board?size=4&format=json&pattern=stripes", "")
+	var got struct {
+		Pattern string `json:"pattern"`
+		FEN     string `json:"fen"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil || got.FEN != "" || got.Pattern != "stripes" {
+		t.Errorf("у доски без фигур не должно быть FEN, а узор должен быть указан: %+v, %v", got, err)
+	}
+}
+
+func TestHandleBoard_FEN(t *testing.T) {
+	s := newTestServer(Config{})
+	recorder := do(t, s, nethttp.MethodGet, "/board?fen="+strings.ReplaceAll(domain.StartFEN, " ", "+"), "")
+	if recorder.Code != nethttp.StatusOK || !strings.HasPrefix(recorder.Body.String(), "rnbqkbnr\n") {
+		t.Errorf("ожидалась начальная позиция, получено %d:\n%s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleBoard_Errors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		target string
+		status int
+		class  string
+	}{
+		{"размер не число", "/board?size=abc", nethttp.StatusBadRequest, "usage"},
+		{"размер меньше минимального", "/board?size=3", nethttp.StatusUnprocessableEntity, "size"},
+		{"размер больше предела сервера", "/board?size=65", nethttp.StatusUnprocessableEntity, "size"},
+		{"расстановка на маленькой доске", "/board?size=4&setup=standard", nethttp.StatusUnprocessableEntity, "size"},
+		{"неизвестная расстановка", "/board?setup=random", nethttp.StatusBadRequest, "usage"},
+		{"неизвестный формат", "/board?format=gif", nethttp.StatusBadRequest, "usage"},
+		{"неизвестная тема", "/board?theme=neon", nethttp.StatusBadRequest, "usage"},
+		{"неизвестная палитра", "/board?format=svg&palette=sepia", nethttp.StatusBadRequest, "usage"},
+		{"неверный цвет", "/board?format=png&dark=%2312", nethttp.StatusBadRequest, "usage"},
+		{"размер клетки не число", "/board?format=svg&square-size=big", nethttp.StatusBadRequest, "usage"},
+		{"слишком большая клетка", "/board?format=png&square-size=100000", nethttp.StatusBadRequest, "usage"},
+		{"отрицательная рамка", "/board?format=svg&border=-1", nethttp.StatusBadRequest, "usage"},
+		{"огромная рамка png", "/board?format=png&border=4611686018427387904", nethttp.StatusBadRequest, "usage"},
+		{"огромная рамка svg", "/board?format=svg&border=4611686018427387904", nethttp.StatusBadRequest, "usage"},
+		{"неверный параметр coords", "/board?coords=maybe", nethttp.StatusBadRequest, "usage"},
+		{"fen вместе с размером", "/board?size=8&fen=8/8/8/8/8/8/8/8", nethttp.StatusBadRequest, "usage"},
+		{"неверный fen", "/board?fen=8/8/8/8/8/8/8/9", nethttp.StatusUnprocessableEntity, "fen"},
+	}
+
+	s := newTestServer(Config{})
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := do(t, s, nethttp.MethodGet, tc.target, "")
+			got := decode[errorResponse](t, recorder)
+			if recorder.Code != tc.status || got.Status != tc.status || got.Class != tc.class || got.Message == "" {
+				t.Errorf("ожидались статус %d и класс %q, получено %d: %+v", tc.status, tc.class, recorder.Code, got)
+			}
+		})
+	}
+}
+
+func TestHandleBoard_ErrorDetails(t *testing.T) {
+	s := newTestServer(Config{MaxBoardSize: 20})
+
+	got := decode[errorResponse](t, do(t, s, nethttp.MethodGet, "/board?size=21", ""))
+	if got.Value != "21" || got.Min == nil || *got.Min != domain.MinBoardSize || got.Max == nil || *got.Max != 20 {
+		t.Errorf("ожидались значение и пределы сервера, получено %+v", got)
+	}
+
+	got = decode[errorResponse](t, do(t, s, nethttp.MethodGet, "/board?format=gif", ""))
+	if strings.Join(got.Allowed, ",") != "text,svg,png,json" {
+		t.Errorf("неверный список форматов: %v", got.Allowed)
+	}
+}
+
+func TestHandleValidateFEN(t *testing.T) {
+	s := newTestServer(Config{})
+
+	recorder := do(t, s, nethttp.MethodPost, "/fen/validate", `{"fen": "  `+domain.StartFEN+`  "}`)
+	if recorder.Code != nethttp.StatusOK {
+		t.Fatalf("ожидался статус 200, получено %d: %s", recorder.Code, recorder.Body.String())
+	}
+	got := decode[positionResponse](t, recorder)
+	expected := positionResponse{FEN: domain.StartFEN, Width: 8, Height: 8, SideToMove: "white",
+		Status: statusResponse{Result: "*"}}
+	if got != expected {
+		t.Errorf("ожидалось %+v, получено %+v", expected, got)
+	}
+
+	// Мат: черные поставили мат в два хода
+	mate := "rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3"
+	got = decode[positionResponse](t, do(t, s, nethttp.MethodPost, "/fen/validate", `{"fen":"`+mate+`"}`))
+	if !got.Status.Checkmate || !got.Status.InCheck || got.Status.Result != "0-1" {
+		t.Errorf("ожидался мат, получено %+v", got.Status)
+	}
+}
+
+func TestHandlePosition_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		status   int
+		class    string
+		field    int
+		position int
+	}{
+		{"неверный JSON", `{"fen":`, nethttp.StatusBadRequest, "usage", 0, 0},
+		{"лишнее поле", `{"fen":"8/8/8/8/8/8/8/8 w - - 0 1","moves":[]}`, nethttp.StatusBadRequest, "usage", 0, 0},
+		{"нет позиции", `{}`, nethttp.StatusBadRequest, "usage", 0, 0},
+		{"неверный fen", `{"fen":"8/8/8/8/8/8/8/9 w - - 0 1"}`, nethttp.StatusUnprocessableEntity, "fen", 1, 15},
+		{"два белых короля", `{"fen":"K6K/8/8/8/8/8/8/k7 w - - 0 1"}`, nethttp.StatusUnprocessableEntity, "fen", 0, 0},
+		{"слишком большое тело", `{"fen":"` + strings.Repeat(" ", 100) + `"}`, nethttp.StatusRequestEntityTooLarge, "usage", 0, 0},
+	}
+
+	s := newTestServer(Config{MaxBodyBytes: 64})
+	for _, target := range []string{"/fen/validate", "/moves"} {
+		for _, tc := range testCases {
+			t.Run(target+" "+tc.name, func(t *testing.T) {
+				recorder := do(t, s, nethttp.MethodPost, target, tc.body)
+				got := decode[errorResponse](t, recorder)
+				if recorder.Code != tc.status || got.Class != tc.class || got.Message == "" {
+					t.Errorf("ожидались статус %d и класс %q, получено %d: %+v", tc.status, tc.class, recorder.Code, got)
+				}
+				if got.Field != tc.field || got.Position != tc.position {
+					t.Errorf("ожидалось место ошибки %d:%d, получено %d:%d", tc.field, tc.position, got.Field, got.Position)
+				}
+			})
+		}
+	}
+}
+
+func TestHandleMoves(t *testing.T) {
+	s := newTestServer(Config{})
+
+	recorder := do(t, s, nethttp.MethodPost, "/moves", `{"fen":"`+domain.StartFEN+`"}`)
+	if recorder.Code != nethttp.StatusOK {
+		t.Fatalf("ожидался статус 200, получено %d: %s", recorder.Code, recorder.Body.String())
+	}
+	got := decode[movesResponse](t, recorder)
+	if got.FEN != domain.StartFEN || len(got.Moves) != 20 {
+		t.Fatalf("в начальной позиции 20 ходов, получено %d: %+v", len(got.Moves), got)
+	}
+	found := false
+	for _, move := range got.Moves {
+		found = found || move == moveResponse{UCI: "g1f3", SAN: "Nf3"}
+	}
+	if !found {
+		t.Errorf("в списке ходов нет g1f3 (Nf3): %+v", got.Moves)
+	}
+
+	// Превращение с шахом и пустой список ходов в мате
+	got = decode[movesResponse](t, do(t, s, nethttp.MethodPost, "/moves", `{"fen":"k7/4P3/8/8/8/8/8/K7 w - - 0 1"}`))
+	found = false
+	for _, move := range got.Moves {
+		found = found || move == moveResponse{UCI: "e7e8q", SAN: "e8=Q+"}
+	}
+	if !found {
+		t.Errorf("в списке ходов нет превращения e8=Q+: %+v", got.Moves)
+	}
+
+	recorder = do(t, s, nethttp.MethodPost, "/moves", `{"fen":"rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3"}`)
+	if !strings.Contains(recorder.Body.String(), `"moves":[]`) {
+		t.Errorf("в мате список ходов должен быть пустым массивом: %s", recorder.Body.String())
+	}
+}
+
+func TestHandler_Routes(t *testing.T) {
+	s := newTestServer(Config{})
+
+	if recorder := do(t, s, nethttp.MethodPost, "/board", ""); recorder.Code != nethttp.StatusMethodNotAllowed {
+		t.Errorf("POST /board: ожидался статус 405, получено %d", recorder.Code)
+	}
+	if recorder := do(t, s, nethttp.MethodGet, "/moves", ""); recorder.Code != nethttp.StatusMethodNotAllowed {
+		t.Errorf("GET /moves: ожидался статус 405, получено %d", recorder.Code)
+	}
+	if recorder := do(t, s, nethttp.MethodGet, "/unknown", ""); recorder.Code != nethttp.StatusNotFound {
+		t.Errorf("неизвестный путь: ожидался статус 404, получено %d", recorder.Code)
+	}
+}
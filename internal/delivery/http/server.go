@@ -0,0 +1,146 @@
+// Package http предоставляет сервис досок domain.BoardService по HTTP: доску
+// в текстовом и графических форматах, проверку позиций FEN и легальные ходы.
+// Ответы и ошибки передаются в JSON, кроме самой доски в форматах text, svg
+// и png; в формате json доска описывается по схеме usecase.JSONSchema.
+package http
+
+import (
+	"context"
+	"errors"
+	"net"
+	nethttp "net/http"
+	"time"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+// Config задает ограничения сервера. Нулевые поля заменяются значениями из
+// DefaultConfig.
+type Config struct {
+	// ReadTimeout - время на чтение запроса вместе с телом
+	ReadTimeout time.Duration
+	// WriteTimeout - время на запись ответа; должно быть больше HandlerTimeout,
+	// чтобы клиент успел получить ответ о превышении времени обработки
+	WriteTimeout time.Duration
+	// IdleTimeout - время ожидания следующего запроса в соединении keep-alive
+	IdleTimeout time.Duration
+	// HandlerTimeout - время на обработку запроса; после него клиент получает 503
+	HandlerTimeout time.Duration
+	// ShutdownTimeout - время, за которое начатые запросы должны завершиться
+	// при остановке сервера
+	ShutdownTimeout time.Duration
+	// MaxBoardSize - наибольшая ширина и высота доски в запросе. Ограничение
+	// меньше domain.MaxBoardSize: ответ строится в памяти целиком.
+	MaxBoardSize int
+	// MaxBodyBytes - наибольший размер тела запроса в байтах
+	MaxBodyBytes int64
+}
+
+// DefaultConfig возвращает ограничения сервера по умолчанию
+func DefaultConfig() Config {
+	return Config{
+		ReadTimeout:     5 * time.Second,
+		WriteTimeout:    30 * time.Second,
+		IdleTimeout:     time.Minute,
+		HandlerTimeout:  20 * time.Second,
+		ShutdownTimeout: 10 * time.Second,
+		MaxBoardSize:    64,
+		MaxBodyBytes:    64 << 10,
+	}
+}
+
+// withDefaults заменяет нулевые поля значениями по умолчанию
+func (c Config) withDefaults() Config {
+	defaults := DefaultConfig()
+	if c.ReadTimeout <= 0 {
+		c.ReadTimeout = defaults.ReadTimeout
+	}
+	if c.WriteTimeout <= 0 {
+		c.WriteTimeout = defaults.WriteTimeout
+	}
+	if c.IdleTimeout <= 0 {
+		c.IdleTimeout = defaults.IdleTimeout
+	}
+	if c.HandlerTimeout <= 0 {
+		c.HandlerTimeout = defaults.HandlerTimeout
+	}
+	if c.ShutdownTimeout <= 0 {
+		c.ShutdownTimeout = defaults.ShutdownTimeout
+	}
+	if c.MaxBoardSize <= 0 {
+		c.MaxBoardSize = defaults.MaxBoardSize
+	}
+	if c.MaxBodyBytes <= 0 {
+		c.MaxBodyBytes = defaults.MaxBodyBytes
+	}
+	return c
+}
+
+// Server обрабатывает запросы к сервису досок
+type Server struct {
+	service domain.BoardService
+	config  Config
+}
+
+// NewServer создает сервер для сервиса service с ограничениями config
+func NewServer(service domain.BoardService, config Config) *Server {
+	return &Server{service: service, config: config.withDefaults()}
+}
+
+// Handler возвращает обработчик всех маршрутов сервера. Обработка запроса
+// ограничена временем HandlerTimeout.
+func (s *Server) Handler() nethttp.Handler {
+	mux := nethttp.NewServeMux()
+	mux.HandleFunc("GET /board", s.handleBoard)
+	mux.HandleFunc("POST /fen/validate", s.handleValidateFEN)
+	mux.HandleFunc("POST /moves", s.handleMoves)
+	return nethttp.TimeoutHandler(mux, s.config.HandlerTimeout, timeoutBody())
+}
+
+// Serve принимает соединения из listener, пока не отменен ctx. После отмены
+// сервер перестает принимать соединения и ждет завершения начатых запросов
+// не дольше ShutdownTimeout, после чего закрывает оставшиеся соединения.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	server := &nethttp.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: s.config.ReadTimeout,
+		ReadTimeout:       s.config.ReadTimeout,
+		WriteTimeout:      s.config.WriteTimeout,
+		IdleTimeout:       s.config.IdleTimeout,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		server.Close()
+		return err
+	}
+	if err := <-serveErr; !errors.Is(err, nethttp.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// timeoutBody - тело ответа 503, когда обработка запроса не уложилась в
+// HandlerTimeout. TimeoutHandler отправляет одно тело всем клиентам, поэтому
+// сообщение на языке по умолчанию.
+func timeoutBody() string {
+	body, _ := encodeJSON(errorResponse{
+		Class:   "timeout",
+		Status:  nethttp.StatusServiceUnavailable,
+		Message: i18n.DefaultPrinter().Sprintf("http.timeout"),
+	})
+	return string(body)
+}
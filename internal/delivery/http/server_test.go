@@ -0,0 +1,164 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/usecase"
+)
+
+// blockingService - сервис досок, отрисовка в котором ждет сигнала release.
+// О начале отрисовки сообщает канал started.
+type blockingService struct {
+	domain.BoardService
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingService() *blockingService {
+	return &blockingService{
+		BoardService: usecase.NewBoardUsecase(usecase.NewBoardRepository()),
+		started:      make(chan struct{}, 1),
+		release:      make(chan struct{}),
+	}
+}
+
+func (s *blockingService) Render(w io.Writer, board *domain.Board, opts domain.RenderOptions) error {
+	s.started <- struct{}{}
+	<-s.release
+	return s.BoardService.Render(w, board, opts)
+}
+
+func TestConfig_Defaults(t *testing.T) {
+	config := Config{MaxBoardSize: 16}.withDefaults()
+	defaults := DefaultConfig()
+	if config.MaxBoardSize != 16 {
+		t.Errorf("заданное значение не должно меняться, получено %d", config.MaxBoardSize)
+	}
+	defaults.MaxBoardSize = 16
+	if config != defaults {
+		t.Errorf("нулевые поля должны заменяться значениями по умолчанию: %+v", config)
+	}
+	if defaults.WriteTimeout <= defaults.HandlerTimeout {
+		t.Error("время записи ответа должно быть больше времени обработки")
+	}
+}
+
+func TestHandler_Timeout(t *testing.T) {
+	service := newBlockingService()
+	defer close(service.release)
+	s := NewServer(service, Config{HandlerTimeout: 20 * time.Millisecond})
+
+	recorder := do(t, s, nethttp.MethodGet, "/board", "")
+	if recorder.Code != nethttp.StatusServiceUnavailable {
+		t.Fatalf("ожидался статус 503, получено %d", recorder.Code)
+	}
+	var got errorResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil || got.Class != "timeout" {
+		t.Errorf("ожидалась ошибка превышения времени в JSON, получено %q", recorder.Body.String())
+	}
+}
+
+func TestServe_GracefulShutdown(t *testing.T) {
+	service := newBlockingService()
+	s := NewServer(service, Config{})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("не удалось открыть порт: %v", err)
+	}
+	url := "http://" + listener.Addr().String()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve(ctx, listener)
+	}()
+
+	// Запрос начинает обрабатываться до остановки сервера и должен завершиться
+	type result struct {
+		status int
+		err    error
+	}
+	responses := make(chan result, 1)
+	go func() {
+		response, err := nethttp.Get(url + "/board?size=4")
+		if err != nil {
+			responses <- result{err: err}
+			return
+		}
+		response.Body.Close()
+		responses <- result{status: response.StatusCode}
+	}()
+	<-service.started
+
+	cancel()
+	// Сервер перестает принимать соединения, не дожидаясь начатого запроса
+	deadline := time.Now().Add(time.Second)
+	for {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if err != nil {
+			break
+		}
+		conn.Close()
+		if time.Now().After(deadline) {
+			t.Fatal("после остановки сервер не должен принимать соединения")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	select {
+	case err := <-served:
+		t.Fatalf("сервер остановился до завершения запроса: %v", err)
+	default:
+	}
+
+	close(service.release)
+	if got := <-responses; got.err != nil || got.status != nethttp.StatusOK {
+		t.Errorf("начатый запрос должен завершиться успешно, получено %d, %v", got.status, got.err)
+	}
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("ожидалась остановка без ошибки, получено %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("сервер не остановился")
+	}
+}
+
+func TestServe_ShutdownTimeout(t *testing.T) {
+	service := newBlockingService()
+	defer close(service.release)
+	s := NewServer(service, Config{ShutdownTimeout: 20 * time.Millisecond})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("не удалось открыть порт: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve(ctx, listener)
+	}()
+	go func() {
+		if response, err := nethttp.Get("http://" + listener.Addr().String() + "/board"); err == nil {
+			response.Body.Close()
+		}
+	}()
+	<-service.started
+
+	cancel()
+	select {
+	case err := <-served:
+		if err != context.DeadlineExceeded {
+			t.Errorf("ожидалась ошибка истечения времени остановки, получено %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("сервер не остановился по истечении ShutdownTimeout")
+	}
+}
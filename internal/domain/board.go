@@ -1,24 +1,116 @@
 package domain
 
+import "io"
+
 const (
 	DefaultBoardSize = 8
 	MinBoardSize     = 4
-	MaxBoardSize     = 100
+	MaxBoardSize     = 10000
 )
 
 // Board представляет шахматную доску
 type Board struct {
-	Size int
+	Width  int
+	Height int
+	// Squares - фигуры на клетках построчно, начиная с первой горизонтали:
+	// индекс клетки равен rank*Width + file. Пустой срез означает доску без фигур.
+	Squares []Piece
+
+	// Состояние партии, которое хранится в FEN вместе с расстановкой
+	SideToMove Color
+	Castling   CastlingRights
+	// EnPassant - клетка, на которую возможно взятие на проходе; nil, если взятия нет
+	EnPassant *Square
+	// HalfmoveClock - число полуходов без взятий и ходов пешками (правило 50 ходов)
+	HalfmoveClock int
+	// FullmoveNumber - номер хода, начиная с 1; увеличивается после хода черных
+	FullmoveNumber int
+}
+
+// IsSquare сообщает, является ли доска квадратной
+func (b *Board) IsSquare() bool {
+	return b.Width == b.Height
+}
+
+// Contains сообщает, находится ли клетка (file, rank) в пределах доски
+func (b *Board) Contains(file, rank int) bool {
+	return file >= 0 && file < b.Width && rank >= 0 && rank < b.Height
+}
+
+// PieceAt возвращает фигуру на клетке (file, rank); вне доски клетка считается пустой
+func (b *Board) PieceAt(file, rank int) Piece {
+	if len(b.Squares) == 0 || !b.Contains(file, rank) {
+		return Piece{}
+	}
+	return b.Squares[rank*b.Width+file]
+}
+
+// SetPiece ставит фигуру на клетку (file, rank), при необходимости создавая сетку клеток
+func (b *Board) SetPiece(file, rank int, piece Piece) {
+	if !b.Contains(file, rank) {
+		return
+	}
+	if len(b.Squares) == 0 {
+		b.Squares = make([]Piece, b.Width*b.Height)
+	}
+	b.Squares[rank*b.Width+file] = piece
+}
+
+// HasPieces сообщает, стоит ли на доске хотя бы одна фигура
+func (b *Board) HasPieces() bool {
+	for _, piece := range b.Squares {
+		if !piece.IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderOptions задает параметры отрисовки доски
+type RenderOptions struct {
+	// Format - формат вывода; пустая строка означает текстовый формат
+	Format Format
+	// Pattern - имя узора; пустая строка означает узор, выбранный в сервисе
+	Pattern string
+	// Coordinates включает обозначения вертикалей и горизонталей по краям доски
+	Coordinates bool
+	// Orientation задает сторону, с которой смотрят на доску
+	Orientation Orientation
+	// Theme - имя темы оформления; пустая строка означает тему по умолчанию
+	Theme string
+	// Palette - имя цветовой палитры для цветных тем
+	Palette string
+	// LightColor и DarkColor переопределяют цвета палитры (формат "#rrggbb")
+	LightColor string
+	DarkColor  string
+	// SquareSize - размер клетки в пикселях для графических форматов
+	SquareSize int
+	// Border - ширина рамки вокруг доски в пикселях для графических форматов
+	Border int
+	// Pieces - способ изображения фигур; пустая строка означает буквы
+	Pieces PieceStyle
+	// Highlights - цвета фона отдельных клеток (формат "#rrggbb") поверх узора,
+	// например курсор и возможные ходы. Учитываются цветными темами текстового формата.
+	Highlights map[Square]string
 }
 
 // BoardRepository определяет контракт для работы с досками
 type BoardRepository interface {
-	GenerateBoard(size int) *Board
+	GenerateBoard(width, height int, setup Setup) *Board
 }
 
 // BoardService определяет бизнес-логику для работы с досками
 type BoardService interface {
-	CreateBoard(size int) *Board
-	ValidateSize(size int) error
+	CreateBoard(width, height int, setup Setup) (*Board, error)
+	LoadFEN(fen string) (*Board, error)
+	LegalMoves(board *Board) ([]Move, error)
+	Perft(board *Board, depth int) (PerftResult, error)
+	Status(board *Board) (GameStatus, error)
+	NewGame(board *Board) (Game, error)
+	Hash(board *Board) (uint64, error)
+	ValidateSize(width, height int) error
 	GeneratePattern() string
+	Render(w io.Writer, board *Board, opts RenderOptions) error
+	SetPattern(name string) error
+	Patterns() []string
 }
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"io"
 	"testing"
 )
 
@@ -12,7 +13,7 @@ func TestConstants(t *testing.T) {
 	}{
 		{"DefaultBoardSize", DefaultBoardSize, 8},
 		{"MinBoardSize", MinBoardSize, 4},
-		{"MaxBoardSize", MaxBoardSize, 100},
+		{"MaxBoardSize", MaxBoardSize, 10000},
 	}
 
 	for _, tc := range testCases {
@@ -27,26 +28,100 @@ func TestConstants(t *testing.T) {
 
 func TestBoard_Initialization(t *testing.T) {
 	t.Run("создание доски с положительным размером", func(t *testing.T) {
-		board := &Board{Size: 10}
+		board := &Board{Width: 10, Height: 10}
 
-		if board.Size != 10 {
-			t.Errorf("ожидался размер 10, получен %d", board.Size)
+		if board.Width != 10 || board.Height != 10 {
+			t.Errorf("ожидался размер 10x10, получен %dx%d", board.Width, board.Height)
 		}
 	})
 
 	t.Run("создание доски с минимальным размером", func(t *testing.T) {
-		board := &Board{Size: MinBoardSize}
+		board := &Board{Width: MinBoardSize, Height: MinBoardSize}
 
-		if board.Size != MinBoardSize {
-			t.Errorf("ожидался размер %d, получен %d", MinBoardSize, board.Size)
+		if board.Width != MinBoardSize || board.Height != MinBoardSize {
+			t.Errorf("ожидался размер %d, получен %dx%d", MinBoardSize, board.Width, board.Height)
 		}
 	})
 
 	t.Run("создание доски с максимальным размером", func(t *testing.T) {
-		board := &Board{Size: MaxBoardSize}
+		board := &Board{Width: MaxBoardSize, Height: MaxBoardSize}
 
-		if board.Size != MaxBoardSize {
-			t.Errorf("ожидался размер %d, получен %d", MaxBoardSize, board.Size)
+		if board.Width != MaxBoardSize || board.Height != MaxBoardSize {
+			t.Errorf("ожидался размер %d, получен %dx%d", MaxBoardSize, board.Width, board.Height)
+		}
+	})
+
+	t.Run("создание прямоугольной доски", func(t *testing.T) {
+		board := &Board{Width: 10, Height: 8}
+
+		if board.Width != 10 || board.Height != 8 {
+			t.Errorf("ожидался размер 10x8, получен %dx%d", board.Width, board.Height)
+		}
+	})
+}
+
+func TestBoard_IsSquare(t *testing.T) {
+	testCases := []struct {
+		name     string
+		board    Board
+		expected bool
+	}{
+		{"квадратная доска", Board{Width: 8, Height: 8}, true},
+		{"доска капабланки", Board{Width: 10, Height: 8}, false},
+		{"доска сянци", Board{Width: 9, Height: 10}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.board.IsSquare(); actual != tc.expected {
+				t.Errorf("для доски %dx%d ожидалось %v, получено %v",
+					tc.board.Width, tc.board.Height, tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBoard_Pieces(t *testing.T) {
+	t.Run("доска без фигур", func(t *testing.T) {
+		board := &Board{Width: 8, Height: 8}
+
+		if board.HasPieces() {
+			t.Error("новая доска не должна содержать фигур")
+		}
+		if !board.PieceAt(0, 0).IsEmpty() {
+			t.Error("клетка новой доски должна быть пустой")
+		}
+	})
+
+	t.Run("установка фигуры", func(t *testing.T) {
+		board := &Board{Width: 10, Height: 8}
+		knight := Piece{Color: Black, Kind: Knight}
+		board.SetPiece(9, 7, knight)
+
+		if len(board.Squares) != 80 {
+			t.Fatalf("ожидалась сетка из 80 клеток, получено %d", len(board.Squares))
+		}
+		if board.PieceAt(9, 7) != knight {
+			t.Errorf("ожидался черный конь, получено %v", board.PieceAt(9, 7))
+		}
+		if !board.HasPieces() {
+			t.Error("доска с фигурой должна сообщать о наличии фигур")
+		}
+	})
+
+	t.Run("клетки вне доски", func(t *testing.T) {
+		board := &Board{Width: 4, Height: 4}
+		board.SetPiece(4, 0, Piece{Color: White, Kind: King})
+		board.SetPiece(-1, 2, Piece{Color: White, Kind: King})
+
+		if board.HasPieces() {
+			t.Error("фигуры вне доски не должны ставиться")
+		}
+		if board.Contains(4, 0) || board.Contains(0, -1) || !board.Contains(3, 3) {
+			t.Error("Contains неверно определяет границы доски")
+		}
+		if !board.PieceAt(10, 10).IsEmpty() {
+			t.Error("клетка вне доски должна считаться пустой")
 		}
 	})
 }
@@ -80,20 +155,56 @@ func TestInterfaceImplementation(t *testing.T) {
 // Mock реализации для проверки интерфейсов
 type mockRepository struct{}
 
-func (m *mockRepository) GenerateBoard(size int) *Board {
-	return &Board{Size: size}
+func (m *mockRepository) GenerateBoard(width, height int, setup Setup) *Board {
+	return &Board{Width: width, Height: height}
 }
 
 type mockService struct{}
 
-func (m *mockService) CreateBoard(size int) *Board {
-	return &Board{Size: size}
+func (m *mockService) CreateBoard(width, height int, setup Setup) (*Board, error) {
+	return &Board{Width: width, Height: height}, nil
+}
+
+func (m *mockService) LoadFEN(fen string) (*Board, error) {
+	return ParseFEN(fen)
+}
+
+func (m *mockService) LegalMoves(board *Board) ([]Move, error) {
+	return nil, nil
+}
+
+func (m *mockService) Perft(board *Board, depth int) (PerftResult, error) {
+	return PerftResult{}, nil
+}
+
+func (m *mockService) Status(board *Board) (GameStatus, error) {
+	return GameStatus{}, nil
 }
 
-func (m *mockService) ValidateSize(size int) error {
+func (m *mockService) NewGame(board *Board) (Game, error) {
+	return nil, nil
+}
+
+func (m *mockService) Hash(board *Board) (uint64, error) {
+	return 0, nil
+}
+
+func (m *mockService) ValidateSize(width, height int) error {
 	return nil
 }
 
 func (m *mockService) GeneratePattern() string {
 	return ""
 }
+
+func (m *mockService) Render(w io.Writer, board *Board, opts RenderOptions) error {
+	return nil
+}
+
+func (m *mockService) SetPattern(name string) error {
+	return nil
+}
+
+func (m *mockService) Patterns() []string {
+	return nil
+}
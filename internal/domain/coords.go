@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+
+	"chessboard/internal/i18n"
+)
+
+// Orientation задает, с чьей стороны смотрят на доску
+type Orientation int
+
+const (
+	// OrientationWhite - вид со стороны белых: первая горизонталь внизу, вертикаль "a" слева
+	OrientationWhite Orientation = iota
+	// OrientationBlack - вид со стороны черных: доска повернута на 180 градусов
+	OrientationBlack
+)
+
+func (o Orientation) String() string {
+	if o == OrientationBlack {
+		return "black"
+	}
+	return "white"
+}
+
+// ParseOrientation разбирает название стороны: white/w или black/b
+func ParseOrientation(input string) (Orientation, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "white", "w":
+		return OrientationWhite, nil
+	case "black", "b":
+		return OrientationBlack, nil
+	}
+	return OrientationWhite, &UnknownValueError{Label: "unknown.orientation", Value: input, Allowed: []string{"white", "black"}}
+}
+
+// FileName возвращает буквенное обозначение вертикали по ее индексу (с нуля).
+// После "z" идут "aa", "ab", ... как в нумерации столбцов электронных таблиц.
+func FileName(file int) string {
+	if file < 0 {
+		return ""
+	}
+
+	var name []byte
+	for n := file + 1; n > 0; n = (n - 1) / 26 {
+		name = append(name, byte('a'+(n-1)%26))
+	}
+	for i, j := 0, len(name)-1; i < j; i, j = i+1, j-1 {
+		name[i], name[j] = name[j], name[i]
+	}
+	return string(name)
+}
+
+// RankName возвращает числовое обозначение горизонтали по ее индексу (с нуля)
+func RankName(rank int) string {
+	if rank < 0 {
+		return ""
+	}
+	return strconv.Itoa(rank + 1)
+}
+
+// Square - клетка доски: индексы вертикали и горизонтали с нуля
+type Square struct {
+	File int
+	Rank int
+}
+
+// String возвращает алгебраическое обозначение клетки, например "e4" или "aa10"
+func (s Square) String() string {
+	return FileName(s.File) + RankName(s.Rank)
+}
+
+// ParseSquare разбирает алгебраическое обозначение клетки: буквы вертикали
+// и номер горизонтали. Принадлежность клетки доске не проверяется.
+func ParseSquare(input string) (Square, error) {
+	letters := 0
+	for letters < len(input) && input[letters] >= 'a' && input[letters] <= 'z' {
+		letters++
+	}
+	if letters == 0 || letters == len(input) {
+		return Square{}, i18n.Errorf("square.invalid", input)
+	}
+
+	file := 0
+	for _, letter := range input[:letters] {
+		file = file*26 + int(letter-'a') + 1
+	}
+
+	digits := input[letters:]
+	rank, err := strconv.Atoi(digits)
+	if err != nil || rank < 1 || digits[0] == '0' || digits[0] == '+' {
+		return Square{}, i18n.Errorf("square.invalid", input)
+	}
+	return Square{File: file - 1, Rank: rank - 1}, nil
+}
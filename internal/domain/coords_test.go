@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestFileName(t *testing.T) {
+	testCases := []struct {
+		file     int
+		expected string
+	}{
+		{0, "a"},
+		{7, "h"},
+		{25, "z"},
+		{26, "aa"},
+		{27, "ab"},
+		{51, "az"},
+		{52, "ba"},
+		{701, "zz"},
+		{702, "aaa"},
+		{-1, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if actual := FileName(tc.file); actual != tc.expected {
+				t.Errorf("для вертикали %d ожидалось '%s', получено '%s'", tc.file, tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRankName(t *testing.T) {
+	testCases := []struct {
+		rank     int
+		expected string
+	}{
+		{0, "1"},
+		{7, "8"},
+		{99, "100"},
+		{-1, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if actual := RankName(tc.rank); actual != tc.expected {
+				t.Errorf("для горизонтали %d ожидалось '%s', получено '%s'", tc.rank, tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseOrientation(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expected    Orientation
+		expectError bool
+	}{
+		{"white", OrientationWhite, false},
+		{"W", OrientationWhite, false},
+		{" black ", OrientationBlack, false},
+		{"b", OrientationBlack, false},
+		{"red", OrientationWhite, true},
+		{"", OrientationWhite, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			actual, err := ParseOrientation(tc.input)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("ожидалась ошибка для '%s', но ошибки нет", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("неожиданная ошибка для '%s': %v", tc.input, err)
+			}
+			if actual != tc.expected {
+				t.Errorf("для '%s' ожидалось %s, получено %s", tc.input, tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSquare(t *testing.T) {
+	testCases := []struct {
+		name     string
+		square   Square
+		expected string
+	}{
+		{"угол a1", Square{0, 0}, "a1"},
+		{"клетка e4", Square{4, 3}, "e4"},
+		{"угол h8", Square{7, 7}, "h8"},
+		{"двухбуквенная вертикаль", Square{26, 9}, "aa10"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.square.String(); actual != tc.expected {
+				t.Errorf("ожидалось '%s', получено '%s'", tc.expected, actual)
+			}
+			parsed, err := ParseSquare(tc.expected)
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if parsed != tc.square {
+				t.Errorf("для '%s' ожидалось %+v, получено %+v", tc.expected, tc.square, parsed)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "e", "4", "e0", "e04", "E4", "e4x", "e+4", "e-1"} {
+		t.Run("ошибка "+input, func(t *testing.T) {
+			if _, err := ParseSquare(input); err == nil {
+				t.Errorf("ожидалась ошибка для '%s'", input)
+			}
+		})
+	}
+}
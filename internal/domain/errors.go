@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"errors"
+
+	"chessboard/internal/i18n"
+)
+
+// Классы ошибок предметной области. Конкретные ошибки - структуры ниже - содержат
+// ошибочное значение и допустимые пределы и сравниваются с классами через errors.Is,
+// поэтому слой доставки может сопоставить ошибке код завершения или статус HTTP,
+// не разбирая текст сообщения.
+var (
+	// ErrInvalidSize - размер доски вне допустимых пределов
+	ErrInvalidSize = i18n.New("error.invalid_size")
+	// ErrSizeTooSmall - размер доски меньше MinBoardSize
+	ErrSizeTooSmall = i18n.New("error.size_too_small")
+	// ErrSizeTooLarge - размер доски больше MaxBoardSize
+	ErrSizeTooLarge = i18n.New("error.size_too_large")
+
+	// ErrInvalidNumber - строка не является неотрицательным целым числом
+	ErrInvalidNumber = i18n.New("error.invalid_number")
+	// ErrNegativeNumber - отрицательное число там, где допустимы только неотрицательные
+	ErrNegativeNumber = i18n.New("error.negative_number")
+	// ErrFractionalNumber - дробное число там, где допустимы только целые
+	ErrFractionalNumber = i18n.New("error.fractional_number")
+	// ErrNotANumber - строка не является числом
+	ErrNotANumber = i18n.New("error.not_a_number")
+
+	// ErrSetupUnavailable - расстановка невозможна на доске такого размера
+	ErrSetupUnavailable = i18n.New("error.setup_unavailable")
+	// ErrUnknownValue - неизвестное название стороны, формата, стиля или расстановки
+	ErrUnknownValue = i18n.New("error.unknown_value")
+	// ErrInvalidFEN - неверная позиция в нотации FEN
+	ErrInvalidFEN = i18n.New("error.invalid_fen")
+	// ErrInvalidPosition - позиция записана верно, но не может возникнуть в партии:
+	// например, у стороны два короля или под шахом король стороны без хода
+	ErrInvalidPosition = i18n.New("error.invalid_position")
+	// ErrIllegalMove - ход невозможен в текущей позиции
+	ErrIllegalMove = i18n.New("error.illegal_move")
+	// ErrInvalidPattern - узор нельзя зарегистрировать или разобрать
+	ErrInvalidPattern = i18n.New("error.invalid_pattern")
+)
+
+// ErrorClass - класс ошибки для слоя доставки: по нему консоль выбирает код
+// завершения, а сервер HTTP - статус ответа. Значение - имя класса
+// в машиночитаемом выводе ошибки.
+type ErrorClass string
+
+// Классы ошибок предметной области
+const (
+	// ClassUsage - неверное значение параметра: число, название или узор
+	ClassUsage ErrorClass = "usage"
+	// ClassSize - размер доски вне пределов или невозможная расстановка
+	ClassSize ErrorClass = "size"
+	// ClassFEN - неверная запись позиции или невозможная позиция
+	ClassFEN ErrorClass = "fen"
+	// ClassMove - невозможный ход
+	ClassMove ErrorClass = "move"
+)
+
+// Classify возвращает класс ошибки предметной области, определяя его через
+// errors.Is. Для остальных ошибок возвращает false: их смысл знает только
+// вызывающий код.
+func Classify(err error) (ErrorClass, bool) {
+	switch {
+	case errors.Is(err, ErrInvalidSize), errors.Is(err, ErrSetupUnavailable):
+		return ClassSize, true
+	case errors.Is(err, ErrInvalidFEN), errors.Is(err, ErrInvalidPosition):
+		return ClassFEN, true
+	case errors.Is(err, ErrIllegalMove):
+		return ClassMove, true
+	case errors.Is(err, ErrInvalidNumber), errors.Is(err, ErrUnknownValue), errors.Is(err, ErrInvalidPattern):
+		return ClassUsage, true
+	}
+	return "", false
+}
+
+// Измерения доски в SizeError
+const (
+	DimensionWidth  i18n.Key = "size.width"
+	DimensionHeight i18n.Key = "size.height"
+)
+
+// SizeError сообщает, что ширина или высота доски вне пределов [Min, Max].
+// Соответствует ErrInvalidSize и, в зависимости от значения, ErrSizeTooSmall
+// или ErrSizeTooLarge.
+type SizeError struct {
+	// Dimension - измерение: DimensionWidth или DimensionHeight
+	Dimension i18n.Key
+	Value     int
+	Min       int
+	Max       int
+}
+
+func (e *SizeError) Error() string {
+	return e.Localize(i18n.DefaultPrinter())
+}
+
+func (e *SizeError) Localize(p *i18n.Printer) string {
+	if e.Value < e.Min {
+		return p.Sprintf("size.too_small", e.Min, e.Dimension, e.Value)
+	}
+	return p.Sprintf("size.too_large", e.Max, e.Dimension, e.Value)
+}
+
+func (e *SizeError) Is(target error) bool {
+	switch target {
+	case ErrInvalidSize:
+		return true
+	case ErrSizeTooSmall:
+		return e.Value < e.Min
+	case ErrSizeTooLarge:
+		return e.Value > e.Max
+	}
+	return false
+}
+
+// NumberError сообщает, что строку Input не удалось разобрать как неотрицательное
+// целое число. Kind - причина: ErrNegativeNumber, ErrFractionalNumber или
+// ErrNotANumber. Соответствует ErrInvalidNumber и своей причине.
+type NumberError struct {
+	Input string
+	Kind  error
+}
+
+func (e *NumberError) Error() string {
+	return e.Localize(i18n.DefaultPrinter())
+}
+
+func (e *NumberError) Localize(p *i18n.Printer) string {
+	return p.Sprintf("error.with_value", e.Kind, e.Input)
+}
+
+func (e *NumberError) Is(target error) bool {
+	return target == ErrInvalidNumber
+}
+
+func (e *NumberError) Unwrap() error {
+	return e.Kind
+}
+
+// SetupError сообщает, что расстановка Setup невозможна на доске Width x Height.
+// Соответствует ErrSetupUnavailable.
+type SetupError struct {
+	Setup  Setup
+	Width  int
+	Height int
+	// RequiredWidth и RequiredHeight - размер доски, на котором расстановка возможна
+	RequiredWidth  int
+	RequiredHeight int
+}
+
+func (e *SetupError) Error() string {
+	return e.Localize(i18n.DefaultPrinter())
+}
+
+func (e *SetupError) Localize(p *i18n.Printer) string {
+	return p.Sprintf(setupErrors[e.Setup], e.RequiredWidth, e.RequiredHeight, e.Width, e.Height)
+}
+
+func (e *SetupError) Is(target error) bool {
+	return target == ErrSetupUnavailable
+}
+
+// setupErrors - сообщения SetupError для каждой расстановки
+var setupErrors = map[Setup]i18n.Key{
+	SetupEmpty:    "setup.empty_unavailable",
+	SetupStandard: "setup.standard_unavailable",
+}
+
+// UnknownValueError сообщает о неизвестном названии. Allowed - допустимые
+// значения, которые можно показать пользователю. Соответствует ErrUnknownValue.
+type UnknownValueError struct {
+	// Label - начало сообщения, например "unknown.orientation" ("неизвестная сторона")
+	Label   i18n.Key
+	Value   string
+	Allowed []string
+}
+
+func (e *UnknownValueError) Error() string {
+	return e.Localize(i18n.DefaultPrinter())
+}
+
+func (e *UnknownValueError) Localize(p *i18n.Printer) string {
+	return p.Sprintf("error.with_value", e.Label, e.Value)
+}
+
+func (e *UnknownValueError) Is(target error) bool {
+	return target == ErrUnknownValue
+}
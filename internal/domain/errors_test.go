@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSizeError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      *SizeError
+		expected string
+		tooSmall bool
+		tooLarge bool
+	}{
+		{"меньше минимума", &SizeError{Dimension: DimensionWidth, Value: 3, Min: 4, Max: 10},
+			"размер доски не может быть меньше 4 (ширина 3)", true, false},
+		{"больше максимума", &SizeError{Dimension: DimensionHeight, Value: 11, Min: 4, Max: 10},
+			"размер доски не может превышать 10 (высота 11)", false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.err.Error(); got != tc.expected {
+				t.Errorf("ожидалось сообщение %q, получено %q", tc.expected, got)
+			}
+			if !errors.Is(tc.err, ErrInvalidSize) {
+				t.Error("ошибка размера должна соответствовать ErrInvalidSize")
+			}
+			if errors.Is(tc.err, ErrSizeTooSmall) != tc.tooSmall || errors.Is(tc.err, ErrSizeTooLarge) != tc.tooLarge {
+				t.Errorf("ожидалось ErrSizeTooSmall=%v, ErrSizeTooLarge=%v", tc.tooSmall, tc.tooLarge)
+			}
+		})
+	}
+}
+
+func TestNumberError(t *testing.T) {
+	err := error(&NumberError{Input: "-8", Kind: ErrNegativeNumber})
+
+	if got := err.Error(); got != "отрицательные числа не поддерживаются: '-8'" {
+		t.Errorf("неожиданное сообщение: %q", got)
+	}
+	if !errors.Is(err, ErrInvalidNumber) || !errors.Is(err, ErrNegativeNumber) {
+		t.Error("ошибка числа должна соответствовать ErrInvalidNumber и своей причине")
+	}
+	if errors.Is(err, ErrFractionalNumber) {
+		t.Error("ошибка не должна соответствовать чужой причине")
+	}
+}
+
+func TestSetupError(t *testing.T) {
+	err := error(&SetupError{Setup: SetupStandard, Width: 10, Height: 8, RequiredWidth: 8, RequiredHeight: 8})
+
+	if got := err.Error(); got != "стандартная расстановка возможна только на доске 8x8, получено 10x8" {
+		t.Errorf("неожиданное сообщение: %q", got)
+	}
+	if !errors.Is(err, ErrSetupUnavailable) {
+		t.Error("ошибка расстановки должна соответствовать ErrSetupUnavailable")
+	}
+}
+
+func TestClassify(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected ErrorClass
+		ok       bool
+	}{
+		{"размер", &SizeError{Dimension: DimensionWidth, Value: 3, Min: 4, Max: 10}, ClassSize, true},
+		{"расстановка", &SetupError{Setup: SetupStandard, Width: 10, Height: 8}, ClassSize, true},
+		{"FEN", &FENError{Reason: errors.New("пустая строка")}, ClassFEN, true},
+		{"позиция", fmt.Errorf("%w: два короля", ErrInvalidPosition), ClassFEN, true},
+		{"ход", fmt.Errorf("%w: 'e5'", ErrIllegalMove), ClassMove, true},
+		{"число", &NumberError{Input: "x", Kind: ErrNotANumber}, ClassUsage, true},
+		{"неизвестное значение", &UnknownValueError{Label: "unknown.theme", Value: "neon"}, ClassUsage, true},
+		{"узор", fmt.Errorf("%w: 'rings'", ErrInvalidPattern), ClassUsage, true},
+		{"обернутая ошибка", fmt.Errorf("доска: %w", &SizeError{Dimension: DimensionHeight, Value: 2, Min: 4, Max: 10}), ClassSize, true},
+		{"прочая ошибка", errors.New("сбой"), "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			class, ok := Classify(tc.err)
+			if class != tc.expected || ok != tc.ok {
+				t.Errorf("ожидалось %q и %v, получено %q и %v", tc.expected, tc.ok, class, ok)
+			}
+		})
+	}
+}
+
+func TestErrorsAs_Wrapped(t *testing.T) {
+	// Классы и структуры ошибок должны распознаваться и через обертки fmt.Errorf
+	err := fmt.Errorf("сторона: %w", &UnknownValueError{Label: "unknown.orientation", Value: "red", Allowed: []string{"white", "black"}})
+
+	if !errors.Is(err, ErrUnknownValue) {
+		t.Error("обернутая ошибка должна соответствовать ErrUnknownValue")
+	}
+	var unknown *UnknownValueError
+	if !errors.As(err, &unknown) {
+		t.Fatal("errors.As должен найти UnknownValueError")
+	}
+	if unknown.Value != "red" || len(unknown.Allowed) != 2 {
+		t.Errorf("неожиданные поля ошибки: %+v", unknown)
+	}
+}
+
+func TestParsers_ReturnTypedErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		err   error
+		class error
+	}{
+		{"сторона", func() error { _, err := ParseOrientation("red"); return err }(), ErrUnknownValue},
+		{"формат", func() error { _, err := ParseFormat("bmp"); return err }(), ErrUnknownValue},
+		{"стиль фигур", func() error { _, err := ParsePieceStyle("emoji"); return err }(), ErrUnknownValue},
+		{"расстановка", func() error { _, err := ParseSetup("random"); return err }(), ErrUnknownValue},
+		{"расстановка не по размеру", SetupStandard.Validate(10, 8), ErrSetupUnavailable},
+		{"FEN", func() error { _, err := ParseFEN("8/8 w"); return err }(), ErrInvalidFEN},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if !errors.Is(tc.err, tc.class) {
+				t.Errorf("ошибка %v должна соответствовать %v", tc.err, tc.class)
+			}
+		})
+	}
+}
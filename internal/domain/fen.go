@@ -0,0 +1,431 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"chessboard/internal/i18n"
+)
+
+// StartFEN - начальная позиция классических шахмат
+const StartFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+// maxFENSquares ограничивает число клеток доски из FEN: в отличие от пустой доски,
+// расстановка хранится в памяти целиком
+const maxFENSquares = 1 << 20
+
+// CastlingRights - права на рокировку обеих сторон (битовая маска)
+type CastlingRights uint8
+
+const (
+	WhiteKingside CastlingRights = 1 << iota
+	WhiteQueenside
+	BlackKingside
+	BlackQueenside
+
+	NoCastling  CastlingRights = 0
+	AllCastling                = WhiteKingside | WhiteQueenside | BlackKingside | BlackQueenside
+)
+
+// castlingLetters - обозначения прав на рокировку в порядке записи FEN
+var castlingLetters = [...]struct {
+	right  CastlingRights
+	letter rune
+}{
+	{WhiteKingside, 'K'},
+	{WhiteQueenside, 'Q'},
+	{BlackKingside, 'k'},
+	{BlackQueenside, 'q'},
+}
+
+// Has сообщает, что среди прав есть все права из other
+func (c CastlingRights) Has(other CastlingRights) bool {
+	return c&other == other
+}
+
+// String возвращает права в записи FEN: "KQkq", "Kq" или "-"
+func (c CastlingRights) String() string {
+	var result strings.Builder
+	for _, cl := range castlingLetters {
+		if c.Has(cl.right) {
+			result.WriteRune(cl.letter)
+		}
+	}
+	if result.Len() == 0 {
+		return "-"
+	}
+	return result.String()
+}
+
+// fenFieldNames - названия полей FEN для сообщений об ошибках
+var fenFieldNames = [...]i18n.Key{
+	"fen.field.placement",
+	"fen.field.side_to_move",
+	"fen.field.castling",
+	"fen.field.en_passant",
+	"fen.field.halfmove_clock",
+	"fen.field.fullmove_number",
+}
+
+// FENError описывает ошибку разбора FEN: в каком поле и на каком символе она найдена
+type FENError struct {
+	// Field - номер поля FEN, начиная с 1; 0, если ошибка относится ко всей строке
+	Field int
+	// Pos - позиция ошибочного символа в строке FEN, начиная с 1
+	Pos int
+	// Char - ошибочный символ; 0, если ошибка относится к полю целиком
+	Char rune
+	// Reason - описание ошибки
+	Reason error
+}
+
+func (e *FENError) Error() string {
+	return e.Localize(i18n.DefaultPrinter())
+}
+
+func (e *FENError) Localize(p *i18n.Printer) string {
+	switch {
+	case e.Field == 0:
+		return p.Sprintf("fen.error", e.Reason)
+	case e.Char == 0:
+		return p.Sprintf("fen.error_at", e.Field, fenFieldNames[e.Field-1], e.Pos, e.Reason)
+	default:
+		return p.Sprintf("fen.error_at_char", e.Field, fenFieldNames[e.Field-1], e.Pos, e.Char, e.Reason)
+	}
+}
+
+// Is сообщает, что ошибка относится к классу ErrInvalidFEN
+func (e *FENError) Is(target error) bool {
+	return target == ErrInvalidFEN
+}
+
+// fenField - поле FEN и его смещение в исходной строке
+type fenField struct {
+	index  int
+	offset int
+	text   string
+}
+
+// errorAt возвращает ошибку, указывающую на символ поля со смещением i (в байтах)
+func (f fenField) errorAt(i int, key i18n.Key, args ...any) *FENError {
+	ch, _ := utf8.DecodeRuneInString(f.text[i:])
+	return &FENError{Field: f.index + 1, Pos: f.offset + i + 1, Char: ch, Reason: i18n.Errorf(key, args...)}
+}
+
+// error возвращает ошибку, относящуюся к полю целиком
+func (f fenField) error(key i18n.Key, args ...any) *FENError {
+	return &FENError{Field: f.index + 1, Pos: f.offset + 1, Reason: i18n.Errorf(key, args...)}
+}
+
+// ParseFEN разбирает позицию в нотации Форсайта-Эдвардса. Поддерживаются доски
+// любого размера: число горизонталей задает высоту, длина первой - ширину,
+// несколько пустых клеток подряд записываются числом, в том числе многозначным.
+// Все шесть полей обязательны и разделяются ровно одним пробелом.
+func ParseFEN(input string) (*Board, error) {
+	fields, err := splitFEN(input)
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := parsePlacement(fields[0])
+	if err != nil {
+		return nil, err
+	}
+	if board.SideToMove, err = parseSideToMove(fields[1]); err != nil {
+		return nil, err
+	}
+	if board.Castling, err = parseCastling(fields[2], board); err != nil {
+		return nil, err
+	}
+	if board.EnPassant, err = parseEnPassant(fields[3], board); err != nil {
+		return nil, err
+	}
+	if board.HalfmoveClock, err = parseFENNumber(fields[4], 0); err != nil {
+		return nil, err
+	}
+	if board.FullmoveNumber, err = parseFENNumber(fields[5], 1); err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// splitFEN делит строку на шесть полей, запоминая их смещения для сообщений об ошибках
+func splitFEN(input string) ([]fenField, error) {
+	trimmed := strings.TrimLeft(input, " ")
+	offset := len(input) - len(trimmed)
+	trimmed = strings.TrimRight(trimmed, " ")
+	if trimmed == "" {
+		return nil, &FENError{Reason: i18n.Errorf("fen.empty")}
+	}
+
+	parts := strings.Split(trimmed, " ")
+	if len(parts) != len(fenFieldNames) {
+		return nil, &FENError{Reason: i18n.Errorf("fen.field_count", len(fenFieldNames), len(parts))}
+	}
+
+	fields := make([]fenField, len(parts))
+	for i, part := range parts {
+		fields[i] = fenField{index: i, offset: offset, text: part}
+		if part == "" {
+			return nil, fields[i].error("fen.extra_space")
+		}
+		offset += len(part) + 1
+	}
+	return fields, nil
+}
+
+// parsePlacement разбирает расстановку фигур; горизонтали перечисляются сверху вниз
+func parsePlacement(f fenField) (*Board, error) {
+	var (
+		ranks   [][]Piece
+		current []Piece
+		width   int
+		empty   int
+		// Смещения пешек и номера их горизонталей для проверки крайних горизонталей
+		pawnOffsets []int
+		pawnRows    []int
+	)
+
+	flushEmpty := func() {
+		for ; empty > 0; empty-- {
+			current = append(current, Piece{})
+		}
+	}
+	endRank := func(i int) *FENError {
+		flushEmpty()
+		if len(current) == 0 {
+			return f.errorAt(i, "fen.empty_rank")
+		}
+		if width == 0 {
+			width = len(current)
+		} else if len(current) != width {
+			return f.errorAt(i, "fen.rank_width",
+				len(ranks)+1, len(current), width)
+		}
+		if len(ranks) >= MaxBoardSize || width*(len(ranks)+1) > maxFENSquares {
+			return f.errorAt(i, "fen.board_too_large")
+		}
+		ranks = append(ranks, current)
+		current = nil
+		return nil
+	}
+
+	for i, ch := range f.text {
+		switch {
+		case ch >= '0' && ch <= '9':
+			if empty == 0 && ch == '0' {
+				return nil, f.errorAt(i, "fen.leading_zero_empty")
+			}
+			empty = empty*10 + int(ch-'0')
+			if width > 0 && len(current)+empty > width {
+				return nil, f.errorAt(i, "fen.rank_too_long", len(ranks)+1, width)
+			}
+			if len(current)+empty > MaxBoardSize {
+				return nil, f.errorAt(i, "fen.board_too_large")
+			}
+		case ch == '/':
+			if err := endRank(i); err != nil {
+				return nil, err
+			}
+		default:
+			piece, ok := PieceFromLetter(ch)
+			if !ok {
+				return nil, f.errorAt(i, "fen.unknown_piece")
+			}
+			flushEmpty()
+			if width > 0 && len(current) >= width {
+				return nil, f.errorAt(i, "fen.rank_too_long", len(ranks)+1, width)
+			}
+			if piece.Kind == Pawn {
+				pawnOffsets = append(pawnOffsets, i)
+				pawnRows = append(pawnRows, len(ranks))
+			}
+			current = append(current, piece)
+		}
+	}
+	if err := endRank(len(f.text) - 1); err != nil {
+		return nil, err
+	}
+
+	height := len(ranks)
+	for k, offset := range pawnOffsets {
+		if pawnRows[k] == 0 || pawnRows[k] == height-1 {
+			return nil, f.errorAt(offset, "fen.pawn_on_edge")
+		}
+	}
+
+	board := &Board{Width: width, Height: height, Squares: make([]Piece, 0, width*height)}
+	for i := height - 1; i >= 0; i-- {
+		board.Squares = append(board.Squares, ranks[i]...)
+	}
+	return board, nil
+}
+
+// parseSideToMove разбирает очередь хода: "w" или "b"
+func parseSideToMove(f fenField) (Color, error) {
+	switch f.text {
+	case "w":
+		return White, nil
+	case "b":
+		return Black, nil
+	}
+	if f.text[0] != 'w' && f.text[0] != 'b' {
+		return White, f.errorAt(0, "fen.side_expected")
+	}
+	return White, f.errorAt(1, "fen.side_expected")
+}
+
+// parseCastling разбирает права на рокировку и сверяет их с расстановкой:
+// король должен стоять на своей крайней горизонтали, ладья - в ее углу
+func parseCastling(f fenField, board *Board) (CastlingRights, error) {
+	if f.text == "-" {
+		return NoCastling, nil
+	}
+
+	var rights CastlingRights
+	for i, ch := range f.text {
+		right, color, rookFile := NoCastling, White, 0
+		for _, cl := range castlingLetters {
+			if cl.letter == ch {
+				right = cl.right
+			}
+		}
+		switch right {
+		case NoCastling:
+			return 0, f.errorAt(i, "fen.unknown_castling")
+		case BlackKingside, BlackQueenside:
+			color = Black
+		}
+		if right == WhiteKingside || right == BlackKingside {
+			rookFile = board.Width - 1
+		}
+		if rights.Has(right) {
+			return 0, f.errorAt(i, "fen.duplicate_castling")
+		}
+		if !board.canCastle(color, rookFile) {
+			return 0, f.errorAt(i, "fen.castling_pieces_missing")
+		}
+		rights |= right
+	}
+	return rights, nil
+}
+
+// canCastle проверяет, что король стороны стоит на ее крайней горизонтали,
+// а ладья - в углу rookFile той же горизонтали
+func (b *Board) canCastle(color Color, rookFile int) bool {
+	rank := b.HomeRank(color)
+	if b.PieceAt(rookFile, rank) != (Piece{Color: color, Kind: Rook}) {
+		return false
+	}
+	for file := 1; file < b.Width-1; file++ {
+		if b.PieceAt(file, rank) == (Piece{Color: color, Kind: King}) {
+			return true
+		}
+	}
+	return false
+}
+
+// HomeRank возвращает крайнюю горизонталь стороны: первую для белых, последнюю для черных
+func (b *Board) HomeRank(color Color) int {
+	if color == Black {
+		return b.Height - 1
+	}
+	return 0
+}
+
+// parseEnPassant разбирает клетку взятия на проходе и проверяет, что перед ней
+// стоит пешка противника, только что сделавшая ход через эту клетку
+func parseEnPassant(f fenField, board *Board) (*Square, error) {
+	if f.text == "-" {
+		return nil, nil
+	}
+
+	square, err := ParseSquare(f.text)
+	if err != nil {
+		return nil, f.error("fen.invalid_square", f.text)
+	}
+	if !board.Contains(square.File, square.Rank) {
+		return nil, f.error("fen.square_off_board", square, board.Width, board.Height)
+	}
+
+	// Пешка противника прошла через клетку на ход вперед от своей исходной позиции
+	pawnColor := board.SideToMove.Opposite()
+	direction := 1
+	if pawnColor == Black {
+		direction = -1
+	}
+	expectedRank := board.HomeRank(pawnColor) + 2*direction
+	if square.Rank != expectedRank {
+		return nil, f.error("fen.en_passant_rank", RankName(expectedRank))
+	}
+	if !board.PieceAt(square.File, square.Rank).IsEmpty() {
+		return nil, f.error("fen.square_occupied", square)
+	}
+	if board.PieceAt(square.File, square.Rank+direction) != (Piece{Color: pawnColor, Kind: Pawn}) {
+		return nil, f.error("fen.en_passant_pawn",
+			Square{File: square.File, Rank: square.Rank + direction}, square)
+	}
+	return &square, nil
+}
+
+// parseFENNumber разбирает счетчик полуходов или номер хода не меньше minimum
+func parseFENNumber(f fenField, minimum int) (int, error) {
+	for i, ch := range f.text {
+		if ch < '0' || ch > '9' {
+			return 0, f.errorAt(i, "fen.number_expected")
+		}
+		if i == 0 && ch == '0' && len(f.text) > 1 {
+			return 0, f.errorAt(i, "fen.leading_zero")
+		}
+	}
+
+	value, err := strconv.Atoi(f.text)
+	if err != nil {
+		return 0, f.error("fen.number_too_large")
+	}
+	if value < minimum {
+		return 0, f.error("fen.number_too_small", minimum)
+	}
+	return value, nil
+}
+
+// FEN возвращает позицию в нотации Форсайта-Эдвардса
+func (b *Board) FEN() string {
+	var result strings.Builder
+
+	for rank := b.Height - 1; rank >= 0; rank-- {
+		empty := 0
+		for file := 0; file < b.Width; file++ {
+			piece := b.PieceAt(file, rank)
+			if piece.IsEmpty() {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				result.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			result.WriteRune(piece.Letter())
+		}
+		if empty > 0 {
+			result.WriteString(strconv.Itoa(empty))
+		}
+		if rank > 0 {
+			result.WriteByte('/')
+		}
+	}
+
+	side := "w"
+	if b.SideToMove == Black {
+		side = "b"
+	}
+	enPassant := "-"
+	if b.EnPassant != nil {
+		enPassant = b.EnPassant.String()
+	}
+	// Номер хода у доски без состояния партии считается первым
+	fmt.Fprintf(&result, " %s %s %s %d %d", side, b.Castling, enPassant, b.HalfmoveClock, max(b.FullmoveNumber, 1))
+	return result.String()
+}
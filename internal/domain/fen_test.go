@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseFEN_StartPosition(t *testing.T) {
+	board, err := ParseFEN(StartFEN)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	expected := &Board{Width: 8, Height: 8}
+	expected.PlaceStandardPieces()
+
+	if board.Width != 8 || board.Height != 8 {
+		t.Fatalf("ожидалась доска 8x8, получено %dx%d", board.Width, board.Height)
+	}
+	for i := range expected.Squares {
+		if board.Squares[i] != expected.Squares[i] {
+			t.Fatalf("клетка %d: ожидалось %v, получено %v", i, expected.Squares[i], board.Squares[i])
+		}
+	}
+	if board.SideToMove != White || board.Castling != AllCastling || board.EnPassant != nil ||
+		board.HalfmoveClock != 0 || board.FullmoveNumber != 1 {
+		t.Errorf("неверное состояние партии: %+v", board)
+	}
+	if fen := expected.FEN(); fen != StartFEN {
+		t.Errorf("FEN начальной позиции: ожидалось %q, получено %q", StartFEN, fen)
+	}
+}
+
+func TestParseFEN_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		name string
+		fen  string
+	}{
+		{"начальная позиция", StartFEN},
+		{"после 1.e4", "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1"},
+		{"после 1.e4 c5", "rnbqkbnr/pp1ppppp/8/2p5/4P3/8/PPPP1PPP/RNBQKBNR w KQkq c6 0 2"},
+		{"частичные права рокировки", "r3k2r/8/8/8/8/8/8/R3K2R w Kq - 12 40"},
+		{"без рокировки", "8/8/4k3/8/8/4K3/8/8 b - - 99 120"},
+		{"прямоугольная доска", "10/10/3k6/10/10/6K3/10/10 w - - 0 1"},
+		{"доска 4x4", "k3/4/4/3K w - - 0 1"},
+		{"доска 12x10", "r4k5r/pppppppppppp/12/12/12/12/12/12/PPPPPPPPPPPP/R4K5R w Qq - 0 1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			board, err := ParseFEN(tc.fen)
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if actual := board.FEN(); actual != tc.fen {
+				t.Errorf("ожидалось %q, получено %q", tc.fen, actual)
+			}
+		})
+	}
+}
+
+func TestParseFEN_State(t *testing.T) {
+	board, err := ParseFEN("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b Kq e3 3 17")
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	if board.SideToMove != Black {
+		t.Error("ожидался ход черных")
+	}
+	if board.Castling != WhiteKingside|BlackQueenside {
+		t.Errorf("ожидались права Kq, получено %s", board.Castling)
+	}
+	if board.EnPassant == nil || *board.EnPassant != (Square{File: 4, Rank: 2}) {
+		t.Errorf("ожидалось взятие на проходе на e3, получено %v", board.EnPassant)
+	}
+	if board.HalfmoveClock != 3 || board.FullmoveNumber != 17 {
+		t.Errorf("ожидались счетчики 3 и 17, получено %d и %d", board.HalfmoveClock, board.FullmoveNumber)
+	}
+	if piece := board.PieceAt(4, 3); piece != (Piece{Color: White, Kind: Pawn}) {
+		t.Errorf("на e4 ожидалась белая пешка, получено %v", piece)
+	}
+}
+
+// fenErrorWant - ожидаемые поля FENError с причиной в виде текста
+type fenErrorWant struct {
+	Field  int
+	Pos    int
+	Char   rune
+	Reason string
+}
+
+func TestParseFEN_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fen      string
+		expected fenErrorWant
+	}{
+		{"пустая строка", "  ", fenErrorWant{Reason: "пустая строка"}},
+		{"не хватает полей", "8/8/8/8/8/8/8/8 w - -", fenErrorWant{Reason: "ожидалось 6 полей через пробел, получено 4"}},
+		{"двойной пробел", "8/8/8/8/8/8/8/8 w  - - 0 1",
+			fenErrorWant{Reason: "ожидалось 6 полей через пробел, получено 7"}},
+		{"неизвестная фигура", "rnbqkbnr/pppppppp/8/8/4X3/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+			fenErrorWant{Field: 1, Pos: 24, Char: 'X', Reason: "неизвестная фигура"}},
+		{"короткая горизонталь", "rnbqkbnr/ppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w - - 0 1",
+			fenErrorWant{Field: 1, Pos: 17, Char: '/', Reason: "горизонталь 2 содержит 7 клеток, ожидалось 8"}},
+		{"длинная горизонталь", "rnbqkbnr/ppppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w - - 0 1",
+			fenErrorWant{Field: 1, Pos: 18, Char: 'p', Reason: "горизонталь 2 длиннее первой (8 клеток)"}},
+		{"многозначное число пустых клеток", "8/44/8/8/8/8/8/8 w - - 0 1",
+			fenErrorWant{Field: 1, Pos: 4, Char: '4', Reason: "горизонталь 2 длиннее первой (8 клеток)"}},
+		{"пустая горизонталь", "8//8/8/8/8/8/8 w - - 0 1",
+			fenErrorWant{Field: 1, Pos: 3, Char: '/', Reason: "пустая горизонталь"}},
+		{"косая черта в конце", "8/8/8/8/8/8/8/8/ w - - 0 1",
+			fenErrorWant{Field: 1, Pos: 16, Char: '/', Reason: "пустая горизонталь"}},
+		{"ведущий ноль", "8/8/08/8/8/8/8/8 w - - 0 1",
+			fenErrorWant{Field: 1, Pos: 5, Char: '0', Reason: "число пустых клеток не может начинаться с нуля"}},
+		{"пешка на первой горизонтали", "4k3/8/8/8/8/8/8/3PK3 w - - 0 1",
+			fenErrorWant{Field: 1, Pos: 18, Char: 'P', Reason: "пешка не может стоять на крайней горизонтали"}},
+		{"неверная очередь хода", "8/8/8/8/8/8/8/8 x - - 0 1",
+			fenErrorWant{Field: 2, Pos: 17, Char: 'x', Reason: "ожидалось 'w' или 'b'"}},
+		{"длинная очередь хода", "8/8/8/8/8/8/8/8 white - - 0 1",
+			fenErrorWant{Field: 2, Pos: 18, Char: 'h', Reason: "ожидалось 'w' или 'b'"}},
+		{"неизвестная рокировка", "r3k2r/8/8/8/8/8/8/R3K2R w KX - 0 1",
+			fenErrorWant{Field: 3, Pos: 28, Char: 'X', Reason: "неизвестное право рокировки"}},
+		{"повтор рокировки", "r3k2r/8/8/8/8/8/8/R3K2R w KQK - 0 1",
+			fenErrorWant{Field: 3, Pos: 29, Char: 'K', Reason: "право рокировки указано дважды"}},
+		{"рокировка без ладьи", "r3k3/8/8/8/8/8/8/R3K2R w KQkq - 0 1",
+			fenErrorWant{Field: 3, Pos: 28, Char: 'k', Reason: "нет короля и ладьи на исходных клетках"}},
+		{"неверная клетка взятия", "8/8/8/8/8/8/8/8 w - e9x 0 1",
+			fenErrorWant{Field: 4, Pos: 21, Reason: "неверное обозначение клетки 'e9x'"}},
+		{"клетка взятия вне доски", "8/8/8/8/8/8/8/8 w - i6 0 1",
+			fenErrorWant{Field: 4, Pos: 21, Reason: "клетка i6 вне доски 8x8"}},
+		{"взятие не на той горизонтали", "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e4 0 1",
+			fenErrorWant{Field: 4, Pos: 54, Reason: "взятие на проходе возможно только на горизонтали 3"}},
+		{"взятие без пешки", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR b KQkq e3 0 1",
+			fenErrorWant{Field: 4, Pos: 52, Reason: "на e4 нет пешки, сделавшей ход через e3"}},
+		{"отрицательные полуходы", "8/8/8/8/8/8/8/8 w - - -1 1",
+			fenErrorWant{Field: 5, Pos: 23, Char: '-', Reason: "ожидалось неотрицательное целое число"}},
+		{"дробный номер хода", "8/8/8/8/8/8/8/8 w - - 0 1.5",
+			fenErrorWant{Field: 6, Pos: 26, Char: '.', Reason: "ожидалось неотрицательное целое число"}},
+		{"нулевой номер хода", "8/8/8/8/8/8/8/8 w - - 0 0",
+			fenErrorWant{Field: 6, Pos: 25, Reason: "значение должно быть не меньше 1"}},
+		{"ведущий ноль в счетчике", "8/8/8/8/8/8/8/8 w - - 07 1",
+			fenErrorWant{Field: 5, Pos: 23, Char: '0', Reason: "число не может начинаться с нуля"}},
+		{"слишком большое число", "8/8/8/8/8/8/8/8 w - - 99999999999999999999 1",
+			fenErrorWant{Field: 5, Pos: 23, Reason: "слишком большое число"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			board, err := ParseFEN(tc.fen)
+			if err == nil {
+				t.Fatalf("ожидалась ошибка, получена позиция %s", board.FEN())
+			}
+
+			var fenErr *FENError
+			if !errors.As(err, &fenErr) {
+				t.Fatalf("ожидалась ошибка типа *FENError, получено %T: %v", err, err)
+			}
+			got := fenErrorWant{Field: fenErr.Field, Pos: fenErr.Pos, Char: fenErr.Char, Reason: fenErr.Reason.Error()}
+			if got != tc.expected {
+				t.Errorf("ожидалось %+v, получено %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestFENError_Message(t *testing.T) {
+	_, err := ParseFEN("rnbqkbnr/pppppppp/8/8/4X3/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+	if err == nil {
+		t.Fatal("ожидалась ошибка")
+	}
+
+	for _, part := range []string{"поле 1 (расстановка)", "позиция 24", "символ 'X'", "неизвестная фигура"} {
+		if !strings.Contains(err.Error(), part) {
+			t.Errorf("сообщение %q должно содержать %q", err.Error(), part)
+		}
+	}
+}
+
+func TestParseFEN_TooLarge(t *testing.T) {
+	rank := "1000"
+	fen := strings.Repeat(rank+"/", 2000) + rank + " w - - 0 1"
+
+	if _, err := ParseFEN(fen); err == nil || !strings.Contains(err.Error(), "слишком большая доска") {
+		t.Errorf("ожидалась ошибка о слишком большой доске, получено %v", err)
+	}
+}
+
+func TestCastlingRights_String(t *testing.T) {
+	testCases := []struct {
+		rights   CastlingRights
+		expected string
+	}{
+		{AllCastling, "KQkq"},
+		{NoCastling, "-"},
+		{WhiteQueenside | BlackKingside, "Qk"},
+	}
+
+	for _, tc := range testCases {
+		if actual := tc.rights.String(); actual != tc.expected {
+			t.Errorf("ожидалось '%s', получено '%s'", tc.expected, actual)
+		}
+	}
+}
+
+func TestBoard_FEN_WithoutState(t *testing.T) {
+	board := &Board{Width: 4, Height: 4}
+	board.SetPiece(0, 0, Piece{Color: White, Kind: King})
+
+	if fen := board.FEN(); fen != "4/4/4/K3 w - - 0 1" {
+		t.Errorf("неверный FEN доски без состояния партии: %q", fen)
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "strings"
+
+// Format - формат вывода доски
+type Format string
+
+const (
+	// FormatText - текстовая доска для терминала
+	FormatText Format = "text"
+	// FormatSVG - векторное изображение SVG
+	FormatSVG Format = "svg"
+	// FormatPNG - растровое изображение PNG
+	FormatPNG Format = "png"
+	// FormatJSON - структурное описание доски в JSON
+	FormatJSON Format = "json"
+)
+
+// Formats возвращает список поддерживаемых форматов вывода
+func Formats() []Format {
+	return []Format{FormatText, FormatSVG, FormatPNG, FormatJSON}
+}
+
+// ParseFormat разбирает название формата вывода
+func ParseFormat(input string) (Format, error) {
+	name := Format(strings.ToLower(strings.TrimSpace(input)))
+	for _, format := range Formats() {
+		if name == format {
+			return format, nil
+		}
+	}
+	allowed := make([]string, 0, len(Formats()))
+	for _, format := range Formats() {
+		allowed = append(allowed, string(format))
+	}
+	return "", &UnknownValueError{Label: "unknown.format", Value: input, Allowed: allowed}
+}
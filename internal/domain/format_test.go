@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expected    Format
+		expectError bool
+	}{
+		{"text", FormatText, false},
+		{"SVG", FormatSVG, false},
+		{" svg ", FormatSVG, false},
+		{"JSON", FormatJSON, false},
+		{"pdf", "", true},
+		{"", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			format, err := ParseFormat(tc.input)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("ожидалась ошибка для формата '%s', но ошибки нет", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("неожиданная ошибка для формата '%s': %v", tc.input, err)
+			}
+			if format != tc.expected {
+				t.Errorf("для '%s' ожидался формат '%s', получен '%s'", tc.input, tc.expected, format)
+			}
+		})
+	}
+}
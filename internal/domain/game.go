@@ -0,0 +1,21 @@
+package domain
+
+// Game - партия: позиция вместе с историей сыгранных ходов. История нужна
+// для отмены ходов и для правил ничьей, которые нельзя определить по одной
+// позиции, например для троекратного повторения.
+type Game interface {
+	// Board возвращает копию текущей позиции
+	Board() *Board
+	// Moves возвращает сыгранные ходы от начальной позиции
+	Moves() []Move
+	// LegalMoves возвращает легальные ходы в текущей позиции
+	LegalMoves() []Move
+	// Play выполняет ход; нелегальный ход возвращает ошибку и не меняет позицию
+	Play(move Move) error
+	// Undo отменяет последний ход; false, если ходов не было
+	Undo() (Move, bool)
+	// Repetitions возвращает, сколько раз текущая позиция встречалась в партии
+	Repetitions() int
+	// Status возвращает состояние партии с учетом повторений позиции
+	Status() GameStatus
+}
@@ -0,0 +1,33 @@
+package domain
+
+// Move - ход фигуры с клетки From на клетку To. Рокировка записывается ходом
+// короля на две клетки, взятие на проходе - ходом пешки на клетку взятия.
+type Move struct {
+	From Square
+	To   Square
+	// Promotion - фигура, в которую превращается пешка; NoPiece, если превращения нет
+	Promotion PieceKind
+}
+
+// String возвращает ход в записи "e2e4" или "e7e8q"
+func (m Move) String() string {
+	result := m.From.String() + m.To.String()
+	if m.Promotion != NoPiece {
+		result += string(Piece{Color: Black, Kind: m.Promotion}.Letter())
+	}
+	return result
+}
+
+// MoveCount - число листьев дерева ходов после хода первого уровня
+type MoveCount struct {
+	Move  Move
+	Nodes int64
+}
+
+// PerftResult - результат подсчета листьев дерева легальных ходов
+type PerftResult struct {
+	Depth int
+	Nodes int64
+	// Divide - разбивка числа листьев по ходам первого уровня в порядке записи ходов
+	Divide []MoveCount
+}
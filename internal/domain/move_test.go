@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestMove_String(t *testing.T) {
+	testCases := []struct {
+		name     string
+		move     Move
+		expected string
+	}{
+		{"обычный ход", Move{From: Square{4, 1}, To: Square{4, 3}}, "e2e4"},
+		{"превращение", Move{From: Square{0, 6}, To: Square{0, 7}, Promotion: Queen}, "a7a8q"},
+		{"превращение в коня", Move{From: Square{7, 1}, To: Square{6, 0}, Promotion: Knight}, "h2g1n"},
+		{"большая доска", Move{From: Square{26, 9}, To: Square{25, 10}}, "aa10z11"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.move.String(); actual != tc.expected {
+				t.Errorf("ожидалось '%s', получено '%s'", tc.expected, actual)
+			}
+		})
+	}
+}
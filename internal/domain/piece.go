@@ -0,0 +1,174 @@
+package domain
+
+import "strings"
+
+// StandardBoardSize - размер доски классических шахмат
+const StandardBoardSize = 8
+
+// Color - цвет фигур (сторона)
+type Color int8
+
+const (
+	White Color = iota
+	Black
+)
+
+// Opposite возвращает цвет противника
+func (c Color) Opposite() Color {
+	return c ^ 1
+}
+
+func (c Color) String() string {
+	if c == Black {
+		return "black"
+	}
+	return "white"
+}
+
+// PieceKind - тип фигуры
+type PieceKind int8
+
+const (
+	NoPiece PieceKind = iota
+	Pawn
+	Knight
+	Bishop
+	Rook
+	Queen
+	King
+)
+
+// pieceLetters - обозначения фигур белых в порядке PieceKind
+const pieceLetters = " PNBRQK"
+
+// pieceGlyphs - Unicode-символы фигур белых и черных в порядке PieceKind
+var pieceGlyphs = [2][7]rune{
+	{' ', '♙', '♘', '♗', '♖', '♕', '♔'},
+	{' ', '♟', '♞', '♝', '♜', '♛', '♚'},
+}
+
+// Piece - фигура на клетке. Нулевое значение означает пустую клетку.
+type Piece struct {
+	Color Color
+	Kind  PieceKind
+}
+
+// IsEmpty сообщает, что на клетке нет фигуры
+func (p Piece) IsEmpty() bool {
+	return p.Kind == NoPiece
+}
+
+// Letter возвращает букву фигуры: заглавную для белых (KQRBNP), строчную для черных
+func (p Piece) Letter() rune {
+	if p.Kind <= NoPiece || p.Kind > King {
+		return ' '
+	}
+	letter := rune(pieceLetters[p.Kind])
+	if p.Color == Black {
+		letter += 'a' - 'A'
+	}
+	return letter
+}
+
+// Glyph возвращает Unicode-символ фигуры (♔♕♖♗♘♙ / ♚♛♜♝♞♟)
+func (p Piece) Glyph() rune {
+	if p.Kind <= NoPiece || p.Kind > King {
+		return ' '
+	}
+	return pieceGlyphs[p.Color&1][p.Kind]
+}
+
+func (p Piece) String() string {
+	return string(p.Letter())
+}
+
+// PieceFromLetter возвращает фигуру по букве (KQRBNP для белых, kqrbnp для черных)
+func PieceFromLetter(letter rune) (Piece, bool) {
+	color := White
+	if letter >= 'a' && letter <= 'z' {
+		color = Black
+		letter -= 'a' - 'A'
+	}
+	kind := strings.IndexRune(pieceLetters, letter)
+	if kind <= 0 {
+		return Piece{}, false
+	}
+	return Piece{Color: color, Kind: PieceKind(kind)}, true
+}
+
+// PieceStyle - способ изображения фигур
+type PieceStyle string
+
+const (
+	// PieceStyleLetters - буквы KQRBNP / kqrbnp
+	PieceStyleLetters PieceStyle = "letters"
+	// PieceStyleUnicode - шахматные символы Unicode
+	PieceStyleUnicode PieceStyle = "unicode"
+)
+
+// ParsePieceStyle разбирает название способа изображения фигур
+func ParsePieceStyle(input string) (PieceStyle, error) {
+	switch style := PieceStyle(strings.ToLower(strings.TrimSpace(input))); style {
+	case PieceStyleLetters, PieceStyleUnicode:
+		return style, nil
+	}
+	return "", &UnknownValueError{Label: "unknown.piece_style", Value: input,
+		Allowed: []string{string(PieceStyleLetters), string(PieceStyleUnicode)}}
+}
+
+// Symbol возвращает изображение фигуры в заданном стиле
+func (p Piece) Symbol(style PieceStyle) rune {
+	if style == PieceStyleUnicode {
+		return p.Glyph()
+	}
+	return p.Letter()
+}
+
+// Setup - начальная расстановка фигур
+type Setup string
+
+const (
+	// SetupEmpty - доска без фигур
+	SetupEmpty Setup = "empty"
+	// SetupStandard - начальная позиция классических шахмат
+	SetupStandard Setup = "standard"
+)
+
+// ParseSetup разбирает название расстановки
+func ParseSetup(input string) (Setup, error) {
+	switch setup := Setup(strings.ToLower(strings.TrimSpace(input))); setup {
+	case SetupEmpty, SetupStandard:
+		return setup, nil
+	}
+	return "", &UnknownValueError{Label: "unknown.setup", Value: input,
+		Allowed: []string{string(SetupEmpty), string(SetupStandard)}}
+}
+
+// Validate проверяет, что расстановка возможна на доске заданного размера
+func (s Setup) Validate(width, height int) error {
+	if s == SetupStandard && (width != StandardBoardSize || height != StandardBoardSize) {
+		return &SetupError{Setup: s, Width: width, Height: height,
+			RequiredWidth: StandardBoardSize, RequiredHeight: StandardBoardSize}
+	}
+	return nil
+}
+
+// standardBackRank - фигуры первой горизонтали в начальной позиции
+var standardBackRank = [StandardBoardSize]PieceKind{Rook, Knight, Bishop, Queen, King, Bishop, Knight, Rook}
+
+// PlaceStandardPieces расставляет фигуры в начальную позицию классических шахмат
+// и сбрасывает состояние партии. Доска должна быть размером 8x8.
+func (b *Board) PlaceStandardPieces() {
+	b.Squares = make([]Piece, b.Width*b.Height)
+	b.SideToMove = White
+	b.Castling = AllCastling
+	b.EnPassant = nil
+	b.HalfmoveClock = 0
+	b.FullmoveNumber = 1
+	for file, kind := range standardBackRank {
+		b.SetPiece(file, 0, Piece{Color: White, Kind: kind})
+		b.SetPiece(file, 1, Piece{Color: White, Kind: Pawn})
+		b.SetPiece(file, b.Height-2, Piece{Color: Black, Kind: Pawn})
+		b.SetPiece(file, b.Height-1, Piece{Color: Black, Kind: kind})
+	}
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestColor_Opposite(t *testing.T) {
+	if White.Opposite() != Black || Black.Opposite() != White {
+		t.Error("Opposite должен менять белых на черных и наоборот")
+	}
+}
+
+func TestPiece_LetterAndGlyph(t *testing.T) {
+	testCases := []struct {
+		piece  Piece
+		letter rune
+		glyph  rune
+	}{
+		{Piece{White, King}, 'K', '♔'},
+		{Piece{White, Queen}, 'Q', '♕'},
+		{Piece{White, Rook}, 'R', '♖'},
+		{Piece{White, Bishop}, 'B', '♗'},
+		{Piece{White, Knight}, 'N', '♘'},
+		{Piece{White, Pawn}, 'P', '♙'},
+		{Piece{Black, King}, 'k', '♚'},
+		{Piece{Black, Queen}, 'q', '♛'},
+		{Piece{Black, Rook}, 'r', '♜'},
+		{Piece{Black, Bishop}, 'b', '♝'},
+		{Piece{Black, Knight}, 'n', '♞'},
+		{Piece{Black, Pawn}, 'p', '♟'},
+		{Piece{}, ' ', ' '},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.letter), func(t *testing.T) {
+			if letter := tc.piece.Letter(); letter != tc.letter {
+				t.Errorf("ожидалась буква '%c', получено '%c'", tc.letter, letter)
+			}
+			if glyph := tc.piece.Glyph(); glyph != tc.glyph {
+				t.Errorf("ожидался символ '%c', получено '%c'", tc.glyph, glyph)
+			}
+			if symbol := tc.piece.Symbol(PieceStyleUnicode); symbol != tc.glyph {
+				t.Errorf("стиль unicode: ожидался символ '%c', получено '%c'", tc.glyph, symbol)
+			}
+			if symbol := tc.piece.Symbol(PieceStyleLetters); symbol != tc.letter {
+				t.Errorf("стиль letters: ожидалась буква '%c', получено '%c'", tc.letter, symbol)
+			}
+		})
+	}
+}
+
+func TestPieceFromLetter(t *testing.T) {
+	for _, letter := range "KQRBNPkqrbnp" {
+		piece, ok := PieceFromLetter(letter)
+		if !ok {
+			t.Errorf("буква '%c' должна распознаваться как фигура", letter)
+			continue
+		}
+		if piece.Letter() != letter {
+			t.Errorf("буква '%c' распознана как '%c'", letter, piece.Letter())
+		}
+	}
+
+	for _, letter := range "xX1 -" {
+		if _, ok := PieceFromLetter(letter); ok {
+			t.Errorf("символ '%c' не должен распознаваться как фигура", letter)
+		}
+	}
+}
+
+func TestParsePieceStyle(t *testing.T) {
+	if style, err := ParsePieceStyle("Unicode"); err != nil || style != PieceStyleUnicode {
+		t.Errorf("ожидался стиль unicode, получено %q, %v", style, err)
+	}
+	if style, err := ParsePieceStyle("letters"); err != nil || style != PieceStyleLetters {
+		t.Errorf("ожидался стиль letters, получено %q, %v", style, err)
+	}
+	if _, err := ParsePieceStyle("emoji"); err == nil {
+		t.Error("ожидалась ошибка для неизвестного стиля")
+	}
+}
+
+func TestSetup(t *testing.T) {
+	t.Run("разбор названий", func(t *testing.T) {
+		if setup, err := ParseSetup(" Standard "); err != nil || setup != SetupStandard {
+			t.Errorf("ожидалась стандартная расстановка, получено %q, %v", setup, err)
+		}
+		if _, err := ParseSetup("fischer"); err == nil {
+			t.Error("ожидалась ошибка для неизвестной расстановки")
+		}
+	})
+
+	testCases := []struct {
+		name          string
+		setup         Setup
+		width, height int
+		expectError   bool
+	}{
+		{"пустая на любой доске", SetupEmpty, 10, 8, false},
+		{"стандартная на 8x8", SetupStandard, 8, 8, false},
+		{"стандартная на 10x8", SetupStandard, 10, 8, true},
+		{"стандартная на 8x10", SetupStandard, 8, 10, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.setup.Validate(tc.width, tc.height)
+			if tc.expectError && err == nil {
+				t.Error("ожидалась ошибка, но ошибки нет")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("неожиданная ошибка: %v", err)
+			}
+		})
+	}
+}
+
+func TestBoard_PlaceStandardPieces(t *testing.T) {
+	board := &Board{Width: 8, Height: 8}
+	board.PlaceStandardPieces()
+
+	expected := []string{
+		"rnbqkbnr",
+		"pppppppp",
+		"        ",
+		"        ",
+		"        ",
+		"        ",
+		"PPPPPPPP",
+		"RNBQKBNR",
+	}
+	for i, line := range expected {
+		rank := board.Height - 1 - i
+		for file, letter := range line {
+			if actual := board.PieceAt(file, rank).Letter(); actual != letter {
+				t.Errorf("клетка %s%s: ожидалось '%c', получено '%c'",
+					FileName(file), RankName(rank), letter, actual)
+			}
+		}
+	}
+}
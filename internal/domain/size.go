@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+
+	"chessboard/internal/i18n"
+)
+
+// ValidateSize проверяет, что ширина и высота доски в пределах
+// [MinBoardSize, MaxBoardSize]. Возвращает *SizeError для первого неверного измерения.
+func ValidateSize(width, height int) error {
+	if err := validateDimension(DimensionWidth, width); err != nil {
+		return err
+	}
+	return validateDimension(DimensionHeight, height)
+}
+
+func validateDimension(name i18n.Key, value int) error {
+	if value < MinBoardSize || value > MaxBoardSize {
+		return &SizeError{Dimension: name, Value: value, Min: MinBoardSize, Max: MaxBoardSize}
+	}
+	return nil
+}
+
+// ParseSize разбирает размер доски: квадратный "N" или прямоугольный "WxH"
+// (разделитель x, X или ×). Пределы размера не проверяются - для этого есть
+// ValidateSize. Возвращает *NumberError для измерения, которое не является
+// неотрицательным целым числом.
+func ParseSize(input string) (int, int, error) {
+	input = strings.TrimSpace(input)
+
+	width, height := input, input
+	if w, h, found := cutDimensions(input); found {
+		width, height = w, h
+	}
+
+	w, err := ParseNumber(width)
+	if err != nil {
+		return 0, 0, err
+	}
+	h, err := ParseNumber(height)
+	if err != nil {
+		return 0, 0, err
+	}
+	return w, h, nil
+}
+
+// cutDimensions разделяет строку вида "WxH" на ширину и высоту
+func cutDimensions(input string) (string, string, bool) {
+	for _, sep := range []string{"x", "X", "×"} {
+		if width, height, found := strings.Cut(input, sep); found {
+			return strings.TrimSpace(width), strings.TrimSpace(height), true
+		}
+	}
+	return "", "", false
+}
+
+// ParseNumber разбирает неотрицательное целое число. Отрицательные и дробные
+// числа отклоняются с отдельной причиной, чтобы сообщение об ошибке было точным.
+func ParseNumber(input string) (int, error) {
+	input = strings.TrimSpace(input)
+	if strings.HasPrefix(input, "-") {
+		return 0, &NumberError{Input: input, Kind: ErrNegativeNumber}
+	}
+	if strings.Contains(input, ".") || strings.Contains(input, ",") {
+		return 0, &NumberError{Input: input, Kind: ErrFractionalNumber}
+	}
+
+	number, err := strconv.Atoi(input)
+	if err != nil {
+		return 0, &NumberError{Input: input, Kind: ErrNotANumber}
+	}
+	return number, nil
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"chessboard/internal/i18n"
+)
+
+func TestValidateSize(t *testing.T) {
+	testCases := []struct {
+		name      string
+		width     int
+		height    int
+		dimension i18n.Key
+		class     error
+	}{
+		{"минимальный размер", MinBoardSize, MinBoardSize, "", nil},
+		{"максимальный размер", MaxBoardSize, MaxBoardSize, "", nil},
+		{"узкая доска", MinBoardSize - 1, 8, DimensionWidth, ErrSizeTooSmall},
+		{"низкая доска", 8, MinBoardSize - 1, DimensionHeight, ErrSizeTooSmall},
+		{"слишком широкая доска", MaxBoardSize + 1, 8, DimensionWidth, ErrSizeTooLarge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateSize(tc.width, tc.height)
+			if tc.class == nil {
+				if err != nil {
+					t.Errorf("неожиданная ошибка: %v", err)
+				}
+				return
+			}
+
+			var sizeErr *SizeError
+			if !errors.As(err, &sizeErr) {
+				t.Fatalf("ожидалась *SizeError, получено %v", err)
+			}
+			if sizeErr.Dimension != tc.dimension || sizeErr.Min != MinBoardSize || sizeErr.Max != MaxBoardSize {
+				t.Errorf("неожиданные поля ошибки: %+v", sizeErr)
+			}
+			if !errors.Is(err, tc.class) {
+				t.Errorf("ошибка должна соответствовать %v", tc.class)
+			}
+		})
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	testCases := []struct {
+		input  string
+		width  int
+		height int
+		kind   error
+	}{
+		{"8", 8, 8, nil},
+		{" 12 ", 12, 12, nil},
+		{"10x8", 10, 8, nil},
+		{"9X10", 9, 10, nil},
+		{"6×4", 6, 4, nil},
+		{"10 x 8", 10, 8, nil},
+		{"3", 3, 3, nil}, // пределы проверяет ValidateSize
+		{"-8", 0, 0, ErrNegativeNumber},
+		{"8.5", 0, 0, ErrFractionalNumber},
+		{"10x8,5", 0, 0, ErrFractionalNumber},
+		{"8a", 0, 0, ErrNotANumber},
+		{"x8", 0, 0, ErrNotANumber},
+		{"", 0, 0, ErrNotANumber},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			width, height, err := ParseSize(tc.input)
+			if tc.kind == nil {
+				if err != nil || width != tc.width || height != tc.height {
+					t.Errorf("ожидалось %dx%d, получено %dx%d (ошибка %v)", tc.width, tc.height, width, height, err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidNumber) || !errors.Is(err, tc.kind) {
+				t.Errorf("ожидалась ошибка %v, получено %v", tc.kind, err)
+			}
+		})
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected int
+		message  string
+	}{
+		{"0", 0, ""},
+		{" 42", 42, ""},
+		{"-1", 0, "отрицательные числа не поддерживаются: '-1'"},
+		{"1,5", 0, "дробные числа не поддерживаются: '1,5'"},
+		{"пять", 0, "неверный формат числа: 'пять'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			number, err := ParseNumber(tc.input)
+			if tc.message == "" {
+				if err != nil || number != tc.expected {
+					t.Errorf("ожидалось %d, получено %d (ошибка %v)", tc.expected, number, err)
+				}
+				return
+			}
+
+			var numberErr *NumberError
+			if !errors.As(err, &numberErr) {
+				t.Fatalf("ожидалась *NumberError, получено %v", err)
+			}
+			if err.Error() != tc.message {
+				t.Errorf("ожидалось сообщение %q, получено %q", tc.message, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package domain
+
+// Пороги правил ничьей. Правило 50 ходов и троекратное повторение позволяют
+// потребовать ничью, а правило 75 ходов и пятикратное повторение завершают
+// партию вничью без требования.
+const (
+	FiftyMovePlies       = 100
+	SeventyFiveMovePlies = 150
+	RepetitionClaimCount = 3
+	RepetitionDrawCount  = 5
+)
+
+// GameResult - исход партии
+type GameResult int8
+
+const (
+	// ResultOngoing означает, что партия продолжается
+	ResultOngoing GameResult = iota
+	ResultWhiteWins
+	ResultBlackWins
+	ResultDraw
+)
+
+// String возвращает исход в записи PGN: "1-0", "0-1", "1/2-1/2" или "*"
+func (r GameResult) String() string {
+	switch r {
+	case ResultWhiteWins:
+		return "1-0"
+	case ResultBlackWins:
+		return "0-1"
+	case ResultDraw:
+		return "1/2-1/2"
+	default:
+		return "*"
+	}
+}
+
+// GameStatus - состояние партии в позиции
+type GameStatus struct {
+	// SideToMove - сторона, имеющая очередь хода
+	SideToMove Color
+	// InCheck сообщает, что король стороны, имеющей очередь хода, под шахом
+	InCheck bool
+	// Checkmate - шах, от которого нет защиты
+	Checkmate bool
+	// Stalemate - легальных ходов нет, но шаха тоже нет
+	Stalemate bool
+	// InsufficientMaterial - ни одна из сторон не может поставить мат
+	InsufficientMaterial bool
+	// FiftyMoves - 50 ходов без взятий и ходов пешками; SeventyFiveMoves - 75 ходов
+	FiftyMoves       bool
+	SeventyFiveMoves bool
+	// ThreefoldRepetition и FivefoldRepetition - позиция повторилась 3 и 5 раз.
+	// Повторения определяются только по истории партии (Game).
+	ThreefoldRepetition bool
+	FivefoldRepetition  bool
+	Result              GameResult
+}
+
+// IsOver сообщает, что партия закончена
+func (s GameStatus) IsOver() bool {
+	return s.Result != ResultOngoing
+}
+
+// CanClaimDraw сообщает, что партия продолжается, но любая сторона может
+// потребовать ничью по правилу 50 ходов или из-за троекратного повторения
+func (s GameStatus) CanClaimDraw() bool {
+	return !s.IsOver() && (s.FiftyMoves || s.ThreefoldRepetition)
+}
+
+// Winner возвращает победившую сторону; false, если победителя нет
+func (s GameStatus) Winner() (Color, bool) {
+	switch s.Result {
+	case ResultWhiteWins:
+		return White, true
+	case ResultBlackWins:
+		return Black, true
+	default:
+		return White, false
+	}
+}
+
+// WinResult возвращает исход партии, в которой победила сторона color
+func WinResult(color Color) GameResult {
+	if color == Black {
+		return ResultBlackWins
+	}
+	return ResultWhiteWins
+}
+
+// InsufficientMaterial сообщает, что мат невозможен при любой игре: кроме королей
+// на доске осталась не более чем одна легкая фигура или только слоны на полях
+// одного цвета
+func (b *Board) InsufficientMaterial() bool {
+	minors := 0
+	bishopSquares := [2]int{}
+	for i, piece := range b.Squares {
+		switch piece.Kind {
+		case NoPiece, King:
+		case Knight:
+			minors++
+		case Bishop:
+			minors++
+			file, rank := i%b.Width, i/b.Width
+			bishopSquares[(file+rank)%2]++
+		default:
+			// Пешка, ладья или ферзь всегда оставляют возможность мата
+			return false
+		}
+	}
+
+	if minors <= 1 {
+		return true
+	}
+	// Слоны на полях одного цвета не могут атаковать клетки другого цвета
+	return bishopSquares[0] == minors || bishopSquares[1] == minors
+}
@@ -0,0 +1,116 @@
+package domain
+
+import "testing"
+
+func TestGameResult_String(t *testing.T) {
+	testCases := []struct {
+		result   GameResult
+		expected string
+	}{
+		{ResultOngoing, "*"},
+		{ResultWhiteWins, "1-0"},
+		{ResultBlackWins, "0-1"},
+		{ResultDraw, "1/2-1/2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if actual := tc.result.String(); actual != tc.expected {
+				t.Errorf("ожидалось %q, получено %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGameStatus_Winner(t *testing.T) {
+	testCases := []struct {
+		name      string
+		result    GameResult
+		winner    Color
+		hasWinner bool
+		over      bool
+	}{
+		{"партия продолжается", ResultOngoing, White, false, false},
+		{"победа белых", ResultWhiteWins, White, true, true},
+		{"победа черных", ResultBlackWins, Black, true, true},
+		{"ничья", ResultDraw, White, false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := GameStatus{Result: tc.result}
+			winner, ok := status.Winner()
+			if ok != tc.hasWinner || (ok && winner != tc.winner) {
+				t.Errorf("ожидалось (%v, %v), получено (%v, %v)", tc.winner, tc.hasWinner, winner, ok)
+			}
+			if status.IsOver() != tc.over {
+				t.Errorf("IsOver: ожидалось %v, получено %v", tc.over, status.IsOver())
+			}
+		})
+	}
+
+	for _, color := range []Color{White, Black} {
+		if winner, _ := (GameStatus{Result: WinResult(color)}).Winner(); winner != color {
+			t.Errorf("WinResult(%s): ожидалась победа %s, получено %s", color, color, winner)
+		}
+	}
+}
+
+func TestGameStatus_CanClaimDraw(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   GameStatus
+		expected bool
+	}{
+		{"партия продолжается", GameStatus{}, false},
+		{"правило 50 ходов", GameStatus{FiftyMoves: true}, true},
+		{"троекратное повторение", GameStatus{ThreefoldRepetition: true}, true},
+		{"партия уже закончена", GameStatus{FiftyMoves: true, SeventyFiveMoves: true, Result: ResultDraw}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.status.CanClaimDraw(); actual != tc.expected {
+				t.Errorf("ожидалось %v, получено %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBoard_InsufficientMaterial(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fen      string
+		expected bool
+	}{
+		{"только короли", "4k3/8/8/8/8/8/8/4K3 w - - 0 1", true},
+		{"король и конь", "4k3/8/8/8/8/8/8/4KN2 w - - 0 1", true},
+		{"король и слон", "4k3/8/8/8/8/8/8/4KB2 b - - 0 1", true},
+		{"слоны на полях одного цвета", "4kb2/8/8/8/8/8/8/2B1K3 w - - 0 1", true},
+		{"слоны на полях разного цвета", "4k1b1/8/8/8/8/8/8/2B1K3 w - - 0 1", false},
+		{"два коня", "4k3/8/8/8/8/8/8/3NKN2 w - - 0 1", false},
+		{"конь против слона", "4kb2/8/8/8/8/8/8/4KN2 w - - 0 1", false},
+		{"пешка", "4k3/8/8/8/8/8/4P3/4K3 w - - 0 1", false},
+		{"ладья", "4k3/8/8/8/8/8/8/R3K3 w - - 0 1", false},
+		{"начальная позиция", StartFEN, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			board, err := ParseFEN(tc.fen)
+			if err != nil {
+				t.Fatalf("неверный FEN: %v", err)
+			}
+			if actual := board.InsufficientMaterial(); actual != tc.expected {
+				t.Errorf("ожидалось %v, получено %v", tc.expected, actual)
+			}
+		})
+	}
+
+	t.Run("доска без фигур", func(t *testing.T) {
+		board := &Board{Width: 8, Height: 8}
+		if !board.InsufficientMaterial() {
+			t.Error("на пустой доске мат невозможен")
+		}
+	})
+}
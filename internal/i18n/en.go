@@ -0,0 +1,315 @@
+package i18n
+
+// englishMessages - сообщения на английском языке
+var englishMessages = map[Key]string{
+	// Битовые доски
+	"bitboard.size": "bitboards only support boards of size %dx%d, got %dx%d",
+
+	// Вывод доски
+	"board.header":       "Chessboard %dx%d:\n",
+	"board.render_error": "rendering failed: %s",
+	"board.status":       "Status: %s\n",
+	"board.status_error": "failed to determine the status: %s",
+
+	// Разбор командной строки
+	"cli.allowed_values":      ". Allowed values: %s",
+	"cli.error":               "Error: %s.\n",
+	"cli.extra_argument":      "unexpected argument: '%s'",
+	"cli.flag_value_required": "flag '%s' requires a value",
+	"cli.help_hint":           "Help: %s help %s\n",
+	"cli.invalid_flag_value":  "invalid value for flag '%s': '%s'",
+	"cli.unknown_command":     "unknown command: '%s'. Commands: %s help",
+	"cli.unknown_flag":        "unknown flag: '%s'",
+
+	// Цвета
+	"color.invalid": "invalid color format: '%s'",
+
+	// Команды
+	"command.fen.args":           "<FEN>",
+	"command.fen.description":    "Shows the position and the game status. The board size is defined by the piece placement.",
+	"command.fen.summary":        "show a position given in FEN",
+	"command.help.args":          "[command]",
+	"command.help.description":   "Without arguments, lists the commands; with a command name, shows help for it.",
+	"command.help.summary":       "show help",
+	"command.perft.args":         "<depth>",
+	"command.perft.description":  "Counts the leaves of the legal move tree of the given depth and prints the breakdown\nby first move, the elapsed time and the speed.",
+	"command.perft.summary":      "count the positions in the move tree",
+	"command.pgn.args":           "show <file>",
+	"command.pgn.description":    "Shows the board after ply M of game N in a PGN file.\nBy default, the final position of the first game.",
+	"command.pgn.summary":        "show a position from a PGN game",
+	"command.play.description":   "Reads moves (e4, Nf3, e2e4) and the commands undo, flip, fen, new, help, quit\none per line and redraws the board after each. The input can be redirected from a file.",
+	"command.play.summary":       "play in the console",
+	"command.render.args":        "[size]",
+	"command.render.description": "Shows an N or WxH board (8x8 by default) in a text or image format.\nThe command name can be omitted: \"chessboard 10\" is the same as \"chessboard render 10\".",
+	"command.render.summary":     "show a board of the given size (default command)",
+	"command.serve.description":  "Serves GET /board, POST /fen/validate and POST /moves.\nStops on Ctrl+C or SIGTERM after the requests in progress complete.",
+	"command.serve.summary":      "start the HTTP server",
+	"command.version.summary":    "show the program version",
+
+	// Классы ошибок предметной области
+	"error.fractional_number": "fractional numbers are not supported",
+	"error.illegal_move":      "illegal move",
+	"error.invalid_fen":       "invalid FEN",
+	"error.invalid_number":    "invalid number",
+	"error.invalid_pattern":   "invalid pattern",
+	"error.invalid_position":  "impossible position",
+	"error.invalid_size":      "invalid board size",
+	"error.negative_number":   "negative numbers are not supported",
+	"error.not_a_number":      "invalid number format",
+	"error.setup_unavailable": "setup is not possible",
+	"error.size_too_large":    "board size is too large",
+	"error.size_too_small":    "board size is too small",
+	"error.unknown_value":     "unknown value",
+	"error.with_value":        "%s: '%s'",
+
+	// Коды завершения
+	"exit.error": "internal error",
+	"exit.fen":   "invalid FEN position",
+	"exit.io":    "input/output error",
+	"exit.move":  "invalid move",
+	"exit.ok":    "command completed",
+	"exit.pgn":   "invalid PGN game",
+	"exit.size":  "invalid board size",
+	"exit.usage": "invalid arguments",
+
+	// Нотация FEN
+	"fen.board_too_large":         "board is too large",
+	"fen.castling_pieces_missing": "no king and rook on their initial squares",
+	"fen.duplicate_castling":      "castling right is given twice",
+	"fen.empty":                   "empty string",
+	"fen.empty_rank":              "empty rank",
+	"fen.en_passant_pawn":         "no pawn on %s that has just passed %s",
+	"fen.en_passant_rank":         "en passant is only possible on rank %s",
+	"fen.error":                   "invalid FEN: %s",
+	"fen.error_at":                "invalid FEN: field %d (%s), position %d: %s",
+	"fen.error_at_char":           "invalid FEN: field %d (%s), position %d, character '%c': %s",
+	"fen.extra_space":             "extra space",
+	"fen.field.castling":          "castling",
+	"fen.field.en_passant":        "en passant",
+	"fen.field.fullmove_number":   "fullmove number",
+	"fen.field.halfmove_clock":    "halfmove clock",
+	"fen.field.placement":         "piece placement",
+	"fen.field.side_to_move":      "side to move",
+	"fen.field_count":             "expected %d space-separated fields, got %d",
+	"fen.invalid_square":          "invalid square '%s'",
+	"fen.leading_zero":            "number cannot start with zero",
+	"fen.leading_zero_empty":      "number of empty squares cannot start with zero",
+	"fen.number_expected":         "expected a non-negative integer",
+	"fen.number_too_large":        "number is too large",
+	"fen.number_too_small":        "value must be at least %d",
+	"fen.pawn_on_edge":            "a pawn cannot stand on the first or last rank",
+	"fen.position_required":       "specify a position in FEN",
+	"fen.rank_too_long":           "rank %d is longer than the first one (%d squares)",
+	"fen.rank_width":              "rank %d has %d squares, expected %d",
+	"fen.side_expected":           "expected 'w' or 'b'",
+	"fen.square_occupied":         "square %s is occupied",
+	"fen.square_off_board":        "square %s is off the %dx%d board",
+	"fen.unknown_castling":        "unknown castling right",
+	"fen.unknown_piece":           "unknown piece",
+
+	// Флаги
+	"flag.addr":        "`address` and port to accept connections on (default localhost:8080)",
+	"flag.border":      "image border `width` in pixels",
+	"flag.coords":      "show square coordinates",
+	"flag.dark":        "dark square `color`, e.g. #769656",
+	"flag.format":      "output `format`: text, svg, png or json",
+	"flag.game":        "game `number` in the file, starting from 1",
+	"flag.light":       "light square `color`, e.g. #eeeed2",
+	"flag.max_size":    "largest board `size` in a request (default 64)",
+	"flag.orientation": "`side` at the bottom of the board: white or black",
+	"flag.palette":     "square color `palette`: brown, green, blue, gray",
+	"flag.pattern":     "square coloring `pattern`: checker, stripes, diagonal, rings or a mask:#./.#",
+	"flag.perft_fen":   "starting FEN `position` (the initial position by default)",
+	"flag.pieces":      "piece `style`: letters or unicode",
+	"flag.play_fen":    "FEN `position` the game starts from",
+	"flag.ply":         "`ply` number after which to show the position",
+	"flag.render_fen":  "FEN `position` to show instead of an empty board",
+	"flag.setup":       "piece `setup`: empty or standard (8x8 only)",
+	"flag.square_size": "image square `size` in pixels",
+	"flag.theme":       "text output `theme`: ascii, unicode, ansi or auto",
+	"flag.tui":         "full-screen mode with cursor control",
+
+	// Справка
+	"help.command_help":      "\nHelp on a command: %s help <command> or %s <command> --help\n",
+	"help.command_usage":     "Usage: %s\n\n%s\n",
+	"help.common_flags":      "\nCommon flags:\n  --strict     strict mode: an error aborts the command and is written to stderr as JSON\n               (on by default when input or output is redirected; --strict=false turns it off)\n  --lang LANG  message language: ru or en (by default from LC_ALL, LC_MESSAGES or LANG)\n",
+	"help.exit_codes":        "\nExit codes:\n",
+	"help.flags":             "\nFlags:\n",
+	"help.flags_placeholder": " [flags]",
+	"help.title":             "%s - chessboard generator\n\n",
+	"help.usage":             "Usage: %s <command> [arguments] [flags]\n\nCommands:\n",
+
+	// Сервер HTTP
+	"http.body_too_large": "request body exceeds %d bytes",
+	"http.fen_required":   "specify a position in the fen field",
+	"http.invalid_json":   "invalid JSON in request body: %s",
+	"http.invalid_param":  "invalid value for parameter '%s': '%s'",
+	"http.timeout":        "request processing timed out",
+
+	// Графические форматы
+	"image.border":      "border width must be from 0 to %d pixels, got %d",
+	"image.square_size": "square size must be from 1 to %d pixels, got %d",
+	"image.too_large":   "image %dx%d is too large: at most %d pixels",
+	"image.too_wide":    "image side exceeds %d pixels: %d squares of %d pixels and a %d border",
+
+	// Описание доски в JSON
+	"json.board_too_large":     "board %dx%d with pieces cannot have more than %d squares",
+	"json.duplicate_square":    "square '%s' is listed twice",
+	"json.error":               "%v: %v",
+	"json.fen_mismatch":        "the placement in the fen field does not match the pieces on the squares",
+	"json.invalid_board":       "invalid board JSON",
+	"json.square_outside":      "square '%s' is outside the %dx%d board",
+	"json.unknown_piece":       "unknown piece '%s %s' on square '%s'",
+	"json.unknown_schema":      "unknown schema '%s', expected '%s'",
+	"json.unsupported_version": "schema version %d is not supported, supported versions are 1 to %d",
+
+	// Запись ходов
+	"move.ambiguous":           "ambiguous move: '%s' (candidates: %s)",
+	"move.castling_impossible": "castling is not possible: '%s'",
+	"move.empty":               "empty move: '%s'",
+	"move.error_in":            "%s in move '%s'",
+	"move.invalid":             "invalid move notation: '%s'",
+	"move.invalid_promotion":   "invalid promotion piece: '%s' in move '%s'",
+	"move.invalid_rank":        "invalid rank: '%s' in move '%s'",
+	"move.invalid_uci":         "invalid UCI move: '%s'",
+	"move.promotion_missing":   "promotion piece is missing: '%s'",
+	"move.promotion_not_pawn":  "only a pawn can promote: '%s'",
+	"move.square_off_board":    "square off the board: '%s' in move '%s'",
+
+	// Узоры
+	"pattern.duplicate":      "pattern '%s' is already registered",
+	"pattern.empty_name":     "pattern name cannot be empty",
+	"pattern.error":          "%v: %v",
+	"pattern.mask_char":      "mask '%s' contains invalid character '%c': expected '#' and '.'",
+	"pattern.mask_empty":     "empty pattern mask",
+	"pattern.mask_ragged":    "rows of mask '%s' must be non-empty and of equal length",
+	"pattern.mask_too_large": "pattern mask is larger than %dx%d",
+	"pattern.no_func":        "no function given for pattern '%s'",
+
+	// Perft
+	"perft.depth":          "perft depth must be from 1 to %d, got %d",
+	"perft.depth_error":    "depth: %s",
+	"perft.depth_required": "specify the depth",
+	"perft.summary":        "\nDepth: %d\nNodes: %d\nTime: %s\nSpeed: %d nodes/s\n",
+
+	// Формат PGN
+	"pgn.comment_not_closed":    "comment is not closed",
+	"pgn.error":                 "invalid PGN: game %d, line %d: %s",
+	"pgn.extra_paren":           "unexpected closing parenthesis",
+	"pgn.file_required":         "specify a file: %s pgn show <file>",
+	"pgn.game_error":            "game %d: %s",
+	"pgn.game_header":           "Game %d: %s - %s (%s)\n",
+	"pgn.game_number_error":     "game number: %s",
+	"pgn.game_number_min":       "game numbers start from 1",
+	"pgn.initial_position":      "initial position",
+	"pgn.invalid_fen_tag":       "invalid FEN tag: %s",
+	"pgn.invalid_move":          "invalid move notation: '%s'",
+	"pgn.invalid_nag":           "invalid move annotation: '%s'",
+	"pgn.nag_before_move":       "annotation '%s' before the first move",
+	"pgn.open_failed":           "cannot open file '%s': %s",
+	"pgn.ply_error":             "ply %d: %s",
+	"pgn.ply_header":            "Ply %d of %d: %s\n",
+	"pgn.ply_number_error":      "ply number: %s",
+	"pgn.result_in_variation":   "result '%s' inside a variation",
+	"pgn.string_not_closed":     "string is not closed",
+	"pgn.tag_bracket_expected":  "']' expected after tag '%s'",
+	"pgn.tag_in_variation":      "tag inside a variation",
+	"pgn.tag_name_expected":     "tag name expected",
+	"pgn.tag_value_expected":    "value of tag '%s' expected",
+	"pgn.unexpected_char":       "unexpected character '%c'",
+	"pgn.unexpected_token":      "unexpected token: '%s'",
+	"pgn.variation_before_move": "variation before the first move",
+	"pgn.variation_not_closed":  "variation is not closed",
+	"pgn.variation_too_deep":    "variations are nested deeper than %d levels",
+
+	// Интерактивный режим
+	"play.help":            "Commands:\n  <move> make a move in SAN (e4, Nf3, O-O) or UCI (e2e4)\n  undo   take back the last move\n  flip   flip the board\n  fen    show the position in FEN\n  new    start the game over\n  help   show this help\n  quit   exit\n",
+	"play.move":            "Move: %s\n",
+	"play.move_error_hint": "%s. Type help for the list of commands",
+	"play.no_undo":         "no moves to take back",
+	"play.prompt":          "Enter a move or a command (help lists the commands).\n",
+	"play.read_error":      "failed to read input: %s",
+	"play.undone":          "Took back: %s\n",
+
+	// Позиция
+	"position.error":            "%v: %v",
+	"position.king_in_check":    "the %s king is in check while %s is to move",
+	"position.squares_mismatch": "number of squares (%d) does not match the %dx%d board size",
+	"position.two_kings":        "%s has more than one king",
+
+	// Параметры изображения
+	"render.border":         "border width: %s",
+	"render.default_size":   "Using the default size %dx%d\n",
+	"render.fen_conflict":   "board size and setup are defined by the FEN",
+	"render.setup_fallback": "Error: %s. The pieces are not set up.\n",
+	"render.size_fallback":  "Error: %s. Using the default size %dx%d.\n",
+	"render.square_size":    "square size: %s",
+
+	// Команда serve
+	"serve.error":          "server error: %s",
+	"serve.listen_error":   "failed to listen on '%s': %s",
+	"serve.listening":      "Server is listening on http://%s\n",
+	"serve.max_size_error": "invalid maximum board size: %s",
+	"serve.stopped":        "Server stopped.\n",
+
+	// Расстановка
+	"setup.empty_unavailable":    "the empty setup is only possible on boards of size %dx%d, got %dx%d",
+	"setup.standard_unavailable": "the standard setup is only possible on boards of size %dx%d, got %dx%d",
+
+	// Стороны
+	"side.black.genitive":   "black",
+	"side.black.nominative": "Black",
+	"side.white.genitive":   "white",
+	"side.white.nominative": "White",
+
+	// Размер доски
+	"size.height":    "height",
+	"size.too_large": "board size cannot exceed %d (%s %d)",
+	"size.too_small": "board size cannot be less than %d (%s %d)",
+	"size.width":     "width",
+
+	// Клетки
+	"square.invalid": "invalid square: '%s'",
+
+	// Статус партии
+	"status.check":                 "check. %s to move.",
+	"status.checkmate":             "checkmate. %s wins (%s).",
+	"status.fifty_moves":           " A draw can be claimed under the 50-move rule.",
+	"status.fivefold_repetition":   "fivefold repetition. Draw (%s).",
+	"status.insufficient_material": "insufficient material to checkmate. Draw (%s).",
+	"status.seventy_five_moves":    "75 moves without captures or pawn moves. Draw (%s).",
+	"status.stalemate":             "stalemate. Draw (%s).",
+	"status.threefold_repetition":  " A draw can be claimed: threefold repetition.",
+	"status.to_move":               "%s to move.",
+
+	// Полноэкранный режим
+	"tui.help":             "Arrows or hjkl - cursor, Enter or space - select and move, Esc - cancel selection, u - take back, f - flip, q - quit",
+	"tui.illegal_move":     "Illegal move.",
+	"tui.linux_only":       "full-screen mode is supported only on Linux",
+	"tui.move":             "Move: %s",
+	"tui.no_moves":         "This piece has no moves.",
+	"tui.no_undo":          "No moves to take back.",
+	"tui.not_terminal":     "full-screen mode works only in a terminal",
+	"tui.promotion":        "Pawn promotion: q or Enter - queen, r - rook, b - bishop, n - knight, Esc - cancel the move.",
+	"tui.select_piece":     "Select a %s piece.",
+	"tui.status":           "Status: %s\r\n",
+	"tui.terminated":       "full-screen mode interrupted by signal '%v'",
+	"tui.undone":           "Took back: %s",
+	"tui.window_too_small": "The window is too small: need at least %dx%d, have %dx%d.\r\n",
+
+	// Неизвестные значения
+	"unknown.format":      "unknown format",
+	"unknown.language":    "unknown language",
+	"unknown.orientation": "unknown side",
+	"unknown.palette":     "unknown palette",
+	"unknown.pattern":     "unknown pattern",
+	"unknown.piece_style": "unknown piece style",
+	"unknown.setup":       "unknown setup",
+	"unknown.theme":       "unknown theme",
+}
+
+// englishPlurals - сообщения, зависящие от числа
+var englishPlurals = map[Key][]string{
+	"pgn.game_not_found":   {"file '%s' has %d game, game %d requested", "file '%s' has %d games, game %d requested"},
+	"pgn.ply_out_of_range": {"the game has %d ply, ply %d requested", "the game has %d plies, ply %d requested"},
+}
@@ -0,0 +1,243 @@
+// Package i18n содержит каталог сообщений программы на поддерживаемых языках
+// и форматирует сообщения с учетом правил множественного числа языка.
+package i18n
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Lang - язык сообщений (код ISO 639-1)
+type Lang string
+
+const (
+	Russian Lang = "ru"
+	English Lang = "en"
+
+	// Default - язык, на котором выводятся сообщения, если язык не выбран
+	// или не поддерживается
+	Default = Russian
+)
+
+// Key - ключ сообщения в каталоге, например "board.header"
+type Key string
+
+// catalog - сообщения одного языка
+type catalog struct {
+	messages map[Key]string
+	// plurals - сообщения, зависящие от числа: формы в порядке, который задает plural
+	plurals map[Key][]string
+	// plural возвращает номер формы множественного числа для n
+	plural func(n int) int
+}
+
+// catalogs - каталоги поддерживаемых языков
+var catalogs = map[Lang]*catalog{
+	Russian: {messages: russianMessages, plurals: russianPlurals, plural: russianPlural},
+	English: {messages: englishMessages, plurals: englishPlurals, plural: englishPlural},
+}
+
+// Languages возвращает поддерживаемые языки
+func Languages() []Lang {
+	return []Lang{Russian, English}
+}
+
+// LookupLang возвращает язык по коду ("ru", "en") или имени локали
+// ("en_US.UTF-8"); регистр не важен
+func LookupLang(name string) (Lang, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if code, _, found := strings.Cut(name, "_"); found {
+		name = code
+	} else if code, _, found := strings.Cut(name, "."); found {
+		name = code
+	}
+	lang := Lang(name)
+	_, ok := catalogs[lang]
+	return lang, ok
+}
+
+// Detect выбирает язык по переменным окружения LC_ALL, LC_MESSAGES и LANG, как
+// это делают утилиты POSIX: учитывается первая непустая переменная. Если она
+// задает неподдерживаемый язык, возвращается Default.
+func Detect(getenv func(string) string) Lang {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := getenv(name)
+		if value == "" {
+			continue
+		}
+		if lang, ok := LookupLang(value); ok {
+			return lang
+		}
+		return Default
+	}
+	return Default
+}
+
+// Printer форматирует сообщения каталога на одном языке
+type Printer struct {
+	lang    Lang
+	catalog *catalog
+}
+
+// NewPrinter создает Printer для языка lang; для неподдерживаемого языка
+// сообщения выводятся на языке по умолчанию
+func NewPrinter(lang Lang) *Printer {
+	c, ok := catalogs[lang]
+	if !ok {
+		lang, c = Default, catalogs[Default]
+	}
+	return &Printer{lang: lang, catalog: c}
+}
+
+// defaultPrinter форматирует сообщения ошибок, которые выводятся через Error()
+var defaultPrinter = NewPrinter(Default)
+
+// DefaultPrinter возвращает Printer языка по умолчанию. Через него ошибки,
+// которые реализуют Localizable, формируют текст для Error().
+func DefaultPrinter() *Printer {
+	return defaultPrinter
+}
+
+// Lang возвращает язык сообщений
+func (p *Printer) Lang() Lang {
+	return p.lang
+}
+
+// Sprintf форматирует сообщение key с аргументами args по правилам fmt.
+// Аргументы-ошибки и аргументы типа Key тоже выводятся на языке Printer.
+// Если сообщения нет в каталоге языка, берется сообщение языка по умолчанию,
+// а если нет и его - сам ключ.
+func (p *Printer) Sprintf(key Key, args ...any) string {
+	format, ok := p.catalog.messages[key]
+	if !ok {
+		if format, ok = catalogs[Default].messages[key]; !ok {
+			format = string(key)
+		}
+	}
+	return p.format(format, args)
+}
+
+// Plural форматирует сообщение key в форме, которая соответствует числу n.
+// Само число передается в args, если сообщение его выводит.
+func (p *Printer) Plural(key Key, n int, args ...any) string {
+	c := p.catalog
+	forms, ok := c.plurals[key]
+	if !ok {
+		if c, forms = catalogs[Default], catalogs[Default].plurals[key]; forms == nil {
+			return string(key)
+		}
+	}
+	form := c.plural(n)
+	if form >= len(forms) {
+		form = len(forms) - 1
+	}
+	return p.format(forms[form], args)
+}
+
+// Fprintf записывает сообщение key в w
+func (p *Printer) Fprintf(w io.Writer, key Key, args ...any) (int, error) {
+	return io.WriteString(w, p.Sprintf(key, args...))
+}
+
+// Error возвращает текст ошибки на языке Printer. Переводятся ошибки, которые
+// реализуют Localizable; остальные выводятся как есть.
+func (p *Printer) Error(err error) string {
+	if l, ok := err.(Localizable); ok {
+		return l.Localize(p)
+	}
+	return err.Error()
+}
+
+func (p *Printer) format(format string, args []any) string {
+	localized := make([]any, len(args))
+	for i, arg := range args {
+		switch arg := arg.(type) {
+		case Key:
+			localized[i] = p.Sprintf(arg)
+		case error:
+			localized[i] = p.Error(arg)
+		default:
+			localized[i] = arg
+		}
+	}
+	return fmt.Sprintf(format, localized...)
+}
+
+// Localizable - ошибка, текст которой можно вывести на любом языке каталога.
+// Error() такой ошибки возвращает текст на языке по умолчанию.
+type Localizable interface {
+	error
+	Localize(p *Printer) string
+}
+
+// Error - ошибка с сообщением из каталога
+type Error struct {
+	Key  Key
+	Args []any
+	// count - число, по которому выбирается форма сообщения; см. PluralErrorf
+	count  int
+	plural bool
+}
+
+// New создает ошибку с сообщением key без аргументов. Как и errors.New, каждый
+// вызов возвращает отдельную ошибку, поэтому New подходит для ошибок-классов,
+// которые сравниваются через errors.Is.
+func New(key Key) error {
+	return &Error{Key: key}
+}
+
+// Errorf создает ошибку с сообщением key. Аргументы-ошибки можно проверить
+// через errors.Is и errors.As, как при обертке через %w в fmt.Errorf.
+func Errorf(key Key, args ...any) error {
+	return &Error{Key: key, Args: args}
+}
+
+// PluralErrorf создает ошибку с сообщением key, зависящим от числа n (см. Printer.Plural)
+func PluralErrorf(key Key, n int, args ...any) error {
+	return &Error{Key: key, Args: args, count: n, plural: true}
+}
+
+func (e *Error) Error() string {
+	return e.Localize(defaultPrinter)
+}
+
+func (e *Error) Localize(p *Printer) string {
+	if e.plural {
+		return p.Plural(e.Key, e.count, e.Args...)
+	}
+	return p.Sprintf(e.Key, e.Args...)
+}
+
+func (e *Error) Unwrap() []error {
+	var wrapped []error
+	for _, arg := range e.Args {
+		if err, ok := arg.(error); ok {
+			wrapped = append(wrapped, err)
+		}
+	}
+	return wrapped
+}
+
+// russianPlural выбирает форму для русского языка: одна, две или пять партий
+func russianPlural(n int) int {
+	n %= 100
+	if n < 0 {
+		n = -n
+	}
+	switch {
+	case n%10 == 1 && n != 11:
+		return 0
+	case n%10 >= 2 && n%10 <= 4 && (n < 12 || n > 14):
+		return 1
+	}
+	return 2
+}
+
+// englishPlural выбирает форму для английского языка: one game, two games
+func englishPlural(n int) int {
+	if n == 1 {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,305 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// verbPattern находит глаголы форматирования fmt: %s, %d, %-*s, %q и т.п.
+var verbPattern = regexp.MustCompile(`%[-+# 0]*(\*|\d+)?(\.(\*|\d+))?[a-zA-Z%]`)
+
+func TestCatalogs_SameKeys(t *testing.T) {
+	base := catalogs[Default]
+	for _, lang := range Languages() {
+		c := catalogs[lang]
+		t.Run(string(lang), func(t *testing.T) {
+			for key := range base.messages {
+				if _, ok := c.messages[key]; !ok {
+					t.Errorf("нет перевода сообщения %q", key)
+				}
+			}
+			for key := range c.messages {
+				if _, ok := base.messages[key]; !ok {
+					t.Errorf("лишнее сообщение %q", key)
+				}
+			}
+			for key := range base.plurals {
+				if _, ok := c.plurals[key]; !ok {
+					t.Errorf("нет перевода сообщения %q", key)
+				}
+			}
+			for key := range c.plurals {
+				if _, ok := base.plurals[key]; !ok {
+					t.Errorf("лишнее сообщение %q", key)
+				}
+			}
+		})
+	}
+}
+
+// keyPattern находит в исходном коде строки, похожие на ключи каталога: "board.header"
+var keyPattern = regexp.MustCompile(`"([a-z]+(\.[a-z_]+)+)"`)
+
+func TestCatalogs_UsedKeys(t *testing.T) {
+	base := catalogs[Default]
+	// Ключ считается ключом каталога, если его группа есть в каталоге
+	groups := make(map[string]bool)
+	for key := range base.messages {
+		group, _, _ := strings.Cut(string(key), ".")
+		groups[group] = true
+	}
+
+	err := filepath.WalkDir("..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		source, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range keyPattern.FindAllStringSubmatch(string(source), -1) {
+			key := Key(match[1])
+			group, _, _ := strings.Cut(match[1], ".")
+			_, isMessage := base.messages[key]
+			_, isPlural := base.plurals[key]
+			if groups[group] && !isMessage && !isPlural {
+				t.Errorf("%s: ключа %q нет в каталоге", path, key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// literalErrorPattern находит ошибки с текстом прямо в исходном коде:
+// errors.New("...") и fmt.Errorf("...")
+var literalErrorPattern = regexp.MustCompile("(?:errors\\.New|fmt\\.Errorf)\\(\\s*(\"(?:[^\"\\\\]|\\\\.)*\"|`[^`]*`)")
+
+// letterPattern находит буквы, оставшиеся в сообщении после удаления глаголов fmt
+var letterPattern = regexp.MustCompile(`\pL`)
+
+func TestSources_NoLiteralMessages(t *testing.T) {
+	// Сообщение с текстом в обход каталога выводится на одном языке при любом
+	// --lang; допустимы только обертки без текста вроде fmt.Errorf("%w: %v")
+	err := filepath.WalkDir("..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		source, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range literalErrorPattern.FindAllStringSubmatch(string(source), -1) {
+			if letterPattern.MatchString(verbPattern.ReplaceAllString(match[1], "")) {
+				t.Errorf("%s: сообщение %s не из каталога", path, match[1])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCatalogs_SameVerbs(t *testing.T) {
+	base := catalogs[Default]
+	for _, lang := range Languages() {
+		c := catalogs[lang]
+		t.Run(string(lang), func(t *testing.T) {
+			for key, format := range c.messages {
+				want := verbPattern.FindAllString(base.messages[key], -1)
+				if got := verbPattern.FindAllString(format, -1); !slices.Equal(got, want) {
+					t.Errorf("%q: глаголы %v, ожидались %v", key, got, want)
+				}
+			}
+			for key, forms := range c.plurals {
+				want := verbPattern.FindAllString(base.plurals[key][0], -1)
+				for _, form := range forms {
+					if got := verbPattern.FindAllString(form, -1); !slices.Equal(got, want) {
+						t.Errorf("%q: глаголы %v в форме %q, ожидались %v", key, got, form, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCatalogs_PluralForms(t *testing.T) {
+	// Число форм множественного числа в каждом языке
+	expected := map[Lang]int{Russian: 3, English: 2}
+	for _, lang := range Languages() {
+		for key, forms := range catalogs[lang].plurals {
+			if len(forms) != expected[lang] {
+				t.Errorf("%s %q: %d форм, ожидалось %d", lang, key, len(forms), expected[lang])
+			}
+		}
+	}
+}
+
+func TestPluralRules(t *testing.T) {
+	testCases := []struct {
+		n       int
+		russian int
+		english int
+	}{
+		{0, 2, 1},
+		{1, 0, 0},
+		{2, 1, 1},
+		{4, 1, 1},
+		{5, 2, 1},
+		{11, 2, 1},
+		{12, 2, 1},
+		{14, 2, 1},
+		{21, 0, 1},
+		{22, 1, 1},
+		{111, 2, 1},
+		{-1, 0, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprint(tc.n), func(t *testing.T) {
+			if got := russianPlural(tc.n); got != tc.russian {
+				t.Errorf("русский: ожидалась форма %d, получено %d", tc.russian, got)
+			}
+			if got := englishPlural(tc.n); got != tc.english {
+				t.Errorf("английский: ожидалась форма %d, получено %d", tc.english, got)
+			}
+		})
+	}
+}
+
+func TestLookupLang(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected Lang
+		ok       bool
+	}{
+		{"ru", Russian, true},
+		{"en", English, true},
+		{"EN", English, true},
+		{"en_US.UTF-8", English, true},
+		{"ru_RU", Russian, true},
+		{"en.UTF-8", English, true},
+		{"de", "de", false},
+		{"C", "c", false},
+		{"", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lang, ok := LookupLang(tc.name)
+			if lang != tc.expected || ok != tc.ok {
+				t.Errorf("ожидалось (%q, %v), получено (%q, %v)", tc.expected, tc.ok, lang, ok)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		expected Lang
+	}{
+		{"переменные не заданы", nil, Default},
+		{"LANG", map[string]string{"LANG": "en_US.UTF-8"}, English},
+		{"LC_MESSAGES важнее LANG", map[string]string{"LC_MESSAGES": "ru_RU.UTF-8", "LANG": "en_US.UTF-8"}, Russian},
+		{"LC_ALL важнее всех", map[string]string{"LC_ALL": "en_GB", "LC_MESSAGES": "ru_RU"}, English},
+		{"неподдерживаемый язык", map[string]string{"LC_ALL": "de_DE", "LANG": "en_US"}, Default},
+		{"локаль C", map[string]string{"LANG": "C"}, Default},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			getenv := func(name string) string { return tc.env[name] }
+			if got := Detect(getenv); got != tc.expected {
+				t.Errorf("ожидался язык %q, получено %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPrinter_Sprintf(t *testing.T) {
+	testCases := []struct {
+		name     string
+		lang     Lang
+		key      Key
+		args     []any
+		expected string
+	}{
+		{"русский", Russian, "board.header", []any{8, 8}, "Шахматная доска 8x8:\n"},
+		{"английский", English, "board.header", []any{8, 8}, "Chessboard 8x8:\n"},
+		{"аргумент-ключ", English, "size.too_small", []any{4, Key("size.width"), 3}, "board size cannot be less than 4 (width 3)"},
+		{"неизвестный ключ", English, "no.such.key", nil, "no.such.key"},
+		{"неподдерживаемый язык", "de", "board.header", []any{2, 2}, "Шахматная доска 2x2:\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := NewPrinter(tc.lang).Sprintf(tc.key, tc.args...); got != tc.expected {
+				t.Errorf("ожидалось %q, получено %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPrinter_Plural(t *testing.T) {
+	testCases := []struct {
+		lang     Lang
+		n        int
+		expected string
+	}{
+		{Russian, 1, "в партии 1 полуход, запрошен полуход 2"},
+		{Russian, 3, "в партии 3 полухода, запрошен полуход 4"},
+		{Russian, 5, "в партии 5 полуходов, запрошен полуход 6"},
+		{English, 1, "the game has 1 ply, ply 2 requested"},
+		{English, 3, "the game has 3 plies, ply 4 requested"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s %d", tc.lang, tc.n), func(t *testing.T) {
+			got := NewPrinter(tc.lang).Plural("pgn.ply_out_of_range", tc.n, tc.n, tc.n+1)
+			if got != tc.expected {
+				t.Errorf("ожидалось %q, получено %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	sentinel := New("error.illegal_move")
+	err := Errorf("error.with_value", sentinel, "e5")
+
+	if !errors.Is(err, sentinel) {
+		t.Error("ошибка должна оборачивать аргумент-ошибку")
+	}
+	if got, want := err.Error(), "недопустимый ход: 'e5'"; got != want {
+		t.Errorf("Error(): ожидалось %q, получено %q", want, got)
+	}
+	if got, want := NewPrinter(English).Error(err), "illegal move: 'e5'"; got != want {
+		t.Errorf("английский: ожидалось %q, получено %q", want, got)
+	}
+	if got, want := NewPrinter(English).Error(errors.New("сбой")), "сбой"; got != want {
+		t.Errorf("обычная ошибка должна выводиться как есть: %q", got)
+	}
+
+	plural := PluralErrorf("pgn.ply_out_of_range", 2, 2, 3)
+	if got, want := plural.Error(), "в партии 2 полухода, запрошен полуход 3"; got != want {
+		t.Errorf("PluralErrorf: ожидалось %q, получено %q", want, got)
+	}
+}
+
+func TestNew_Distinct(t *testing.T) {
+	if errors.Is(New("error.invalid_size"), New("error.invalid_size")) {
+		t.Error("ошибки New с одним ключом должны различаться, как errors.New")
+	}
+}
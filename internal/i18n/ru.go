@@ -0,0 +1,315 @@
+package i18n
+
+// russianMessages - сообщения на русском языке; русский - язык по умолчанию, и в нем должны быть все ключи
+var russianMessages = map[Key]string{
+	// Битовые доски
+	"bitboard.size": "битовые доски поддерживают только доску %dx%d, получено %dx%d",
+
+	// Вывод доски
+	"board.header":       "Шахматная доска %dx%d:\n",
+	"board.render_error": "ошибка отрисовки: %s",
+	"board.status":       "Статус: %s\n",
+	"board.status_error": "ошибка определения статуса: %s",
+
+	// Разбор командной строки
+	"cli.allowed_values":      ". Допустимые значения: %s",
+	"cli.error":               "Ошибка: %s.\n",
+	"cli.extra_argument":      "лишний аргумент: '%s'",
+	"cli.flag_value_required": "флаг '%s' требует значения",
+	"cli.help_hint":           "Справка: %s help %s\n",
+	"cli.invalid_flag_value":  "неверное значение флага '%s': '%s'",
+	"cli.unknown_command":     "неизвестная команда: '%s'. Список команд: %s help",
+	"cli.unknown_flag":        "неизвестный флаг: '%s'",
+
+	// Цвета
+	"color.invalid": "неверный формат цвета: '%s'",
+
+	// Команды
+	"command.fen.args":           "<FEN>",
+	"command.fen.description":    "Выводит позицию и состояние партии. Размер доски определяется расстановкой.",
+	"command.fen.summary":        "вывести позицию, заданную в нотации FEN",
+	"command.help.args":          "[команда]",
+	"command.help.description":   "Без аргументов выводит список команд, с именем команды - справку по ней.",
+	"command.help.summary":       "показать справку",
+	"command.perft.args":         "<глубина>",
+	"command.perft.description":  "Считает листья дерева легальных ходов заданной глубины и выводит разбивку\nпо первым ходам, время и скорость подсчета.",
+	"command.perft.summary":      "посчитать число позиций в дереве ходов",
+	"command.pgn.args":           "show <файл>",
+	"command.pgn.description":    "Выводит доску после M-го полухода N-й партии файла PGN.\nПо умолчанию - конечная позиция первой партии.",
+	"command.pgn.summary":        "показать позицию из партии в формате PGN",
+	"command.play.description":   "Читает ходы (e4, Nf3, e2e4) и команды undo, flip, fen, new, help, quit\nпо одной на строку и перерисовывает доску после каждой. Ввод можно перенаправить из файла.",
+	"command.play.summary":       "играть в консоли",
+	"command.render.args":        "[размер]",
+	"command.render.description": "Выводит доску размера N или WxH (по умолчанию 8x8) в текстовом или графическом формате.\nИмя команды можно не указывать: \"chessboard 10\" равносильно \"chessboard render 10\".",
+	"command.render.summary":     "вывести доску заданного размера (команда по умолчанию)",
+	"command.serve.description":  "Отвечает на запросы GET /board, POST /fen/validate и POST /moves.\nОстанавливается по Ctrl+C или SIGTERM, дождавшись завершения начатых запросов.",
+	"command.serve.summary":      "запустить сервер HTTP",
+	"command.version.summary":    "показать версию программы",
+
+	// Классы ошибок предметной области
+	"error.fractional_number": "дробные числа не поддерживаются",
+	"error.illegal_move":      "недопустимый ход",
+	"error.invalid_fen":       "неверный FEN",
+	"error.invalid_number":    "неверное число",
+	"error.invalid_pattern":   "неверный узор",
+	"error.invalid_position":  "невозможная позиция",
+	"error.invalid_size":      "неверный размер доски",
+	"error.negative_number":   "отрицательные числа не поддерживаются",
+	"error.not_a_number":      "неверный формат числа",
+	"error.setup_unavailable": "расстановка невозможна",
+	"error.size_too_large":    "размер доски слишком велик",
+	"error.size_too_small":    "размер доски слишком мал",
+	"error.unknown_value":     "неизвестное значение",
+	"error.with_value":        "%s: '%s'",
+
+	// Коды завершения
+	"exit.error": "внутренняя ошибка",
+	"exit.fen":   "неверная позиция FEN",
+	"exit.io":    "ошибка ввода-вывода",
+	"exit.move":  "неверный ход",
+	"exit.ok":    "команда выполнена",
+	"exit.pgn":   "неверная партия PGN",
+	"exit.size":  "неверный размер доски",
+	"exit.usage": "неверные аргументы",
+
+	// Нотация FEN
+	"fen.board_too_large":         "слишком большая доска",
+	"fen.castling_pieces_missing": "нет короля и ладьи на исходных клетках",
+	"fen.duplicate_castling":      "право рокировки указано дважды",
+	"fen.empty":                   "пустая строка",
+	"fen.empty_rank":              "пустая горизонталь",
+	"fen.en_passant_pawn":         "на %s нет пешки, сделавшей ход через %s",
+	"fen.en_passant_rank":         "взятие на проходе возможно только на горизонтали %s",
+	"fen.error":                   "неверный FEN: %s",
+	"fen.error_at":                "неверный FEN: поле %d (%s), позиция %d: %s",
+	"fen.error_at_char":           "неверный FEN: поле %d (%s), позиция %d, символ '%c': %s",
+	"fen.extra_space":             "лишний пробел",
+	"fen.field.castling":          "рокировка",
+	"fen.field.en_passant":        "взятие на проходе",
+	"fen.field.fullmove_number":   "номер хода",
+	"fen.field.halfmove_clock":    "полуходы",
+	"fen.field.placement":         "расстановка",
+	"fen.field.side_to_move":      "очередь хода",
+	"fen.field_count":             "ожидалось %d полей через пробел, получено %d",
+	"fen.invalid_square":          "неверное обозначение клетки '%s'",
+	"fen.leading_zero":            "число не может начинаться с нуля",
+	"fen.leading_zero_empty":      "число пустых клеток не может начинаться с нуля",
+	"fen.number_expected":         "ожидалось неотрицательное целое число",
+	"fen.number_too_large":        "слишком большое число",
+	"fen.number_too_small":        "значение должно быть не меньше %d",
+	"fen.pawn_on_edge":            "пешка не может стоять на крайней горизонтали",
+	"fen.position_required":       "укажите позицию в нотации FEN",
+	"fen.rank_too_long":           "горизонталь %d длиннее первой (%d клеток)",
+	"fen.rank_width":              "горизонталь %d содержит %d клеток, ожидалось %d",
+	"fen.side_expected":           "ожидалось 'w' или 'b'",
+	"fen.square_occupied":         "клетка %s занята",
+	"fen.square_off_board":        "клетка %s вне доски %dx%d",
+	"fen.unknown_castling":        "неизвестное право рокировки",
+	"fen.unknown_piece":           "неизвестная фигура",
+
+	// Флаги
+	"flag.addr":        "`адрес` и порт, на котором сервер принимает соединения (по умолчанию localhost:8080)",
+	"flag.border":      "`ширина` рамки изображения в пикселях",
+	"flag.coords":      "показать координаты клеток",
+	"flag.dark":        "`цвет` темных клеток, например #769656",
+	"flag.format":      "`формат` вывода: text, svg, png или json",
+	"flag.game":        "`номер` партии в файле, начиная с 1",
+	"flag.light":       "`цвет` светлых клеток, например #eeeed2",
+	"flag.max_size":    "наибольший `размер` доски в запросе (по умолчанию 64)",
+	"flag.orientation": "`сторона` снизу доски: white или black",
+	"flag.palette":     "`палитра` цветов клеток: brown, green, blue, gray",
+	"flag.pattern":     "`узор` раскраски клеток: checker, stripes, diagonal, rings или маска mask:#./.#",
+	"flag.perft_fen":   "начальная `позиция` FEN (по умолчанию - начальная позиция партии)",
+	"flag.pieces":      "`стиль` фигур: letters или unicode",
+	"flag.play_fen":    "`позиция` FEN, с которой начинается партия",
+	"flag.ply":         "номер `полуход`а, после которого показать позицию",
+	"flag.render_fen":  "`позиция` FEN, которую вывести вместо пустой доски",
+	"flag.setup":       "`расстановка` фигур: empty или standard (только 8x8)",
+	"flag.square_size": "`размер` клетки изображения в пикселях",
+	"flag.theme":       "`тема` текстового вывода: ascii, unicode, ansi или auto",
+	"flag.tui":         "полноэкранный режим с управлением курсором",
+
+	// Справка
+	"help.command_help":      "\nСправка по команде: %s help <команда> или %s <команда> --help\n",
+	"help.command_usage":     "Использование: %s\n\n%s\n",
+	"help.common_flags":      "\nОбщие флаги:\n  --strict     строгий режим: ошибка прерывает команду и выводится в stderr в формате JSON\n               (включен по умолчанию, если ввод или вывод перенаправлен; --strict=false выключает)\n  --lang LANG  язык сообщений: ru или en (по умолчанию из LC_ALL, LC_MESSAGES или LANG)\n",
+	"help.exit_codes":        "\nКоды завершения:\n",
+	"help.flags":             "\nФлаги:\n",
+	"help.flags_placeholder": " [флаги]",
+	"help.title":             "%s - генератор шахматных досок\n\n",
+	"help.usage":             "Использование: %s <команда> [аргументы] [флаги]\n\nКоманды:\n",
+
+	// Сервер HTTP
+	"http.body_too_large": "тело запроса больше %d байт",
+	"http.fen_required":   "укажите позицию в поле fen",
+	"http.invalid_json":   "неверный JSON в теле запроса: %s",
+	"http.invalid_param":  "неверное значение параметра '%s': '%s'",
+	"http.timeout":        "превышено время обработки запроса",
+
+	// Графические форматы
+	"image.border":      "ширина рамки должна быть от 0 до %d пикселей, получено %d",
+	"image.square_size": "размер клетки должен быть от 1 до %d пикселей, получено %d",
+	"image.too_large":   "изображение %dx%d слишком велико: не более %d пикселей",
+	"image.too_wide":    "сторона изображения превышает %d пикселей: %d клеток по %d пикселей и рамка %d",
+
+	// Описание доски в JSON
+	"json.board_too_large":     "на доске %dx%d с фигурами не может быть больше %d клеток",
+	"json.duplicate_square":    "клетка '%s' указана дважды",
+	"json.error":               "%v: %v",
+	"json.fen_mismatch":        "расстановка в поле fen не совпадает с фигурами на клетках",
+	"json.invalid_board":       "неверное описание доски в JSON",
+	"json.square_outside":      "клетка '%s' вне доски %dx%d",
+	"json.unknown_piece":       "неизвестная фигура '%s %s' на клетке '%s'",
+	"json.unknown_schema":      "неизвестная схема '%s', ожидалась '%s'",
+	"json.unsupported_version": "версия схемы %d не поддерживается, поддерживаются версии от 1 до %d",
+
+	// Запись ходов
+	"move.ambiguous":           "неоднозначный ход: '%s' (подходят %s)",
+	"move.castling_impossible": "рокировка невозможна: '%s'",
+	"move.empty":               "пустая запись хода: '%s'",
+	"move.error_in":            "%s в ходе '%s'",
+	"move.invalid":             "неверная запись хода: '%s'",
+	"move.invalid_promotion":   "неверная фигура превращения: '%s' в ходе '%s'",
+	"move.invalid_rank":        "неверная горизонталь: '%s' в ходе '%s'",
+	"move.invalid_uci":         "неверная запись хода UCI: '%s'",
+	"move.promotion_missing":   "не указана фигура превращения: '%s'",
+	"move.promotion_not_pawn":  "превращение возможно только для пешки: '%s'",
+	"move.square_off_board":    "клетка вне доски: '%s' в ходе '%s'",
+
+	// Узоры
+	"pattern.duplicate":      "узор '%s' уже зарегистрирован",
+	"pattern.empty_name":     "имя узора не может быть пустым",
+	"pattern.error":          "%v: %v",
+	"pattern.mask_char":      "в маске '%s' недопустимый символ '%c': ожидались '#' и '.'",
+	"pattern.mask_empty":     "пустая маска узора",
+	"pattern.mask_ragged":    "строки маски '%s' должны быть непустыми и одной длины",
+	"pattern.mask_too_large": "маска узора больше %dx%d",
+	"pattern.no_func":        "для узора '%s' не задана функция",
+
+	// Perft
+	"perft.depth":          "глубина perft должна быть от 1 до %d, получено %d",
+	"perft.depth_error":    "глубина: %s",
+	"perft.depth_required": "укажите глубину",
+	"perft.summary":        "\nГлубина: %d\nУзлов: %d\nВремя: %s\nСкорость: %d узлов/с\n",
+
+	// Формат PGN
+	"pgn.comment_not_closed":    "комментарий не закрыт",
+	"pgn.error":                 "неверный PGN: партия %d, строка %d: %s",
+	"pgn.extra_paren":           "лишняя закрывающая скобка",
+	"pgn.file_required":         "укажите файл: %s pgn show <файл>",
+	"pgn.game_error":            "партия %d: %s",
+	"pgn.game_header":           "Партия %d: %s - %s (%s)\n",
+	"pgn.game_number_error":     "номер партии: %s",
+	"pgn.game_number_min":       "номер партии начинается с 1",
+	"pgn.initial_position":      "начальная позиция",
+	"pgn.invalid_fen_tag":       "неверный тег FEN: %s",
+	"pgn.invalid_move":          "неверная запись хода: '%s'",
+	"pgn.invalid_nag":           "неверная оценка хода: '%s'",
+	"pgn.nag_before_move":       "оценка '%s' перед первым ходом",
+	"pgn.open_failed":           "не удалось открыть файл '%s': %s",
+	"pgn.ply_error":             "полуход %d: %s",
+	"pgn.ply_header":            "Полуход %d из %d: %s\n",
+	"pgn.ply_number_error":      "номер полухода: %s",
+	"pgn.result_in_variation":   "результат '%s' внутри варианта",
+	"pgn.string_not_closed":     "строка не закрыта",
+	"pgn.tag_bracket_expected":  "ожидалась ']' после тега '%s'",
+	"pgn.tag_in_variation":      "тег внутри варианта",
+	"pgn.tag_name_expected":     "ожидалось имя тега",
+	"pgn.tag_value_expected":    "ожидалось значение тега '%s'",
+	"pgn.unexpected_char":       "неожиданный символ '%c'",
+	"pgn.unexpected_token":      "неожиданная лексема: '%s'",
+	"pgn.variation_before_move": "вариант перед первым ходом",
+	"pgn.variation_not_closed":  "вариант не закрыт",
+	"pgn.variation_too_deep":    "варианты вложены глубже %d уровней",
+
+	// Интерактивный режим
+	"play.help":            "Команды:\n  <ход>  сделать ход в нотации SAN (e4, Nf3, O-O) или UCI (e2e4)\n  undo   отменить последний ход\n  flip   перевернуть доску\n  fen    показать позицию в нотации FEN\n  new    начать партию заново\n  help   показать эту справку\n  quit   выйти\n",
+	"play.move":            "Ход: %s\n",
+	"play.move_error_hint": "%s. Список команд: help",
+	"play.no_undo":         "нет ходов для отмены",
+	"play.prompt":          "Введите ход или команду (help - список команд).\n",
+	"play.read_error":      "ошибка чтения ввода: %s",
+	"play.undone":          "Отменен ход: %s\n",
+
+	// Позиция
+	"position.error":            "%v: %v",
+	"position.king_in_check":    "король %s под шахом, хотя ход %s",
+	"position.squares_mismatch": "число клеток (%d) не соответствует размеру доски %dx%d",
+	"position.two_kings":        "у %s больше одного короля",
+
+	// Параметры изображения
+	"render.border":         "ширина рамки: %s",
+	"render.default_size":   "Используется размер по умолчанию %dx%d\n",
+	"render.fen_conflict":   "размер доски и расстановка задаются в FEN",
+	"render.setup_fallback": "Ошибка: %s. Фигуры не расставлены.\n",
+	"render.size_fallback":  "Ошибка: %s. Используется размер по умолчанию %dx%d.\n",
+	"render.square_size":    "размер клетки: %s",
+
+	// Команда serve
+	"serve.error":          "ошибка сервера: %s",
+	"serve.listen_error":   "не удалось открыть адрес '%s': %s",
+	"serve.listening":      "Сервер запущен: http://%s\n",
+	"serve.max_size_error": "неверный наибольший размер доски: %s",
+	"serve.stopped":        "Сервер остановлен.\n",
+
+	// Расстановка
+	"setup.empty_unavailable":    "пустая расстановка возможна только на доске %dx%d, получено %dx%d",
+	"setup.standard_unavailable": "стандартная расстановка возможна только на доске %dx%d, получено %dx%d",
+
+	// Стороны
+	"side.black.genitive":   "черных",
+	"side.black.nominative": "черные",
+	"side.white.genitive":   "белых",
+	"side.white.nominative": "белые",
+
+	// Размер доски
+	"size.height":    "высота",
+	"size.too_large": "размер доски не может превышать %d (%s %d)",
+	"size.too_small": "размер доски не может быть меньше %d (%s %d)",
+	"size.width":     "ширина",
+
+	// Клетки
+	"square.invalid": "неверное обозначение клетки: '%s'",
+
+	// Статус партии
+	"status.check":                 "шах. Ход %s.",
+	"status.checkmate":             "мат. Победили %s (%s).",
+	"status.fifty_moves":           " Можно потребовать ничью по правилу 50 ходов.",
+	"status.fivefold_repetition":   "позиция повторилась пять раз. Ничья (%s).",
+	"status.insufficient_material": "недостаточно материала для мата. Ничья (%s).",
+	"status.seventy_five_moves":    "75 ходов без взятий и ходов пешками. Ничья (%s).",
+	"status.stalemate":             "пат. Ничья (%s).",
+	"status.threefold_repetition":  " Можно потребовать ничью: позиция повторилась трижды.",
+	"status.to_move":               "ход %s.",
+
+	// Полноэкранный режим
+	"tui.help":             "Стрелки или hjkl - курсор, Enter или пробел - выбрать и сходить, Esc - отмена выбора, u - отменить ход, f - перевернуть, q - выход",
+	"tui.illegal_move":     "Недопустимый ход.",
+	"tui.linux_only":       "полноэкранный режим поддерживается только в Linux",
+	"tui.move":             "Ход: %s",
+	"tui.no_moves":         "У этой фигуры нет ходов.",
+	"tui.no_undo":          "Нет ходов для отмены.",
+	"tui.not_terminal":     "полноэкранный режим работает только в терминале",
+	"tui.promotion":        "Превращение пешки: q или Enter - ферзь, r - ладья, b - слон, n - конь, Esc - отмена хода.",
+	"tui.select_piece":     "Выберите фигуру %s.",
+	"tui.status":           "Статус: %s\r\n",
+	"tui.terminated":       "полноэкранный режим прерван сигналом '%v'",
+	"tui.undone":           "Отменен ход: %s",
+	"tui.window_too_small": "Окно слишком маленькое: нужно не меньше %dx%d, сейчас %dx%d.\r\n",
+
+	// Неизвестные значения
+	"unknown.format":      "неизвестный формат",
+	"unknown.language":    "неизвестный язык",
+	"unknown.orientation": "неизвестная сторона",
+	"unknown.palette":     "неизвестная палитра",
+	"unknown.pattern":     "неизвестный узор",
+	"unknown.piece_style": "неизвестный стиль фигур",
+	"unknown.setup":       "неизвестная расстановка",
+	"unknown.theme":       "неизвестная тема",
+}
+
+// russianPlurals - сообщения, зависящие от числа
+var russianPlurals = map[Key][]string{
+	"pgn.game_not_found":   {"в файле '%s' %d партия, запрошена партия %d", "в файле '%s' %d партии, запрошена партия %d", "в файле '%s' %d партий, запрошена партия %d"},
+	"pgn.ply_out_of_range": {"в партии %d полуход, запрошен полуход %d", "в партии %d полухода, запрошен полуход %d", "в партии %d полуходов, запрошен полуход %d"},
+}
@@ -0,0 +1,317 @@
+// Package notation читает и записывает ходы в стандартной алгебраической
+// нотации (SAN) и в длинной нотации UCI. Для разрешения неоднозначностей
+// и знаков шаха используются легальные ходы партии.
+package notation
+
+import (
+	"regexp"
+	"strings"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+// Обозначения рокировок; нули вместо букв O тоже принимаются при разборе
+const (
+	CastleKingside  = "O-O"
+	CastleQueenside = "O-O-O"
+)
+
+// Буквы фигур в записи хода и фигур, в которые может превратиться пешка
+const (
+	pieceLetters     = "KQRBN"
+	promotionLetters = "QRBN"
+)
+
+// sanBody - ход без фигуры, превращения и знаков: вертикаль и горизонталь
+// исходной клетки (обе необязательны), знак взятия и клетка назначения.
+// Буква x в уточнении вертикали всегда считается знаком взятия.
+var sanBody = regexp.MustCompile(`^([a-wyz]*)([0-9]*)(x?)([a-z]+)([0-9]+)$`)
+
+// FormatSAN записывает легальный ход партии в стандартной алгебраической нотации:
+// "e4", "Nbd2", "exd5", "e8=Q+", "O-O-O#". Чтобы определить знак шаха или мата,
+// ход временно делается в партии и отменяется.
+func FormatSAN(game domain.Game, move domain.Move) (string, error) {
+	board := game.Board()
+	legal := game.LegalMoves()
+	if !containsMove(legal, move) {
+		return "", i18n.Errorf("error.with_value", domain.ErrIllegalMove, move)
+	}
+
+	san := formatSANBody(board, legal, move)
+
+	if err := game.Play(move); err != nil {
+		return "", err
+	}
+	status := game.Status()
+	game.Undo()
+
+	switch {
+	case status.Checkmate:
+		san += "#"
+	case status.InCheck:
+		san += "+"
+	}
+	return san, nil
+}
+
+// formatSANBody записывает ход без знака шаха
+func formatSANBody(board *domain.Board, legal []domain.Move, move domain.Move) string {
+	piece := board.PieceAt(move.From.File, move.From.Rank)
+	if isCastling(piece, move) {
+		if move.To.File > move.From.File {
+			return CastleKingside
+		}
+		return CastleQueenside
+	}
+
+	var san strings.Builder
+	capture := isCapture(board, piece, move)
+	if piece.Kind == domain.Pawn {
+		if capture {
+			san.WriteString(domain.FileName(move.From.File))
+		}
+	} else {
+		san.WriteRune(domain.Piece{Kind: piece.Kind}.Letter())
+		san.WriteString(disambiguation(board, legal, piece, move))
+	}
+	if capture {
+		san.WriteByte('x')
+	}
+	san.WriteString(move.To.String())
+	if move.Promotion != domain.NoPiece {
+		san.WriteByte('=')
+		san.WriteRune(domain.Piece{Kind: move.Promotion}.Letter())
+	}
+	return san.String()
+}
+
+// disambiguation возвращает уточнение исходной клетки, если на ту же клетку
+// может пойти другая такая же фигура: вертикаль, горизонталь или обе
+func disambiguation(board *domain.Board, legal []domain.Move, piece domain.Piece, move domain.Move) string {
+	var ambiguous, sameFile, sameRank bool
+	for _, other := range legal {
+		if other.To != move.To || other.From == move.From ||
+			board.PieceAt(other.From.File, other.From.Rank) != piece {
+			continue
+		}
+		ambiguous = true
+		sameFile = sameFile || other.From.File == move.From.File
+		sameRank = sameRank || other.From.Rank == move.From.Rank
+	}
+
+	switch {
+	case !ambiguous:
+		return ""
+	case !sameFile:
+		return domain.FileName(move.From.File)
+	case !sameRank:
+		return domain.RankName(move.From.Rank)
+	default:
+		return move.From.String()
+	}
+}
+
+// isCastling сообщает, что ход короля на две клетки по горизонтали - рокировка
+func isCastling(piece domain.Piece, move domain.Move) bool {
+	return piece.Kind == domain.King && move.From.Rank == move.To.Rank &&
+		(move.To.File-move.From.File >= 2 || move.From.File-move.To.File >= 2)
+}
+
+// isCapture сообщает, что ход берет фигуру, в том числе пешку на проходе
+func isCapture(board *domain.Board, piece domain.Piece, move domain.Move) bool {
+	if !board.PieceAt(move.To.File, move.To.Rank).IsEmpty() {
+		return true
+	}
+	return piece.Kind == domain.Pawn && board.EnPassant != nil && *board.EnPassant == move.To
+}
+
+// ParseSAN разбирает ход в стандартной алгебраической нотации и находит его среди
+// легальных ходов партии. Знаки шаха, мата и оценки хода ("!", "?") не проверяются,
+// лишнее уточнение исходной клетки ("Ng1f3") допускается.
+func ParseSAN(game domain.Game, text string) (domain.Move, error) {
+	san := strings.TrimSpace(text)
+	body := strings.TrimRight(strings.TrimRight(san, "!?"), "+#")
+	if body == "" {
+		return domain.Move{}, i18n.Errorf("move.empty", text)
+	}
+
+	board := game.Board()
+	legal := game.LegalMoves()
+
+	switch body {
+	case CastleKingside, "0-0":
+		return findCastling(board, legal, true, san)
+	case CastleQueenside, "0-0-0":
+		return findCastling(board, legal, false, san)
+	}
+
+	kind := domain.Pawn
+	if strings.IndexByte(pieceLetters, body[0]) >= 0 {
+		piece, _ := domain.PieceFromLetter(rune(body[0]))
+		kind = piece.Kind
+		body = body[1:]
+	}
+	if body == "" {
+		return domain.Move{}, i18n.Errorf("move.invalid", san)
+	}
+
+	body, promotion, err := cutPromotion(body, san)
+	if err != nil {
+		return domain.Move{}, err
+	}
+	if promotion != domain.NoPiece && kind != domain.Pawn {
+		return domain.Move{}, i18n.Errorf("move.promotion_not_pawn", san)
+	}
+
+	parts := sanBody.FindStringSubmatch(body)
+	if parts == nil {
+		return domain.Move{}, i18n.Errorf("move.invalid", san)
+	}
+	patterns, err := sanPatterns(board, parts, san)
+	if err != nil {
+		return domain.Move{}, err
+	}
+
+	var matches []domain.Move
+	for _, move := range legal {
+		if board.PieceAt(move.From.File, move.From.Rank).Kind != kind {
+			continue
+		}
+		if promotion != domain.NoPiece && move.Promotion != promotion {
+			continue
+		}
+		for _, pattern := range patterns {
+			if pattern.matches(move) {
+				matches = append(matches, move)
+				break
+			}
+		}
+	}
+	return chooseMove(board, legal, matches, san)
+}
+
+// sanPattern - ограничения на ход, заданные записью: клетка назначения и,
+// если указаны, вертикаль и горизонталь исходной клетки
+type sanPattern struct {
+	to       domain.Square
+	fromFile int
+	fromRank int
+}
+
+func (p sanPattern) matches(move domain.Move) bool {
+	return move.To == p.to &&
+		(p.fromFile < 0 || move.From.File == p.fromFile) &&
+		(p.fromRank < 0 || move.From.Rank == p.fromRank)
+}
+
+// sanPatterns переводит части записи в ограничения на ход. На досках шире 26
+// вертикалей буквы вертикалей можно разделить между исходной клеткой и клеткой
+// назначения по-разному, поэтому проверяются все варианты.
+func sanPatterns(board *domain.Board, parts []string, san string) ([]sanPattern, error) {
+	fromLetters, fromDigits, capture, toLetters, toDigits := parts[1], parts[2], parts[3], parts[4], parts[5]
+
+	type split struct{ from, to string }
+	splits := []split{{fromLetters, toLetters}}
+	if fromDigits == "" && capture == "" {
+		for k := len(fromLetters) - 1; k >= 0; k-- {
+			splits = append(splits, split{fromLetters[:k], fromLetters[k:] + toLetters})
+		}
+	}
+
+	fromRank := -1
+	if fromDigits != "" {
+		square, err := domain.ParseSquare("a" + fromDigits)
+		if err != nil {
+			return nil, i18n.Errorf("move.invalid_rank", fromDigits, san)
+		}
+		fromRank = square.Rank
+	}
+
+	var patterns []sanPattern
+	for _, s := range splits {
+		to, err := domain.ParseSquare(s.to + toDigits)
+		if err != nil {
+			return nil, i18n.Errorf("move.error_in", err, san)
+		}
+		if !board.Contains(to.File, to.Rank) {
+			continue
+		}
+		fromFile := -1
+		if s.from != "" {
+			square, _ := domain.ParseSquare(s.from + "1")
+			fromFile = square.File
+		}
+		patterns = append(patterns, sanPattern{to: to, fromFile: fromFile, fromRank: fromRank})
+	}
+	if len(patterns) == 0 {
+		return nil, i18n.Errorf("move.square_off_board", toLetters+toDigits, san)
+	}
+	return patterns, nil
+}
+
+// cutPromotion отделяет от записи фигуру превращения: "e8=Q" или "e8Q"
+func cutPromotion(body, san string) (string, domain.PieceKind, error) {
+	letter := ""
+	if before, after, found := strings.Cut(body, "="); found {
+		body, letter = before, after
+		if len(letter) != 1 || !strings.Contains(promotionLetters, letter) {
+			return "", domain.NoPiece, i18n.Errorf("move.invalid_promotion", letter, san)
+		}
+	} else if last := body[len(body)-1:]; strings.Contains(promotionLetters, last) {
+		body, letter = body[:len(body)-1], last
+	}
+	if letter == "" {
+		return body, domain.NoPiece, nil
+	}
+	piece, _ := domain.PieceFromLetter(rune(letter[0]))
+	return body, piece.Kind, nil
+}
+
+// findCastling находит легальную рокировку в сторону короля или ферзя
+func findCastling(board *domain.Board, legal []domain.Move, kingside bool, san string) (domain.Move, error) {
+	for _, move := range legal {
+		piece := board.PieceAt(move.From.File, move.From.Rank)
+		if isCastling(piece, move) && (move.To.File > move.From.File) == kingside {
+			return move, nil
+		}
+	}
+	return domain.Move{}, i18n.Errorf("move.castling_impossible", san)
+}
+
+// chooseMove выбирает единственный ход из подходящих под запись
+func chooseMove(board *domain.Board, legal, matches []domain.Move, san string) (domain.Move, error) {
+	switch {
+	case len(matches) == 0:
+		return domain.Move{}, i18n.Errorf("error.with_value", domain.ErrIllegalMove, san)
+	case len(matches) == 1:
+		return matches[0], nil
+	}
+
+	// Несколько превращений одной пешки означают, что фигура превращения не указана
+	if matches[0].Promotion != domain.NoPiece {
+		samePawn := true
+		for _, move := range matches[1:] {
+			samePawn = samePawn && move.From == matches[0].From && move.To == matches[0].To
+		}
+		if samePawn {
+			return domain.Move{}, i18n.Errorf("move.promotion_missing", san)
+		}
+	}
+
+	options := make([]string, len(matches))
+	for i, move := range matches {
+		options[i] = formatSANBody(board, legal, move)
+	}
+	return domain.Move{}, i18n.Errorf("move.ambiguous", san, strings.Join(options, ", "))
+}
+
+// containsMove сообщает, есть ли ход среди moves
+func containsMove(moves []domain.Move, move domain.Move) bool {
+	for _, m := range moves {
+		if m == move {
+			return true
+		}
+	}
+	return false
+}
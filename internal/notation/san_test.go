@@ -0,0 +1,194 @@
+package notation
+
+import (
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/usecase"
+	"chessboard/internal/usecase/usecasetest"
+)
+
+// mustUCI разбирает ход в нотации UCI или прерывает тест
+func mustUCI(t *testing.T, text string) domain.Move {
+	t.Helper()
+
+	move, err := ParseUCI(text)
+	if err != nil {
+		t.Fatalf("неверный ход %q: %v", text, err)
+	}
+	return move
+}
+
+const (
+	knightsFEN   = "4k3/8/8/8/8/8/8/1N2KN2 w - - 0 1"
+	rooksFEN     = "4k3/8/8/R7/8/8/8/R3K3 w - - 0 1"
+	queensFEN    = "4k3/8/8/8/8/Q7/8/Q1Q1K3 w - - 0 1"
+	captureFEN   = "rnbqkbnr/ppp1pppp/8/3p4/4P3/8/PPPP1PPP/RNBQKBNR w KQkq d6 0 2"
+	enPassantFEN = "4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 2"
+	promotionFEN = "3r3k/4P3/8/8/8/8/8/K7 w - - 0 1"
+	castlingFEN  = "r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1"
+	foolsMateFEN = "rnbqkbnr/pppp1ppp/8/4p3/6P1/5P2/PPPPP2P/RNBQKBNR b KQkq g3 0 2"
+	largeFEN     = "4k5/10/10/10/10/10/10/10/10/R3K5 w - - 0 1"
+	// На доске шире 26 вертикалей после "z" идут вертикали "aa" и "ab"
+	wideFEN = "3k24/28/28/R26K w - - 0 1"
+)
+
+func TestFormatSAN(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fen      string
+		move     string
+		expected string
+	}{
+		{"ход пешкой", domain.StartFEN, "e2e4", "e4"},
+		{"ход конем", domain.StartFEN, "g1f3", "Nf3"},
+		{"уточнение вертикалью", knightsFEN, "b1d2", "Nbd2"},
+		{"уточнение другой вертикалью", knightsFEN, "f1d2", "Nfd2"},
+		{"уточнение горизонталью", rooksFEN, "a1a3", "R1a3"},
+		{"уточнение клеткой", queensFEN, "a1b2", "Qa1b2"},
+		{"взятие пешкой", captureFEN, "e4d5", "exd5"},
+		{"взятие на проходе", enPassantFEN, "e5d6", "exd6"},
+		{"превращение с шахом", promotionFEN, "e7e8q", "e8=Q+"},
+		{"превращение со взятием", promotionFEN, "e7d8n", "exd8=N"},
+		{"короткая рокировка", castlingFEN, "e1g1", "O-O"},
+		{"длинная рокировка", castlingFEN, "e1c1", "O-O-O"},
+		{"мат", foolsMateFEN, "d8h4", "Qh4#"},
+		{"доска 10x10", largeFEN, "a1a10", "Ra10+"},
+		{"двухбуквенная вертикаль", wideFEN, "a1aa1", "Raa1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			game := usecasetest.MustGame(t, usecase.NewGame, tc.fen)
+			before := game.Board().FEN()
+
+			actual, err := FormatSAN(game, mustUCI(t, tc.move))
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if actual != tc.expected {
+				t.Errorf("ожидалось %q, получено %q", tc.expected, actual)
+			}
+			if after := game.Board().FEN(); after != before {
+				t.Errorf("запись хода изменила позицию: %q", after)
+			}
+		})
+	}
+
+	t.Run("нелегальный ход", func(t *testing.T) {
+		_, err := FormatSAN(usecasetest.MustGame(t, usecase.NewGame, domain.StartFEN), mustUCI(t, "e2e5"))
+		if err == nil || !strings.Contains(err.Error(), "недопустимый ход: 'e2e5'") {
+			t.Errorf("ожидалась ошибка о недопустимом ходе, получено: %v", err)
+		}
+	})
+}
+
+func TestParseSAN(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fen      string
+		san      string
+		expected string
+	}{
+		{"ход пешкой", domain.StartFEN, "e4", "e2e4"},
+		{"ход конем", domain.StartFEN, "Nf3", "g1f3"},
+		{"лишнее уточнение", domain.StartFEN, "Ng1f3", "g1f3"},
+		{"оценка хода", domain.StartFEN, "e4!?", "e2e4"},
+		{"уточнение вертикалью", knightsFEN, "Nbd2", "b1d2"},
+		{"уточнение горизонталью", rooksFEN, "R5a3", "a5a3"},
+		{"уточнение клеткой", queensFEN, "Qa1b2", "a1b2"},
+		{"взятие пешкой", captureFEN, "exd5", "e4d5"},
+		{"взятие на проходе", enPassantFEN, "exd6", "e5d6"},
+		{"превращение", promotionFEN, "e8=Q", "e7e8q"},
+		{"превращение без знака равенства", promotionFEN, "e8R+", "e7e8r"},
+		{"превращение со взятием", promotionFEN, "exd8=N", "e7d8n"},
+		{"короткая рокировка", castlingFEN, "O-O", "e1g1"},
+		{"длинная рокировка нулями", castlingFEN, "0-0-0", "e1c1"},
+		{"мат", foolsMateFEN, "Qh4#", "d8h4"},
+		{"мат без знака", foolsMateFEN, "Qh4", "d8h4"},
+		{"доска 10x10", largeFEN, "Ra10", "a1a10"},
+		{"двухбуквенная вертикаль", wideFEN, "Raa1", "a1aa1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			move, err := ParseSAN(usecasetest.MustGame(t, usecase.NewGame, tc.fen), tc.san)
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if move.String() != tc.expected {
+				t.Errorf("ожидался ход %s, получено %s", tc.expected, move)
+			}
+		})
+	}
+}
+
+func TestParseSAN_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fen      string
+		san      string
+		errorMsg string
+	}{
+		{"пустая запись", domain.StartFEN, "  ", "пустая запись хода"},
+		{"только фигура", domain.StartFEN, "N", "неверная запись хода: 'N'"},
+		{"неизвестная фигура", domain.StartFEN, "Zf3", "неверная запись хода: 'Zf3'"},
+		{"клетка вне доски", domain.StartFEN, "e9", "клетка вне доски: 'e9' в ходе 'e9'"},
+		{"неверная клетка", domain.StartFEN, "e0", "неверное обозначение клетки: 'e0' в ходе 'e0'"},
+		{"нелегальный ход", domain.StartFEN, "e5", "недопустимый ход: 'e5'"},
+		{"неоднозначный ход", knightsFEN, "Nd2", "неоднозначный ход: 'Nd2' (подходят Nbd2, Nfd2)"},
+		{"не указано превращение", promotionFEN, "e8", "не указана фигура превращения: 'e8'"},
+		{"неверное превращение", promotionFEN, "e8=K", "неверная фигура превращения: 'K' в ходе 'e8=K'"},
+		{"превращение не пешки", knightsFEN, "Nd2=Q", "превращение возможно только для пешки: 'Nd2=Q'"},
+		{"рокировка без права", rooksFEN, "O-O-O", "рокировка невозможна: 'O-O-O'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseSAN(usecasetest.MustGame(t, usecase.NewGame, tc.fen), tc.san)
+			if err == nil || !strings.Contains(err.Error(), tc.errorMsg) {
+				t.Errorf("ожидалась ошибка с текстом '%s', получено: %v", tc.errorMsg, err)
+			}
+		})
+	}
+}
+
+// checkRoundTrip проверяет во всех узлах дерева глубины depth, что каждый
+// легальный ход после записи в SAN разбирается обратно в тот же ход
+func checkRoundTrip(t *testing.T, game domain.Game, depth int) {
+	t.Helper()
+
+	for _, move := range game.LegalMoves() {
+		san, err := FormatSAN(game, move)
+		if err != nil {
+			t.Fatalf("позиция %s, ход %s: %v", game.Board().FEN(), move, err)
+		}
+		parsed, err := ParseSAN(game, san)
+		if err != nil || parsed != move {
+			t.Fatalf("позиция %s: ход %s записан как %q и разобран как %s (%v)",
+				game.Board().FEN(), move, san, parsed, err)
+		}
+
+		if depth > 1 {
+			if err := game.Play(move); err != nil {
+				t.Fatalf("ход %s: %v", move, err)
+			}
+			checkRoundTrip(t, game, depth-1)
+			game.Undo()
+		}
+	}
+}
+
+func TestSAN_RoundTrip(t *testing.T) {
+	depth := 2
+	if testing.Short() {
+		depth = 1
+	}
+
+	for _, tc := range usecase.PerftSuite {
+		t.Run(tc.Name, func(t *testing.T) {
+			checkRoundTrip(t, usecasetest.MustGame(t, usecase.NewGame, tc.FEN), depth)
+		})
+	}
+}
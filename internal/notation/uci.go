@@ -0,0 +1,58 @@
+package notation
+
+import (
+	"regexp"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+// uciMove - ход в нотации UCI: исходная клетка, клетка назначения
+// и строчная буква фигуры превращения
+var uciMove = regexp.MustCompile(`^([a-z]+[0-9]+)([a-z]+[0-9]+)([qrbn]?)$`)
+
+// FormatUCI записывает ход в длинной нотации UCI: "e2e4", "e7e8q"
+func FormatUCI(move domain.Move) string {
+	return move.String()
+}
+
+// ParseUCI разбирает ход в длинной нотации UCI. Проверяется только запись хода,
+// а не его легальность: для нее ход нужно найти среди легальных ходов партии.
+func ParseUCI(text string) (domain.Move, error) {
+	parts := uciMove.FindStringSubmatch(text)
+	if parts == nil {
+		return domain.Move{}, i18n.Errorf("move.invalid_uci", text)
+	}
+
+	from, err := domain.ParseSquare(parts[1])
+	if err != nil {
+		return domain.Move{}, i18n.Errorf("move.error_in", err, text)
+	}
+	to, err := domain.ParseSquare(parts[2])
+	if err != nil {
+		return domain.Move{}, i18n.Errorf("move.error_in", err, text)
+	}
+
+	move := domain.Move{From: from, To: to}
+	if parts[3] != "" {
+		piece, _ := domain.PieceFromLetter(rune(parts[3][0]))
+		move.Promotion = piece.Kind
+	}
+	return move, nil
+}
+
+// ParseMove разбирает ход в нотации UCI или SAN и проверяет, что он легален
+// в текущей позиции партии
+func ParseMove(game domain.Game, text string) (domain.Move, error) {
+	if uciMove.MatchString(text) {
+		move, err := ParseUCI(text)
+		if err != nil {
+			return domain.Move{}, err
+		}
+		if !containsMove(game.LegalMoves(), move) {
+			return domain.Move{}, i18n.Errorf("error.with_value", domain.ErrIllegalMove, text)
+		}
+		return move, nil
+	}
+	return ParseSAN(game, text)
+}
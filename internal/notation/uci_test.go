@@ -0,0 +1,92 @@
+package notation
+
+import (
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/usecase"
+	"chessboard/internal/usecase/usecasetest"
+)
+
+func TestParseUCI(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected domain.Move
+	}{
+		{"e2e4", domain.Move{From: domain.Square{File: 4, Rank: 1}, To: domain.Square{File: 4, Rank: 3}}},
+		{"e7e8q", domain.Move{From: domain.Square{File: 4, Rank: 6}, To: domain.Square{File: 4, Rank: 7}, Promotion: domain.Queen}},
+		{"b2a1n", domain.Move{From: domain.Square{File: 1, Rank: 1}, To: domain.Square{File: 0, Rank: 0}, Promotion: domain.Knight}},
+		{"a10a9", domain.Move{From: domain.Square{File: 0, Rank: 9}, To: domain.Square{File: 0, Rank: 8}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			move, err := ParseUCI(tc.input)
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if move != tc.expected {
+				t.Errorf("ожидалось %+v, получено %+v", tc.expected, move)
+			}
+			if formatted := FormatUCI(move); formatted != tc.input {
+				t.Errorf("ожидалась запись %q, получено %q", tc.input, formatted)
+			}
+		})
+	}
+}
+
+func TestParseUCI_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		errorMsg string
+	}{
+		{"пустая строка", "", "неверная запись хода UCI: ''"},
+		{"нет клетки назначения", "e2", "неверная запись хода UCI: 'e2'"},
+		{"заглавные буквы", "E2E4", "неверная запись хода UCI: 'E2E4'"},
+		{"превращение в короля", "e7e8k", "неверная запись хода UCI: 'e7e8k'"},
+		{"горизонталь с нуля", "e0e4", "неверное обозначение клетки: 'e0' в ходе 'e0e4'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseUCI(tc.input)
+			if err == nil || !strings.Contains(err.Error(), tc.errorMsg) {
+				t.Errorf("ожидалась ошибка с текстом '%s', получено: %v", tc.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestParseMove(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+		errorMsg string
+	}{
+		{name: "запись UCI", input: "g1f3", expected: "g1f3"},
+		{name: "запись SAN", input: "Nf3", expected: "g1f3"},
+		{name: "нелегальный ход UCI", input: "e2e5", errorMsg: "недопустимый ход: 'e2e5'"},
+		{name: "нелегальный ход SAN", input: "Nf4", errorMsg: "недопустимый ход: 'Nf4'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			move, err := ParseMove(usecasetest.MustGame(t, usecase.NewGame, domain.StartFEN), tc.input)
+			if tc.errorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.errorMsg) {
+					t.Errorf("ожидалась ошибка с текстом '%s', получено: %v", tc.errorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if move.String() != tc.expected {
+				t.Errorf("ожидался ход %s, получено %s", tc.expected, move)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Package pgn читает и записывает партии в формате PGN (Portable Game Notation):
+// теги, ходы в стандартной алгебраической нотации, комментарии, числовые
+// оценки (NAG) и вложенные варианты.
+package pgn
+
+import (
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+	"chessboard/internal/notation"
+)
+
+// sevenTagRoster - обязательные теги PGN в порядке их записи
+var sevenTagRoster = []string{"Event", "Site", "Date", "Round", "White", "Black", "Result"}
+
+// Результаты партии, завершающие запись ходов
+const (
+	ResultWhiteWins = "1-0"
+	ResultBlackWins = "0-1"
+	ResultDraw      = "1/2-1/2"
+	ResultUnknown   = "*"
+)
+
+// Tag - пара тега PGN: [Name "Value"]
+type Tag struct {
+	Name  string
+	Value string
+}
+
+// Move - ход в записи SAN с оценками, комментарием и вариантами, которые
+// можно сыграть вместо него
+type Move struct {
+	SAN string
+	// NAGs - числовые оценки хода ($1 - "!", $2 - "?" и т.д.)
+	NAGs []int
+	// Comment - комментарий после хода
+	Comment string
+	// Variations - продолжения, начинающиеся с позиции перед этим ходом
+	Variations []Variation
+}
+
+// Variation - последовательность ходов: основной вариант партии или вариант внутри скобок
+type Variation struct {
+	// Comment - комментарий перед первым ходом
+	Comment string
+	Moves   []Move
+}
+
+// Game - партия PGN: теги в порядке чтения, основной вариант и результат
+type Game struct {
+	Tags     []Tag
+	MainLine Variation
+	Result   string
+}
+
+// Tag возвращает значение тега или пустую строку, если тега нет
+func (g *Game) Tag(name string) string {
+	for _, tag := range g.Tags {
+		if tag.Name == name {
+			return tag.Value
+		}
+	}
+	return ""
+}
+
+// SetTag задает значение тега, добавляя тег, если его еще нет
+func (g *Game) SetTag(name, value string) {
+	for i := range g.Tags {
+		if g.Tags[i].Name == name {
+			g.Tags[i].Value = value
+			return
+		}
+	}
+	g.Tags = append(g.Tags, Tag{Name: name, Value: value})
+}
+
+// StartBoard возвращает начальную позицию партии: из тега FEN или стандартную
+func (g *Game) StartBoard() (*domain.Board, error) {
+	fen := g.Tag("FEN")
+	if fen == "" {
+		fen = domain.StartFEN
+	}
+	return domain.ParseFEN(fen)
+}
+
+// Plies возвращает число полуходов основного варианта
+func (g *Game) Plies() int {
+	return len(g.MainLine.Moves)
+}
+
+// Play делает в партии game, начатой с позиции StartBoard, первые plies полуходов
+// основного варианта
+func (g *Game) Play(game domain.Game, plies int) error {
+	if plies < 0 || plies > g.Plies() {
+		return i18n.PluralErrorf("pgn.ply_out_of_range", g.Plies(), g.Plies(), plies)
+	}
+	for i, played := range g.MainLine.Moves[:plies] {
+		move, err := notation.ParseSAN(game, played.SAN)
+		if err != nil {
+			return i18n.Errorf("pgn.ply_error", i+1, err)
+		}
+		if err := game.Play(move); err != nil {
+			return i18n.Errorf("pgn.ply_error", i+1, err)
+		}
+	}
+	return nil
+}
+
+// FromMoves записывает ходы, сыгранные из начальной позиции партии game, как
+// основной вариант партии PGN. Ходы делаются в game.
+func FromMoves(game domain.Game, moves []domain.Move, tags []Tag) (*Game, error) {
+	result := &Game{Tags: append([]Tag(nil), tags...), Result: ResultUnknown}
+	start := game.Board()
+	if start.FEN() != domain.StartFEN {
+		result.SetTag("SetUp", "1")
+		result.SetTag("FEN", start.FEN())
+	}
+
+	for i, move := range moves {
+		san, err := notation.FormatSAN(game, move)
+		if err != nil {
+			return nil, i18n.Errorf("pgn.ply_error", i+1, err)
+		}
+		if err := game.Play(move); err != nil {
+			return nil, i18n.Errorf("pgn.ply_error", i+1, err)
+		}
+		result.MainLine.Moves = append(result.MainLine.Moves, Move{SAN: san})
+	}
+
+	// Запись исхода в domain.GameResult совпадает с записью результата в PGN
+	result.Result = game.Status().Result.String()
+	result.SetTag("Result", result.Result)
+	return result, nil
+}
+
+// Error - ошибка разбора PGN с номером партии в файле и строки
+type Error struct {
+	// Game - номер партии в потоке, начиная с 1
+	Game int
+	// Line - номер строки, начиная с 1
+	Line   int
+	Reason error
+}
+
+func (e *Error) Error() string {
+	return e.Localize(i18n.DefaultPrinter())
+}
+
+func (e *Error) Localize(p *i18n.Printer) string {
+	return p.Sprintf("pgn.error", e.Game, e.Line, e.Reason)
+}
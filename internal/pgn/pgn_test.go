@@ -0,0 +1,188 @@
+package pgn
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/notation"
+	"chessboard/internal/usecase"
+	"chessboard/internal/usecase/usecasetest"
+)
+
+// mustMoves разбирает ходы UCI, разделенные пробелами, или прерывает тест
+func mustMoves(t *testing.T, text string) []domain.Move {
+	t.Helper()
+
+	var moves []domain.Move
+	for _, field := range strings.Fields(text) {
+		move, err := notation.ParseUCI(field)
+		if err != nil {
+			t.Fatalf("неверный ход %q: %v", field, err)
+		}
+		moves = append(moves, move)
+	}
+	return moves
+}
+
+// sanLine возвращает ходы варианта через пробел
+func sanLine(v Variation) string {
+	sans := make([]string, len(v.Moves))
+	for i, move := range v.Moves {
+		sans[i] = move.SAN
+	}
+	return strings.Join(sans, " ")
+}
+
+func TestGame_Tags(t *testing.T) {
+	game := &Game{}
+	if got := game.Tag("White"); got != "" {
+		t.Errorf("ожидался пустой тег, получено %q", got)
+	}
+
+	game.SetTag("White", "Морфи")
+	game.SetTag("Black", "Герцог")
+	game.SetTag("White", "Пол Морфи")
+
+	if got := game.Tag("White"); got != "Пол Морфи" {
+		t.Errorf("ожидалось %q, получено %q", "Пол Морфи", got)
+	}
+	if len(game.Tags) != 2 || game.Tags[0].Name != "White" {
+		t.Errorf("теги должны сохранять порядок добавления: %v", game.Tags)
+	}
+}
+
+func TestGame_StartBoard(t *testing.T) {
+	game := &Game{}
+	board, err := game.StartBoard()
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if board.FEN() != domain.StartFEN {
+		t.Errorf("без тега FEN ожидалась начальная позиция, получено %q", board.FEN())
+	}
+
+	fen := "4k3/8/8/8/8/8/8/4K2R w K - 0 1"
+	game.SetTag("FEN", fen)
+	if board, err = game.StartBoard(); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if board.FEN() != fen {
+		t.Errorf("ожидалась позиция %q, получено %q", fen, board.FEN())
+	}
+
+	game.SetTag("FEN", "неверный FEN")
+	if _, err := game.StartBoard(); err == nil {
+		t.Error("ожидалась ошибка для неверного тега FEN")
+	}
+}
+
+func TestGame_Play(t *testing.T) {
+	pgnGame := &Game{MainLine: Variation{Moves: []Move{{SAN: "e4"}, {SAN: "e5"}, {SAN: "Nf3"}}}}
+
+	testCases := []struct {
+		name     string
+		plies    int
+		expected string
+	}{
+		{"начальная позиция", 0, domain.StartFEN},
+		{"после хода белых", 1, "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1"},
+		{"вся партия", 3, "rnbqkbnr/pppp1ppp/8/4p3/4P3/5N2/PPPP1PPP/RNBQKB1R b KQkq - 1 2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			game := usecasetest.MustGame(t, usecase.NewGame, domain.StartFEN)
+			if err := pgnGame.Play(game, tc.plies); err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if got := game.Board().FEN(); got != tc.expected {
+				t.Errorf("ожидалась позиция %q, получено %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGame_Play_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		moves    []Move
+		plies    int
+		expected string
+	}{
+		{"полуход за концом партии", []Move{{SAN: "e4"}}, 2, "в партии 1 полуход, запрошен полуход 2"},
+		{"отрицательный полуход", []Move{{SAN: "e4"}}, -1, "запрошен полуход -1"},
+		{"недопустимый ход", []Move{{SAN: "e4"}, {SAN: "e4"}}, 2, "полуход 2: недопустимый ход: 'e4'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pgnGame := &Game{MainLine: Variation{Moves: tc.moves}}
+			err := pgnGame.Play(usecasetest.MustGame(t, usecase.NewGame, domain.StartFEN), tc.plies)
+			if err == nil {
+				t.Fatal("ожидалась ошибка")
+			}
+			if !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("ожидалась ошибка с %q, получено %q", tc.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestFromMoves(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fen      string
+		moves    string
+		line     string
+		result   string
+		fenTag   bool
+		expected string
+	}{
+		{"незаконченная партия", domain.StartFEN, "e2e4 e7e5 g1f3", "e4 e5 Nf3", ResultUnknown, false, ""},
+		{"детский мат", domain.StartFEN, "f2f3 e7e5 g2g4 d8h4", "f3 e5 g4 Qh4#", ResultBlackWins, false, ""},
+		{"пат", "7k/8/6Q1/8/8/8/8/K7 w - - 0 1", "g6f7", "Qf7", ResultDraw, true, "7k/8/6Q1/8/8/8/8/K7 w - - 0 1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tags := []Tag{{Name: "White", Value: "Белые"}}
+			got, err := FromMoves(usecasetest.MustGame(t, usecase.NewGame, tc.fen), mustMoves(t, tc.moves), tags)
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+
+			if line := sanLine(got.MainLine); line != tc.line {
+				t.Errorf("ожидались ходы %q, получено %q", tc.line, line)
+			}
+			if got.Result != tc.result || got.Tag("Result") != tc.result {
+				t.Errorf("ожидался результат %q, получено %q и тег %q", tc.result, got.Result, got.Tag("Result"))
+			}
+			if got.Tag("White") != "Белые" {
+				t.Errorf("переданные теги должны сохраниться: %v", got.Tags)
+			}
+			if (got.Tag("SetUp") == "1") != tc.fenTag || got.Tag("FEN") != tc.expected {
+				t.Errorf("неверные теги начальной позиции: %v", got.Tags)
+			}
+		})
+	}
+}
+
+func TestFromMoves_IllegalMove(t *testing.T) {
+	_, err := FromMoves(usecasetest.MustGame(t, usecase.NewGame, domain.StartFEN), mustMoves(t, "e2e4 e2e4"), nil)
+	if err == nil {
+		t.Fatal("ожидалась ошибка")
+	}
+	if !strings.HasPrefix(err.Error(), "полуход 2: ") {
+		t.Errorf("ошибка должна указывать полуход, получено %q", err.Error())
+	}
+}
+
+func TestError(t *testing.T) {
+	err := &Error{Game: 2, Line: 15, Reason: errors.New("вариант не закрыт")}
+	expected := "неверный PGN: партия 2, строка 15: вариант не закрыт"
+	if err.Error() != expected {
+		t.Errorf("ожидалось %q, получено %q", expected, err.Error())
+	}
+}
@@ -0,0 +1,419 @@
+package pgn
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"chessboard/internal/i18n"
+)
+
+// tokenKind - вид лексемы PGN
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	// tokenSymbol - ход, номер хода, результат или имя тега
+	tokenSymbol
+	tokenString
+	tokenPeriod
+	tokenNAG
+	// tokenSuffix - оценка хода знаками: "!", "?", "!?" и т.д.
+	tokenSuffix
+	tokenComment
+	tokenOpenBracket
+	tokenCloseBracket
+	tokenOpenParen
+	tokenCloseParen
+)
+
+// token - лексема с номером строки, на которой она начинается
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+// MaxVariationDepth ограничивает вложенность вариантов: варианты читаются
+// рекурсивно, и без предела файл из одних открывающих скобок исчерпал бы стек
+const MaxVariationDepth = 100
+
+// suffixNAGs - числовые оценки, соответствующие оценкам знаками
+var suffixNAGs = map[string]int{"!": 1, "?": 2, "!!": 3, "??": 4, "!?": 5, "?!": 6}
+
+// Reader последовательно читает партии из потока PGN, не загружая весь
+// поток в память
+type Reader struct {
+	r *bufio.Reader
+	// line - номер текущей строки; lineStart - читается ли начало строки
+	line      int
+	lineStart bool
+	// games - число начатых партий, для сообщений об ошибках
+	games int
+	// peeked - лексема, прочитанная заранее и еще не разобранная
+	peeked *token
+}
+
+// NewReader создает читателя партий из r
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r), line: 1, lineStart: true}
+}
+
+// Next читает следующую партию. В конце потока возвращается io.EOF.
+func (r *Reader) Next() (*Game, error) {
+	tok, err := r.next()
+	if err != nil {
+		return nil, err
+	}
+	if tok.kind == tokenEOF {
+		return nil, io.EOF
+	}
+
+	r.games++
+	game := &Game{}
+	for tok.kind == tokenOpenBracket {
+		tag, err := r.readTag()
+		if err != nil {
+			return nil, err
+		}
+		game.Tags = append(game.Tags, tag)
+		if tok, err = r.next(); err != nil {
+			return nil, err
+		}
+	}
+	r.peeked = &tok
+
+	result, err := r.readVariation(&game.MainLine, 0)
+	if err != nil {
+		return nil, err
+	}
+	game.Result = result
+	if game.Result == "" {
+		// Запись ходов оборвалась без результата - берем его из тегов
+		game.Result = game.Tag("Result")
+	}
+	if game.Result == "" {
+		game.Result = ResultUnknown
+	}
+	return game, nil
+}
+
+// readTag читает пару тега после открывающей скобки
+func (r *Reader) readTag() (Tag, error) {
+	name, err := r.expect(tokenSymbol, "pgn.tag_name_expected")
+	if err != nil {
+		return Tag{}, err
+	}
+	value, err := r.expect(tokenString, "pgn.tag_value_expected", name.text)
+	if err != nil {
+		return Tag{}, err
+	}
+	if _, err := r.expect(tokenCloseBracket, "pgn.tag_bracket_expected", name.text); err != nil {
+		return Tag{}, err
+	}
+	return Tag{Name: name.text, Value: value.text}, nil
+}
+
+// expect читает лексему заданного вида или возвращает ошибку с текстом reason
+func (r *Reader) expect(kind tokenKind, reason i18n.Key, args ...any) (token, error) {
+	tok, err := r.next()
+	if err != nil {
+		return tok, err
+	}
+	if tok.kind != kind {
+		return tok, r.errorf(tok.line, reason, args...)
+	}
+	return tok, nil
+}
+
+// readVariation читает ходы варианта до закрывающей скобки, а на глубине 0 -
+// до результата партии, конца потока или тегов следующей партии. Возвращает
+// результат партии, если он записан.
+func (r *Reader) readVariation(v *Variation, depth int) (string, error) {
+	for {
+		tok, err := r.next()
+		if err != nil {
+			return "", err
+		}
+
+		switch tok.kind {
+		case tokenEOF:
+			if depth > 0 {
+				return "", r.errorf(tok.line, "pgn.variation_not_closed")
+			}
+			return "", nil
+		case tokenOpenBracket:
+			if depth > 0 {
+				return "", r.errorf(tok.line, "pgn.tag_in_variation")
+			}
+			r.peeked = &tok
+			return "", nil
+		case tokenCloseParen:
+			if depth == 0 {
+				return "", r.errorf(tok.line, "pgn.extra_paren")
+			}
+			return "", nil
+		case tokenPeriod:
+		case tokenSymbol:
+			switch {
+			case isResult(tok.text):
+				if depth > 0 {
+					return "", r.errorf(tok.line, "pgn.result_in_variation", tok.text)
+				}
+				return tok.text, nil
+			case isMoveNumber(tok.text):
+			case unicode.IsLetter(rune(tok.text[0])) || strings.HasPrefix(tok.text, "0-0"):
+				v.Moves = append(v.Moves, Move{SAN: tok.text})
+			default:
+				return "", r.errorf(tok.line, "pgn.invalid_move", tok.text)
+			}
+		case tokenComment:
+			if len(v.Moves) == 0 {
+				v.Comment = joinComment(v.Comment, tok.text)
+			} else {
+				last := &v.Moves[len(v.Moves)-1]
+				last.Comment = joinComment(last.Comment, tok.text)
+			}
+		case tokenNAG, tokenSuffix:
+			if len(v.Moves) == 0 {
+				return "", r.errorf(tok.line, "pgn.nag_before_move", tok.text)
+			}
+			nag, ok := suffixNAGs[tok.text]
+			if tok.kind == tokenNAG {
+				nag, ok = parseNAG(tok.text)
+			}
+			if !ok {
+				return "", r.errorf(tok.line, "pgn.invalid_nag", tok.text)
+			}
+			last := &v.Moves[len(v.Moves)-1]
+			last.NAGs = append(last.NAGs, nag)
+		case tokenOpenParen:
+			if len(v.Moves) == 0 {
+				return "", r.errorf(tok.line, "pgn.variation_before_move")
+			}
+			if depth >= MaxVariationDepth {
+				return "", r.errorf(tok.line, "pgn.variation_too_deep", MaxVariationDepth)
+			}
+			var sub Variation
+			if _, err := r.readVariation(&sub, depth+1); err != nil {
+				return "", err
+			}
+			last := &v.Moves[len(v.Moves)-1]
+			last.Variations = append(last.Variations, sub)
+		default:
+			return "", r.errorf(tok.line, "pgn.unexpected_token", tok.text)
+		}
+	}
+}
+
+// next читает следующую лексему, пропуская пробелы и строки, начинающиеся с '%'
+func (r *Reader) next() (token, error) {
+	if r.peeked != nil {
+		tok := *r.peeked
+		r.peeked = nil
+		return tok, nil
+	}
+
+	for {
+		lineStart := r.lineStart
+		c, err := r.readRune()
+		if err == io.EOF {
+			return token{kind: tokenEOF, line: r.line}, nil
+		}
+		if err != nil {
+			return token{}, err
+		}
+		line := r.line
+
+		switch {
+		case c == '\n' || unicode.IsSpace(c):
+		case c == '%' && lineStart:
+			if _, err := r.readLine(); err != nil {
+				return token{}, err
+			}
+		case c == ';':
+			text, err := r.readLine()
+			if err != nil {
+				return token{}, err
+			}
+			return token{kind: tokenComment, text: strings.TrimSpace(text), line: line}, nil
+		case c == '{':
+			text, err := r.readUntil('}')
+			if err != nil {
+				return token{}, err
+			}
+			return token{kind: tokenComment, text: strings.Join(strings.Fields(text), " "), line: line}, nil
+		case c == '"':
+			return r.readString(line)
+		case c == '$':
+			digits, err := r.readWhile(unicode.IsDigit)
+			if err != nil {
+				return token{}, err
+			}
+			return token{kind: tokenNAG, text: "$" + digits, line: line}, nil
+		case c == '!' || c == '?':
+			rest, err := r.readWhile(func(c rune) bool { return c == '!' || c == '?' })
+			if err != nil {
+				return token{}, err
+			}
+			return token{kind: tokenSuffix, text: string(c) + rest, line: line}, nil
+		case c == '*':
+			return token{kind: tokenSymbol, text: ResultUnknown, line: line}, nil
+		case c == '.':
+			return token{kind: tokenPeriod, text: ".", line: line}, nil
+		case c == '[':
+			return token{kind: tokenOpenBracket, text: "[", line: line}, nil
+		case c == ']':
+			return token{kind: tokenCloseBracket, text: "]", line: line}, nil
+		case c == '(':
+			return token{kind: tokenOpenParen, text: "(", line: line}, nil
+		case c == ')':
+			return token{kind: tokenCloseParen, text: ")", line: line}, nil
+		case c < unicode.MaxASCII && (unicode.IsLetter(c) || unicode.IsDigit(c)):
+			rest, err := r.readWhile(isSymbolRune)
+			if err != nil {
+				return token{}, err
+			}
+			return token{kind: tokenSymbol, text: string(c) + rest, line: line}, nil
+		default:
+			return token{}, r.errorf(line, "pgn.unexpected_char", c)
+		}
+	}
+}
+
+// readRune читает символ, отслеживая номер строки
+func (r *Reader) readRune() (rune, error) {
+	c, _, err := r.r.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	r.lineStart = c == '\n'
+	if c == '\n' {
+		r.line++
+	}
+	return c, nil
+}
+
+// unreadRune возвращает последний прочитанный символ, кроме перевода строки
+func (r *Reader) unreadRune() {
+	_ = r.r.UnreadRune()
+	r.lineStart = false
+}
+
+// readWhile читает символы, пока для них выполняется условие
+func (r *Reader) readWhile(accept func(rune) bool) (string, error) {
+	var text strings.Builder
+	for {
+		c, err := r.readRune()
+		if err == io.EOF {
+			return text.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if c == '\n' || !accept(c) {
+			if c == '\n' {
+				r.line--
+			}
+			r.unreadRune()
+			r.lineStart = false
+			return text.String(), nil
+		}
+		text.WriteRune(c)
+	}
+}
+
+// readLine читает остаток строки без перевода строки
+func (r *Reader) readLine() (string, error) {
+	return r.readWhile(func(rune) bool { return true })
+}
+
+// readUntil читает текст до закрывающего символа end, который не входит в текст
+func (r *Reader) readUntil(end rune) (string, error) {
+	line := r.line
+	var text strings.Builder
+	for {
+		c, err := r.readRune()
+		if err == io.EOF {
+			return "", r.errorf(line, "pgn.comment_not_closed")
+		}
+		if err != nil {
+			return "", err
+		}
+		if c == end {
+			return text.String(), nil
+		}
+		text.WriteRune(c)
+	}
+}
+
+// readString читает строку в кавычках с экранированием \" и \\
+func (r *Reader) readString(line int) (token, error) {
+	var text strings.Builder
+	for {
+		c, err := r.readRune()
+		if err == io.EOF || c == '\n' {
+			return token{}, r.errorf(line, "pgn.string_not_closed")
+		}
+		if err != nil {
+			return token{}, err
+		}
+		switch c {
+		case '"':
+			return token{kind: tokenString, text: text.String(), line: line}, nil
+		case '\\':
+			if c, err = r.readRune(); err != nil || c == '\n' {
+				return token{}, r.errorf(line, "pgn.string_not_closed")
+			}
+		}
+		text.WriteRune(c)
+	}
+}
+
+// errorf создает ошибку разбора для текущей партии
+func (r *Reader) errorf(line int, reason i18n.Key, args ...any) error {
+	return &Error{Game: max(r.games, 1), Line: line, Reason: i18n.Errorf(reason, args...)}
+}
+
+// isSymbolRune сообщает, может ли символ продолжать ход, номер или имя тега
+func isSymbolRune(c rune) bool {
+	return c < unicode.MaxASCII && (unicode.IsLetter(c) || unicode.IsDigit(c) || strings.ContainsRune("_+#=:-/", c))
+}
+
+// isResult сообщает, что лексема - результат партии
+func isResult(text string) bool {
+	switch text {
+	case ResultWhiteWins, ResultBlackWins, ResultDraw, ResultUnknown:
+		return true
+	}
+	return false
+}
+
+// isMoveNumber сообщает, что лексема - номер хода
+func isMoveNumber(text string) bool {
+	for _, c := range text {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseNAG разбирает числовую оценку "$n"
+func parseNAG(text string) (int, bool) {
+	nag, err := strconv.Atoi(strings.TrimPrefix(text, "$"))
+	return nag, err == nil && nag >= 0 && nag <= 255
+}
+
+// joinComment добавляет к комментарию следующий через пробел
+func joinComment(comment, next string) string {
+	if comment == "" {
+		return next
+	}
+	if next == "" {
+		return comment
+	}
+	return comment + " " + next
+}
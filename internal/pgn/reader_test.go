@@ -0,0 +1,302 @@
+package pgn
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readAll читает все партии из текста PGN или прерывает тест
+func readAll(t *testing.T, text string) []*Game {
+	t.Helper()
+
+	reader := NewReader(strings.NewReader(text))
+	var games []*Game
+	for {
+		game, err := reader.Next()
+		if err == io.EOF {
+			return games
+		}
+		if err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		games = append(games, game)
+	}
+}
+
+// readOne читает единственную партию из текста PGN или прерывает тест
+func readOne(t *testing.T, text string) *Game {
+	t.Helper()
+
+	games := readAll(t, text)
+	if len(games) != 1 {
+		t.Fatalf("ожидалась одна партия, прочитано %d", len(games))
+	}
+	return games[0]
+}
+
+const operaGame = `[Event "Opera Game"]
+[Site "Paris FRA"]
+[Date "1858.??.??"]
+[Round "?"]
+[White "Paul Morphy"]
+[Black "Duke Karl / Count Isouard"]
+[Result "1-0"]
+
+1. e4 e5 2. Nf3 d6 3. d4 Bg4 4. dxe5 Bxf3 5. Qxf3 dxe5 6. Bc4 Nf6 7. Qb3 Qe7
+8. Nc3 c6 9. Bg5 b5 10. Nxb5 cxb5 11. Bxb5+ Nbd7 12. O-O-O Rd8 13. Rxd7 Rxd7
+14. Rd1 Qe6 15. Bxd7+ Nxd7 16. Qb8+ Nxb8 17. Rd8# 1-0
+`
+
+func TestReader_Tags(t *testing.T) {
+	game := readOne(t, operaGame)
+
+	expected := []Tag{
+		{"Event", "Opera Game"},
+		{"Site", "Paris FRA"},
+		{"Date", "1858.??.??"},
+		{"Round", "?"},
+		{"White", "Paul Morphy"},
+		{"Black", "Duke Karl / Count Isouard"},
+		{"Result", "1-0"},
+	}
+	if !reflect.DeepEqual(game.Tags, expected) {
+		t.Errorf("ожидались теги %v, получено %v", expected, game.Tags)
+	}
+	if game.Plies() != 33 {
+		t.Errorf("ожидалось 33 полухода, получено %d", game.Plies())
+	}
+	if game.Result != ResultWhiteWins {
+		t.Errorf("ожидался результат %q, получено %q", ResultWhiteWins, game.Result)
+	}
+	if last := game.MainLine.Moves[32].SAN; last != "Rd8#" {
+		t.Errorf("ожидался последний ход %q, получено %q", "Rd8#", last)
+	}
+}
+
+func TestReader_TagEscapes(t *testing.T) {
+	game := readOne(t, `[Event "Турнир \"Мемориал\" \\ финал"] *`)
+
+	expected := `Турнир "Мемориал" \ финал`
+	if got := game.Tag("Event"); got != expected {
+		t.Errorf("ожидалось %q, получено %q", expected, got)
+	}
+}
+
+func TestReader_MoveText(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+		result   string
+	}{
+		{"номера без пробелов", "1.e4 e5 2.Nf3 *", "e4 e5 Nf3", ResultUnknown},
+		{"многоточие у хода черных", "1. e4 1... e5 2. Nf3 Nc6 1/2-1/2", "e4 e5 Nf3 Nc6", ResultDraw},
+		{"рокировка нулями", "1. 0-0 O-O-O 0-1", "0-0 O-O-O", ResultBlackWins},
+		{"превращение", "1. e8=Q+ Kxe8 *", "e8=Q+ Kxe8", ResultUnknown},
+		{"без результата", "1. e4 e5", "e4 e5", ResultUnknown},
+		{"без ходов", "*", "", ResultUnknown},
+		{"строка-директива", "% служебная строка\n1. d4 *", "d4", ResultUnknown},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			game := readOne(t, tc.text)
+			if got := sanLine(game.MainLine); got != tc.expected {
+				t.Errorf("ожидались ходы %q, получено %q", tc.expected, got)
+			}
+			if game.Result != tc.result {
+				t.Errorf("ожидался результат %q, получено %q", tc.result, game.Result)
+			}
+		})
+	}
+}
+
+func TestReader_ResultFromTag(t *testing.T) {
+	game := readOne(t, "[Result \"1-0\"]\n\n1. e4 e5")
+	if game.Result != ResultWhiteWins {
+		t.Errorf("без результата в записи ходов ожидался результат из тега, получено %q", game.Result)
+	}
+}
+
+func TestReader_Annotations(t *testing.T) {
+	game := readOne(t, `{Начало партии} 1. e4! {Лучший ход} e5?! $14 ; до конца строки
+2. Nf3 $1 {первый} {второй} *`)
+
+	if game.MainLine.Comment != "Начало партии" {
+		t.Errorf("ожидался комментарий варианта, получено %q", game.MainLine.Comment)
+	}
+
+	expected := []Move{
+		{SAN: "e4", NAGs: []int{1}, Comment: "Лучший ход"},
+		{SAN: "e5", NAGs: []int{6, 14}, Comment: "до конца строки"},
+		{SAN: "Nf3", NAGs: []int{1}, Comment: "первый второй"},
+	}
+	if !reflect.DeepEqual(game.MainLine.Moves, expected) {
+		t.Errorf("ожидались ходы %+v, получено %+v", expected, game.MainLine.Moves)
+	}
+}
+
+func TestReader_MultilineComment(t *testing.T) {
+	game := readOne(t, "1. e4 {комментарий\n  на двух строках} e5 *")
+
+	expected := "комментарий на двух строках"
+	if got := game.MainLine.Moves[0].Comment; got != expected {
+		t.Errorf("ожидалось %q, получено %q", expected, got)
+	}
+}
+
+func TestReader_NestedVariations(t *testing.T) {
+	game := readOne(t, `1. e4 e5 (1... c5 {Сицилианская} 2. Nf3 (2. Nc3 Nc6) 2... d6) (1... e6) 2. Nf3 *`)
+
+	if got := sanLine(game.MainLine); got != "e4 e5 Nf3" {
+		t.Fatalf("неверный основной вариант: %q", got)
+	}
+
+	variations := game.MainLine.Moves[1].Variations
+	if len(variations) != 2 {
+		t.Fatalf("ожидалось 2 варианта, получено %d", len(variations))
+	}
+	if got := sanLine(variations[0]); got != "c5 Nf3 d6" {
+		t.Errorf("неверный первый вариант: %q", got)
+	}
+	if got := sanLine(variations[1]); got != "e6" {
+		t.Errorf("неверный второй вариант: %q", got)
+	}
+	if got := variations[0].Moves[0].Comment; got != "Сицилианская" {
+		t.Errorf("неверный комментарий в варианте: %q", got)
+	}
+
+	nested := variations[0].Moves[1].Variations
+	if len(nested) != 1 || sanLine(nested[0]) != "Nc3 Nc6" {
+		t.Errorf("неверный вложенный вариант: %+v", nested)
+	}
+}
+
+func TestReader_MaxVariationDepth(t *testing.T) {
+	text := "1. e4 " + strings.Repeat("(1. d4 ", MaxVariationDepth) + strings.Repeat(") ", MaxVariationDepth) + "*"
+	game, err := NewReader(strings.NewReader(text)).Next()
+	if err != nil {
+		t.Fatalf("варианты наибольшей глубины должны читаться: %v", err)
+	}
+	depth := 0
+	for v := game.MainLine; len(v.Moves) > 0 && len(v.Moves[0].Variations) > 0; v = v.Moves[0].Variations[0] {
+		depth++
+	}
+	if depth != MaxVariationDepth {
+		t.Errorf("ожидалась глубина %d, получено %d", MaxVariationDepth, depth)
+	}
+}
+
+func TestReader_MultipleGames(t *testing.T) {
+	text := operaGame + `
+[Event "Вторая"]
+
+1. d4 d5 1/2-1/2
+
+[Event "Без результата"]
+1. c4
+[Event "Четвертая"]
+*`
+	games := readAll(t, text)
+
+	expected := []struct {
+		event  string
+		plies  int
+		result string
+	}{
+		{"Opera Game", 33, ResultWhiteWins},
+		{"Вторая", 2, ResultDraw},
+		{"Без результата", 1, ResultUnknown},
+		{"Четвертая", 0, ResultUnknown},
+	}
+	if len(games) != len(expected) {
+		t.Fatalf("ожидалось %d партий, прочитано %d", len(expected), len(games))
+	}
+	for i, e := range expected {
+		game := games[i]
+		if game.Tag("Event") != e.event || game.Plies() != e.plies || game.Result != e.result {
+			t.Errorf("партия %d: ожидалось %q, %d полуходов, %q; получено %q, %d, %q",
+				i+1, e.event, e.plies, e.result, game.Tag("Event"), game.Plies(), game.Result)
+		}
+	}
+}
+
+func TestReader_Empty(t *testing.T) {
+	for _, text := range []string{"", "  \n\n", "% только директива\n"} {
+		if games := readAll(t, text); len(games) != 0 {
+			t.Errorf("в %q не должно быть партий, прочитано %d", text, len(games))
+		}
+	}
+}
+
+func TestReader_Errors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		text   string
+		game   int
+		line   int
+		reason string
+	}{
+		{"незакрытый вариант", "1. e4 (1. d4 d5\n*", 1, 2, "результат '*' внутри варианта"},
+		{"вариант до конца файла", "1. e4 (1. d4\n\n", 1, 3, "вариант не закрыт"},
+		{"лишняя скобка", "1. e4 e5)\n*", 1, 1, "лишняя закрывающая скобка"},
+		{"вариант перед первым ходом", "(1. e4) *", 1, 1, "вариант перед первым ходом"},
+		{"оценка перед первым ходом", "$1 1. e4 *", 1, 1, "оценка '$1' перед первым ходом"},
+		{"неверная оценка", "1. e4 $ *", 1, 1, "неверная оценка хода: '$'"},
+		{"неверная оценка знаками", "1. e4 !!! *", 1, 1, "неверная оценка хода: '!!!'"},
+		{"незакрытый комментарий", "1. e4\n{комментарий", 1, 2, "комментарий не закрыт"},
+		{"незакрытая строка", "[Event \"Турнир\n1. e4 *", 1, 1, "строка не закрыта"},
+		{"тег без значения", "[Event]\n*", 1, 1, "ожидалось значение тега 'Event'"},
+		{"тег без имени", "[\"Турнир\"]\n*", 1, 1, "ожидалось имя тега"},
+		{"незакрытый тег", "[Event \"Турнир\"\n*", 1, 2, "ожидалась ']' после тега 'Event'"},
+		{"неожиданный символ", "1. e4 & *", 1, 1, "неожиданный символ '&'"},
+		{"неверная запись хода", "1. e4 4e5 *", 1, 1, "неверная запись хода: '4e5'"},
+		{"слишком глубокие варианты", "1. e4 " + strings.Repeat("(1. d4 ", MaxVariationDepth+1), 1, 1, "варианты вложены глубже 100 уровней"},
+		{"ошибка во второй партии", "1. e4 *\n\n1. d4 d5\n2. c4 )", 2, 4, "лишняя закрывающая скобка"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reader := NewReader(strings.NewReader(tc.text))
+			var err error
+			for err == nil {
+				_, err = reader.Next()
+			}
+
+			var pgnErr *Error
+			if !errors.As(err, &pgnErr) {
+				t.Fatalf("ожидалась ошибка разбора, получено %v", err)
+			}
+			if pgnErr.Game != tc.game || pgnErr.Line != tc.line || pgnErr.Reason.Error() != tc.reason {
+				expected := Error{Game: tc.game, Line: tc.line, Reason: errors.New(tc.reason)}
+				t.Errorf("ожидалась ошибка %q, получено %q", expected.Error(), pgnErr.Error())
+			}
+		})
+	}
+}
+
+// failingReader возвращает ошибку после того, как отдаст весь текст
+type failingReader struct {
+	text io.Reader
+	err  error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	n, err := r.text.Read(p)
+	if err == io.EOF {
+		return n, r.err
+	}
+	return n, err
+}
+
+func TestReader_ReadError(t *testing.T) {
+	readErr := errors.New("ошибка чтения")
+	reader := NewReader(&failingReader{text: strings.NewReader("1. e4 e5"), err: readErr})
+
+	if _, err := reader.Next(); !errors.Is(err, readErr) {
+		t.Errorf("ожидалась ошибка чтения, получено %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package pgn
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"chessboard/internal/i18n"
+)
+
+// MaxLineLength - наибольшая длина строки записи ходов, рекомендованная стандартом
+const MaxLineLength = 80
+
+// Writer записывает партии в поток PGN, разделяя их пустой строкой
+type Writer struct {
+	w     io.Writer
+	games int
+}
+
+// NewWriter создает писателя партий в w
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write записывает партию: сначала семь обязательных тегов, затем остальные
+// в порядке следования и запись ходов с переносом строк по 80 символов
+func (w *Writer) Write(g *Game) error {
+	startPly, err := g.startPly()
+	if err != nil {
+		return err
+	}
+	result := g.Result
+	if result == "" {
+		result = ResultUnknown
+	}
+
+	out := bufio.NewWriter(w.w)
+	if w.games > 0 {
+		fmt.Fprintln(out)
+	}
+	w.games++
+
+	for _, name := range sevenTagRoster {
+		writeTag(out, name, g.rosterValue(name, result))
+	}
+	for _, tag := range g.Tags {
+		if !isRosterTag(tag.Name) {
+			writeTag(out, tag.Name, tag.Value)
+		}
+	}
+	fmt.Fprintln(out)
+
+	tokens := appendVariation(nil, g.MainLine, startPly)
+	writeWrapped(out, append(tokens, result))
+	return out.Flush()
+}
+
+// startPly возвращает номер полухода начальной позиции, считая от 0: по нему
+// ставятся номера ходов
+func (g *Game) startPly() (int, error) {
+	board, err := g.StartBoard()
+	if err != nil {
+		return 0, i18n.Errorf("pgn.invalid_fen_tag", err)
+	}
+	return (board.FullmoveNumber-1)*2 + int(board.SideToMove), nil
+}
+
+// rosterValue возвращает значение обязательного тега или замену для
+// неизвестного значения. Тег Result всегда совпадает с результатом партии.
+func (g *Game) rosterValue(name, result string) string {
+	switch {
+	case name == "Result":
+		return result
+	case g.Tag(name) != "":
+		return g.Tag(name)
+	case name == "Date":
+		return "????.??.??"
+	default:
+		return "?"
+	}
+}
+
+// isRosterTag сообщает, что тег входит в семь обязательных
+func isRosterTag(name string) bool {
+	for _, roster := range sevenTagRoster {
+		if name == roster {
+			return true
+		}
+	}
+	return false
+}
+
+// writeTag записывает пару тега, экранируя кавычки и обратную косую черту
+func writeTag(out io.Writer, name, value string) {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	fmt.Fprintf(out, "[%s \"%s\"]\n", name, value)
+}
+
+// appendVariation добавляет к tokens слова записи варианта, начинающегося с
+// полухода ply. Номер хода черных ставится в начале варианта и после
+// комментария или вложенного варианта, иначе непонятно, чей это ход.
+func appendVariation(tokens []string, v Variation, ply int) []string {
+	tokens = appendComment(tokens, v.Comment)
+	needNumber := true
+	for i, move := range v.Moves {
+		number := (ply+i)/2 + 1
+		if (ply+i)%2 == 0 {
+			tokens = append(tokens, fmt.Sprintf("%d.", number))
+		} else if needNumber {
+			tokens = append(tokens, fmt.Sprintf("%d...", number))
+		}
+		needNumber = false
+
+		tokens = append(tokens, move.SAN)
+		for _, nag := range move.NAGs {
+			tokens = append(tokens, fmt.Sprintf("$%d", nag))
+		}
+		if move.Comment != "" {
+			tokens = appendComment(tokens, move.Comment)
+			needNumber = true
+		}
+		for _, sub := range move.Variations {
+			subTokens := appendVariation(nil, sub, ply+i)
+			if len(subTokens) == 0 {
+				subTokens = []string{"()"}
+			} else {
+				subTokens[0] = "(" + subTokens[0]
+				subTokens[len(subTokens)-1] += ")"
+			}
+			tokens = append(tokens, subTokens...)
+			needNumber = true
+		}
+	}
+	return tokens
+}
+
+// appendComment добавляет комментарий по словам, чтобы длинный комментарий
+// можно было перенести на следующую строку
+func appendComment(tokens []string, comment string) []string {
+	words := strings.Fields(strings.ReplaceAll(comment, "}", ""))
+	if len(words) == 0 {
+		return tokens
+	}
+	words[0] = "{" + words[0]
+	words[len(words)-1] += "}"
+	return append(tokens, words...)
+}
+
+// writeWrapped записывает слова через пробел, перенося строку, когда она
+// становится длиннее MaxLineLength символов. Слово длиннее строки
+// записывается на отдельной строке целиком.
+func writeWrapped(out io.Writer, tokens []string) {
+	length := 0
+	for _, token := range tokens {
+		tokenLength := utf8.RuneCountInString(token)
+		switch {
+		case length == 0:
+		case length+1+tokenLength > MaxLineLength:
+			fmt.Fprintln(out)
+			length = 0
+		default:
+			fmt.Fprint(out, " ")
+			length++
+		}
+		fmt.Fprint(out, token)
+		length += tokenLength
+	}
+	fmt.Fprintln(out)
+}
@@ -0,0 +1,214 @@
+package pgn
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/notation"
+	"chessboard/internal/usecase"
+	"chessboard/internal/usecase/usecasetest"
+)
+
+// writeAll записывает партии в строку или прерывает тест
+func writeAll(t *testing.T, games ...*Game) string {
+	t.Helper()
+
+	var out strings.Builder
+	writer := NewWriter(&out)
+	for _, game := range games {
+		if err := writer.Write(game); err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+	}
+	return out.String()
+}
+
+func TestWriter_Tags(t *testing.T) {
+	game := &Game{
+		Tags: []Tag{
+			{"ECO", "C41"},
+			{"White", "Paul Morphy"},
+			{"Event", `Турнир "Мемориал" \ финал`},
+			{"Result", "0-1"},
+		},
+		MainLine: Variation{Moves: []Move{{SAN: "e4"}}},
+		Result:   ResultWhiteWins,
+	}
+
+	expected := `[Event "Турнир \"Мемориал\" \\ финал"]
+[Site "?"]
+[Date "????.??.??"]
+[Round "?"]
+[White "Paul Morphy"]
+[Black "?"]
+[Result "1-0"]
+[ECO "C41"]
+
+1. e4 1-0
+`
+	if got := writeAll(t, game); got != expected {
+		t.Errorf("ожидалось:\n%s\nполучено:\n%s", expected, got)
+	}
+}
+
+// moveText возвращает запись ходов партии без тегов
+func moveText(t *testing.T, game *Game) string {
+	t.Helper()
+
+	text := writeAll(t, game)
+	_, moves, found := strings.Cut(text, "\n\n")
+	if !found {
+		t.Fatalf("нет пустой строки после тегов:\n%s", text)
+	}
+	return strings.TrimSuffix(moves, "\n")
+}
+
+func TestWriter_MoveText(t *testing.T) {
+	variation := Variation{Moves: []Move{{SAN: "c5", Comment: "Сицилианская"}, {SAN: "Nf3"}}}
+
+	testCases := []struct {
+		name     string
+		game     *Game
+		expected string
+	}{
+		{
+			"без ходов",
+			&Game{Result: ResultDraw},
+			"1/2-1/2",
+		},
+		{
+			"пустой результат",
+			&Game{MainLine: Variation{Moves: []Move{{SAN: "e4"}}}},
+			"1. e4 *",
+		},
+		{
+			"оценки и комментарии",
+			&Game{
+				MainLine: Variation{Comment: "Начало", Moves: []Move{
+					{SAN: "e4", NAGs: []int{1}},
+					{SAN: "e5", NAGs: []int{2, 14}, Comment: "Сомнительно"},
+					{SAN: "Nf3"},
+					{SAN: "Nc6"},
+				}},
+				Result: ResultUnknown,
+			},
+			"{Начало} 1. e4 $1 e5 $2 $14 {Сомнительно} 2. Nf3 Nc6 *",
+		},
+		{
+			"варианты",
+			&Game{
+				MainLine: Variation{Moves: []Move{
+					{SAN: "e4"},
+					{SAN: "e5", Variations: []Variation{variation, {}}},
+					{SAN: "Nf3", Variations: []Variation{{Moves: []Move{{SAN: "Nc3"}}}}},
+				}},
+				Result: ResultUnknown,
+			},
+			"1. e4 e5 (1... c5 {Сицилианская} 2. Nf3) () 2. Nf3 (2. Nc3) *",
+		},
+		{
+			"позиция с хода черных",
+			&Game{
+				Tags:     []Tag{{"FEN", "4k3/8/8/8/8/8/8/4K3 b - - 0 12"}},
+				MainLine: Variation{Moves: []Move{{SAN: "Kd7"}, {SAN: "Kd2"}}},
+				Result:   ResultDraw,
+			},
+			"12... Kd7 13. Kd2 1/2-1/2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := moveText(t, tc.game); got != tc.expected {
+				t.Errorf("ожидалось %q, получено %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestWriter_LineWrapping(t *testing.T) {
+	game := readOne(t, operaGame)
+	game.MainLine.Moves[10].Comment = strings.Repeat("очень длинный комментарий ", 10)
+	text := writeAll(t, game)
+
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if len(lines) < 12 {
+		t.Fatalf("запись ходов должна занимать несколько строк:\n%s", text)
+	}
+	for _, line := range lines {
+		if length := utf8.RuneCountInString(line); length > MaxLineLength {
+			t.Errorf("строка длиннее %d символов (%d): %q", MaxLineLength, length, line)
+		}
+	}
+}
+
+func TestWriter_InvalidFEN(t *testing.T) {
+	game := &Game{Tags: []Tag{{"FEN", "неверный FEN"}}}
+	if err := NewWriter(&strings.Builder{}).Write(game); err == nil {
+		t.Error("ожидалась ошибка для неверного тега FEN")
+	}
+}
+
+func TestWriter_MultipleGames(t *testing.T) {
+	first := &Game{Tags: []Tag{{"Event", "Первая"}}, Result: ResultWhiteWins}
+	second := &Game{Tags: []Tag{{"Event", "Вторая"}}, Result: ResultBlackWins}
+
+	text := writeAll(t, first, second)
+	if !strings.Contains(text, "1-0\n\n[Event \"Вторая\"]") {
+		t.Errorf("партии должны разделяться пустой строкой:\n%s", text)
+	}
+
+	games := readAll(t, text)
+	if len(games) != 2 || games[1].Tag("Event") != "Вторая" || games[1].Result != ResultBlackWins {
+		t.Errorf("записанные партии не прочитались обратно: %+v", games)
+	}
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	original := readOne(t, `[Event "Разбор"]
+[FEN "r3k2r/8/8/8/8/8/8/R3K2R b KQkq - 3 20"]
+
+{Эндшпиль} 20... O-O (20... O-O-O $6 21. O-O (21. Kf1 {или} Rd1+)) 21. O-O-O!? {Симметрия}
+Rfd8 $10 1/2-1/2`)
+
+	text := writeAll(t, original)
+	again := readOne(t, text)
+	if writeAll(t, again) != text {
+		t.Errorf("повторная запись отличается:\n%s\n%s", text, writeAll(t, again))
+	}
+
+	// Основной вариант и ходы из вариантов должны оставаться легальными
+	game := usecasetest.MustGame(t, usecase.NewGame, again.Tag("FEN"))
+	if err := again.Play(game, again.Plies()); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	sub := again.MainLine.Moves[0].Variations[0]
+	if sub.Moves[0].NAGs[0] != 6 || sub.Moves[1].Variations[0].Moves[0].Comment != "или" {
+		t.Errorf("вариант прочитан неверно: %+v", sub)
+	}
+}
+
+func TestWriter_FromMovesRoundTrip(t *testing.T) {
+	moves := mustMoves(t, "e2e4 e7e5 g1f3 b8c6 f1c4 g8f6 f3g5 d7d5 e4d5 f6d5 g5f7 e8f7 d1f3 f7e6")
+	pgnGame, err := FromMoves(usecasetest.MustGame(t, usecase.NewGame, domain.StartFEN), moves, nil)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	again := readOne(t, writeAll(t, pgnGame))
+	game := usecasetest.MustGame(t, usecase.NewGame, domain.StartFEN)
+	for i, move := range again.MainLine.Moves {
+		parsed, err := notation.ParseSAN(game, move.SAN)
+		if err != nil {
+			t.Fatalf("полуход %d: неожиданная ошибка: %v", i+1, err)
+		}
+		if parsed != moves[i] {
+			t.Errorf("полуход %d: ожидался ход %s, получено %s", i+1, moves[i], parsed)
+		}
+		if err := game.Play(parsed); err != nil {
+			t.Fatalf("полуход %d: неожиданная ошибка: %v", i+1, err)
+		}
+	}
+}
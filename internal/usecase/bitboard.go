@@ -0,0 +1,503 @@
+package usecase
+
+import (
+	"math/bits"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+// Bitboard - множество клеток доски 8x8: бит i соответствует клетке с индексом
+// i = rank*8 + file, то есть a1 - младший бит, h8 - старший
+type Bitboard uint64
+
+// rank1 - клетки первой горизонтали
+const rank1 Bitboard = 0xff
+
+// squareBit возвращает множество из одной клетки
+func squareBit(square int) Bitboard {
+	return 1 << square
+}
+
+// Has сообщает, входит ли клетка в множество
+func (b Bitboard) Has(square int) bool {
+	return b&squareBit(square) != 0
+}
+
+// Count возвращает число клеток в множестве
+func (b Bitboard) Count() int {
+	return bits.OnesCount64(uint64(b))
+}
+
+// first возвращает индекс младшей клетки непустого множества
+func (b Bitboard) first() int {
+	return bits.TrailingZeros64(uint64(b))
+}
+
+// last возвращает индекс старшей клетки непустого множества
+func (b Bitboard) last() int {
+	return 63 - bits.LeadingZeros64(uint64(b))
+}
+
+// Направления лучей дальнобойных фигур. Первые четыре увеличивают индекс клетки,
+// поэтому ближайшая блокирующая фигура на них - младшая, на остальных - старшая.
+const (
+	rayNorth = iota
+	rayEast
+	rayNorthEast
+	rayNorthWest
+	raySouth
+	rayWest
+	raySouthWest
+	raySouthEast
+	rayCount
+)
+
+var rayDirections = [rayCount]direction{
+	rayNorth:     {0, 1},
+	rayEast:      {1, 0},
+	rayNorthEast: {1, 1},
+	rayNorthWest: {-1, 1},
+	raySouth:     {0, -1},
+	rayWest:      {-1, 0},
+	raySouthWest: {-1, -1},
+	raySouthEast: {1, -1},
+}
+
+// Таблицы атак, вычисляемые один раз при загрузке пакета
+var (
+	knightAttacks [64]Bitboard
+	kingAttacks   [64]Bitboard
+	// pawnAttacks[color][square] - клетки, которые бьет пешка цвета color с клетки square
+	pawnAttacks [2][64]Bitboard
+	// rays[dir][square] - клетки луча из square в направлении dir без самой клетки
+	rays [rayCount][64]Bitboard
+)
+
+func init() {
+	for square := 0; square < 64; square++ {
+		knightAttacks[square] = stepTargets(square, knightSteps)
+		kingAttacks[square] = stepTargets(square, kingSteps)
+		pawnAttacks[domain.White][square] = stepTargets(square, []direction{{-1, 1}, {1, 1}})
+		pawnAttacks[domain.Black][square] = stepTargets(square, []direction{{-1, -1}, {1, -1}})
+
+		for dir, d := range rayDirections {
+			file, rank := square%8+d.file, square/8+d.rank
+			for ; file >= 0 && file < 8 && rank >= 0 && rank < 8; file, rank = file+d.file, rank+d.rank {
+				rays[dir][square] |= squareBit(rank*8 + file)
+			}
+		}
+	}
+}
+
+// stepTargets возвращает клетки, достижимые с square одним из шагов steps
+func stepTargets(square int, steps []direction) Bitboard {
+	var targets Bitboard
+	for _, d := range steps {
+		file, rank := square%8+d.file, square/8+d.rank
+		if file >= 0 && file < 8 && rank >= 0 && rank < 8 {
+			targets |= squareBit(rank*8 + file)
+		}
+	}
+	return targets
+}
+
+// rayAttacks возвращает клетки луча до первой занятой клетки включительно
+func rayAttacks(square, dir int, occupied Bitboard) Bitboard {
+	attacks := rays[dir][square]
+	blockers := attacks & occupied
+	if blockers == 0 {
+		return attacks
+	}
+	blocker := blockers.first()
+	if dir >= raySouth {
+		blocker = blockers.last()
+	}
+	return attacks &^ rays[dir][blocker]
+}
+
+// bishopAttacks возвращает клетки, которые бьет слон с square при занятости occupied
+func bishopAttacks(square int, occupied Bitboard) Bitboard {
+	return rayAttacks(square, rayNorthEast, occupied) | rayAttacks(square, rayNorthWest, occupied) |
+		rayAttacks(square, raySouthEast, occupied) | rayAttacks(square, raySouthWest, occupied)
+}
+
+// rookAttacks возвращает клетки, которые бьет ладья с square при занятости occupied
+func rookAttacks(square int, occupied Bitboard) Bitboard {
+	return rayAttacks(square, rayNorth, occupied) | rayAttacks(square, rayEast, occupied) |
+		rayAttacks(square, raySouth, occupied) | rayAttacks(square, rayWest, occupied)
+}
+
+// BitboardPosition - позиция на доске 8x8 в виде битовых досок: множества клеток
+// для каждого типа фигур и цвета и общая занятость. Для быстрого ответа на вопрос
+// "что стоит на клетке" дополнительно хранится массив клеток. Ходы выполняются
+// на копии позиции, поэтому отменять их не нужно.
+type BitboardPosition struct {
+	pieces   [2][domain.King + 1]Bitboard
+	colors   [2]Bitboard
+	occupied Bitboard
+	squares  [64]domain.Piece
+
+	side     domain.Color
+	castling domain.CastlingRights
+	// epSquare - индекс клетки взятия на проходе или -1
+	epSquare int
+	halfmove int
+	fullmove int
+	// hash - хеш Зобриста, совпадающий с хешем позиции по клеткам
+	hash uint64
+}
+
+// NewBitboardPosition переводит доску 8x8 в битовое представление. Позиция
+// проверяется так же, как генератором ходов по клеткам.
+func NewBitboardPosition(board *domain.Board) (*BitboardPosition, error) {
+	if board.Width != domain.StandardBoardSize || board.Height != domain.StandardBoardSize {
+		return nil, i18n.Errorf("bitboard.size",
+			domain.StandardBoardSize, domain.StandardBoardSize, board.Width, board.Height)
+	}
+	p, err := newPosition(board)
+	if err != nil {
+		return nil, err
+	}
+	return p.bitboards(), nil
+}
+
+// bitboards переводит позицию размером 8x8 в битовое представление
+func (p *position) bitboards() *BitboardPosition {
+	bp := &BitboardPosition{
+		side:     p.side,
+		castling: p.castling,
+		epSquare: p.epSquare,
+		halfmove: p.halfmove,
+		fullmove: p.fullmove,
+	}
+	for square, piece := range p.squares {
+		if !piece.IsEmpty() {
+			bp.put(square, piece)
+		}
+	}
+	bp.hash = p.hash
+	return bp
+}
+
+// Board переводит позицию обратно в доску домена
+func (p *BitboardPosition) Board() *domain.Board {
+	board := &domain.Board{
+		Width:          domain.StandardBoardSize,
+		Height:         domain.StandardBoardSize,
+		Squares:        append([]domain.Piece(nil), p.squares[:]...),
+		SideToMove:     p.side,
+		Castling:       p.castling,
+		HalfmoveClock:  p.halfmove,
+		FullmoveNumber: p.fullmove,
+	}
+	if p.epSquare >= 0 {
+		board.EnPassant = &domain.Square{File: p.epSquare % 8, Rank: p.epSquare / 8}
+	}
+	return board
+}
+
+// Pieces возвращает клетки фигур заданного цвета и типа
+func (p *BitboardPosition) Pieces(color domain.Color, kind domain.PieceKind) Bitboard {
+	return p.pieces[color][kind]
+}
+
+// Colors возвращает клетки всех фигур заданного цвета
+func (p *BitboardPosition) Colors(color domain.Color) Bitboard {
+	return p.colors[color]
+}
+
+// Occupied возвращает все занятые клетки
+func (p *BitboardPosition) Occupied() Bitboard {
+	return p.occupied
+}
+
+func (p *BitboardPosition) put(square int, piece domain.Piece) {
+	bit := squareBit(square)
+	p.pieces[piece.Color][piece.Kind] |= bit
+	p.colors[piece.Color] |= bit
+	p.occupied |= bit
+	p.squares[square] = piece
+	p.hash ^= pieceKey(piece, square)
+}
+
+func (p *BitboardPosition) remove(square int) domain.Piece {
+	piece := p.squares[square]
+	if piece.IsEmpty() {
+		return piece
+	}
+	bit := squareBit(square)
+	p.pieces[piece.Color][piece.Kind] &^= bit
+	p.colors[piece.Color] &^= bit
+	p.occupied &^= bit
+	p.squares[square] = domain.Piece{}
+	p.hash ^= pieceKey(piece, square)
+	return piece
+}
+
+// isAttacked сообщает, бьет ли сторона by клетку square
+func (p *BitboardPosition) isAttacked(square int, by domain.Color) bool {
+	enemy := &p.pieces[by]
+	// Пешка стороны by бьет square, если пешка противника с square бьет ее клетку
+	if pawnAttacks[by.Opposite()][square]&enemy[domain.Pawn] != 0 ||
+		knightAttacks[square]&enemy[domain.Knight] != 0 ||
+		kingAttacks[square]&enemy[domain.King] != 0 {
+		return true
+	}
+	queens := enemy[domain.Queen]
+	return bishopAttacks(square, p.occupied)&(enemy[domain.Bishop]|queens) != 0 ||
+		rookAttacks(square, p.occupied)&(enemy[domain.Rook]|queens) != 0
+}
+
+// pseudoLegalMoves добавляет к moves ходы по правилам перемещения фигур
+func (p *BitboardPosition) pseudoLegalMoves(moves []move) []move {
+	own := p.colors[p.side]
+	enemy := p.colors[p.side.Opposite()]
+	mine := &p.pieces[p.side]
+
+	moves = p.pawnMoves(moves)
+	for knights := mine[domain.Knight]; knights != 0; knights &= knights - 1 {
+		from := knights.first()
+		moves = addTargets(moves, from, knightAttacks[from]&^own, enemy)
+	}
+	for sliders := mine[domain.Bishop] | mine[domain.Queen]; sliders != 0; sliders &= sliders - 1 {
+		from := sliders.first()
+		moves = addTargets(moves, from, bishopAttacks(from, p.occupied)&^own, enemy)
+	}
+	for sliders := mine[domain.Rook] | mine[domain.Queen]; sliders != 0; sliders &= sliders - 1 {
+		from := sliders.first()
+		moves = addTargets(moves, from, rookAttacks(from, p.occupied)&^own, enemy)
+	}
+	if kings := mine[domain.King]; kings != 0 {
+		from := kings.first()
+		moves = addTargets(moves, from, kingAttacks[from]&^own, enemy)
+		moves = p.castlingMoves(moves, from)
+	}
+	return moves
+}
+
+// addTargets добавляет ходы с клетки from на каждую клетку targets
+func addTargets(moves []move, from int, targets, enemy Bitboard) []move {
+	for ; targets != 0; targets &= targets - 1 {
+		to := targets.first()
+		m := move{from: from, to: to}
+		if enemy.Has(to) {
+			m.flags = flagCapture
+		}
+		moves = append(moves, m)
+	}
+	return moves
+}
+
+func (p *BitboardPosition) pawnMoves(moves []move) []move {
+	enemy := p.colors[p.side.Opposite()]
+	forward, startRank, lastRank := 8, rank1<<8, rank1<<56
+	if p.side == domain.Black {
+		forward, startRank, lastRank = -8, rank1<<48, rank1
+	}
+
+	for pawns := p.pieces[p.side][domain.Pawn]; pawns != 0; pawns &= pawns - 1 {
+		from := pawns.first()
+
+		if to := from + forward; to >= 0 && to < 64 && !p.occupied.Has(to) {
+			moves = addPawnTarget(moves, move{from: from, to: to}, lastRank)
+			if to2 := to + forward; startRank.Has(from) && !p.occupied.Has(to2) {
+				moves = append(moves, move{from: from, to: to2, flags: flagDoublePush})
+			}
+		}
+
+		attacks := pawnAttacks[p.side][from]
+		for captures := attacks & enemy; captures != 0; captures &= captures - 1 {
+			moves = addPawnTarget(moves, move{from: from, to: captures.first(), flags: flagCapture}, lastRank)
+		}
+		if p.epSquare >= 0 && attacks.Has(p.epSquare) && !p.occupied.Has(p.epSquare) {
+			moves = append(moves, move{from: from, to: p.epSquare, flags: flagCapture | flagEnPassant})
+		}
+	}
+	return moves
+}
+
+// addPawnTarget добавляет ход пешки, раскрывая его в превращения на последней горизонтали
+func addPawnTarget(moves []move, m move, lastRank Bitboard) []move {
+	if !lastRank.Has(m.to) {
+		return append(moves, m)
+	}
+	for _, kind := range promotionKinds {
+		m.promotion = kind
+		moves = append(moves, m)
+	}
+	return moves
+}
+
+// castlingMoves добавляет рокировки по тем же правилам, что и генератор по клеткам
+func (p *BitboardPosition) castlingMoves(moves []move, from int) []move {
+	rankStart := 0
+	if p.side == domain.Black {
+		rankStart = 56
+	}
+	if from/8 != rankStart/8 || p.castling&(castlingRight(p.side, true)|castlingRight(p.side, false)) == 0 {
+		return moves
+	}
+	enemy := p.side.Opposite()
+	if p.isAttacked(from, enemy) {
+		return moves
+	}
+
+	rooks := p.pieces[p.side][domain.Rook]
+	for _, kingside := range [2]bool{true, false} {
+		rook, dir, between := rankStart, -1, rays[rayWest][from]&^rays[rayWest][rankStart]
+		if kingside {
+			rook, dir, between = rankStart+7, 1, rays[rayEast][from]&^rays[rayEast][rankStart+7]
+		}
+		between &^= squareBit(rook)
+		target := from + 2*dir
+		if !p.castling.Has(castlingRight(p.side, kingside)) || !rooks.Has(rook) ||
+			target <= rankStart || target >= rankStart+7 || between&p.occupied != 0 ||
+			p.isAttacked(from+dir, enemy) || p.isAttacked(target, enemy) {
+			continue
+		}
+		moves = append(moves, move{from: from, to: target, flags: flagCastling})
+	}
+	return moves
+}
+
+// bitboardCornerRights возвращает права рокировки, связанные с угловой клеткой square
+func bitboardCornerRights(square int) domain.CastlingRights {
+	switch square {
+	case 0:
+		return domain.WhiteQueenside
+	case 7:
+		return domain.WhiteKingside
+	case 56:
+		return domain.BlackQueenside
+	case 63:
+		return domain.BlackKingside
+	}
+	return domain.NoCastling
+}
+
+// play возвращает позицию после хода m
+func (p *BitboardPosition) play(m move) BitboardPosition {
+	next := *p
+	next.hash ^= castlingKeys[p.castling] ^ sideToMoveKey(p.side) ^ p.epKey()
+	piece := next.remove(m.from)
+
+	captured := m.to
+	if m.flags&flagEnPassant != 0 {
+		captured = m.to - pawnDirection(p.side)*8
+	}
+	taken := next.remove(captured)
+
+	next.halfmove++
+	if piece.Kind == domain.Pawn || !taken.IsEmpty() {
+		next.halfmove = 0
+	}
+	if m.promotion != domain.NoPiece {
+		piece.Kind = m.promotion
+	}
+	next.put(m.to, piece)
+
+	if m.flags&flagCastling != 0 {
+		rankStart := m.to - m.to%8
+		rookFrom, rookTo := rankStart, m.to+1
+		if m.to > m.from {
+			rookFrom, rookTo = rankStart+7, m.to-1
+		}
+		next.put(rookTo, next.remove(rookFrom))
+	}
+	if piece.Kind == domain.King {
+		next.castling &^= castlingRight(p.side, true) | castlingRight(p.side, false)
+	}
+	next.castling &^= bitboardCornerRights(m.from) | bitboardCornerRights(m.to)
+
+	next.epSquare = -1
+	if m.flags&flagDoublePush != 0 {
+		next.epSquare = m.from + pawnDirection(p.side)*8
+	}
+	if p.side == domain.Black {
+		next.fullmove++
+	}
+	next.side = p.side.Opposite()
+	next.hash ^= castlingKeys[next.castling] ^ sideToMoveKey(next.side) ^ next.epKey()
+	return next
+}
+
+// epKey возвращает ключ взятия на проходе, если клетку взятия атакует пешка
+// стороны, имеющей очередь хода (см. position.epKey)
+func (p *BitboardPosition) epKey() uint64 {
+	if p.epSquare < 0 || pawnAttacks[p.side.Opposite()][p.epSquare]&p.pieces[p.side][domain.Pawn] == 0 {
+		return 0
+	}
+	return enPassantKey(p.epSquare % 8)
+}
+
+// Hash возвращает хеш Зобриста позиции; он совпадает с результатом функции Hash
+// для той же доски
+func (p *BitboardPosition) Hash() uint64 {
+	if epKey := p.epKey(); epKey != 0 {
+		for _, m := range p.legalMoves(nil) {
+			if m.flags&flagEnPassant != 0 {
+				return p.hash
+			}
+		}
+		return p.hash ^ epKey
+	}
+	return p.hash
+}
+
+// leavesKingSafe сообщает, что после хода король ходившей стороны не под шахом
+func (p *BitboardPosition) leavesKingSafe(mover domain.Color) bool {
+	king := p.pieces[mover][domain.King]
+	return king == 0 || !p.isAttacked(king.first(), mover.Opposite())
+}
+
+// legalMoves добавляет к moves легальные ходы
+func (p *BitboardPosition) legalMoves(moves []move) []move {
+	start := len(moves)
+	moves = p.pseudoLegalMoves(moves)
+
+	legal := moves[:start]
+	for _, m := range moves[start:] {
+		next := p.play(m)
+		if next.leavesKingSafe(p.side) {
+			legal = append(legal, m)
+		}
+	}
+	return legal
+}
+
+// LegalMoves возвращает легальные ходы стороны, имеющей очередь хода
+func (p *BitboardPosition) LegalMoves() []domain.Move {
+	moves := p.legalMoves(nil)
+	result := make([]domain.Move, len(moves))
+	for i, m := range moves {
+		result[i] = domain.Move{
+			From:      domain.Square{File: m.from % 8, Rank: m.from / 8},
+			To:        domain.Square{File: m.to % 8, Rank: m.to / 8},
+			Promotion: m.promotion,
+		}
+	}
+	return result
+}
+
+// perft считает листья дерева легальных ходов глубины depth. Позиции после ходов
+// пишутся в заранее выделенный срез positions, по одной на уровень.
+func (p *BitboardPosition) perft(depth int, buffers [][]move, positions []BitboardPosition) int64 {
+	if depth == 0 {
+		return 1
+	}
+
+	moves := p.legalMoves(buffers[depth][:0])
+	buffers[depth] = moves
+	if depth == 1 {
+		return int64(len(moves))
+	}
+
+	var nodes int64
+	next := &positions[depth-1]
+	for _, m := range moves {
+		*next = p.play(m)
+		nodes += next.perft(depth-1, buffers, positions)
+	}
+	return nodes
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+)
+
+// mustBitboards создает битовую позицию из FEN или прерывает тест
+func mustBitboards(t testing.TB, fen string) *BitboardPosition {
+	t.Helper()
+
+	board, err := domain.ParseFEN(fen)
+	if err != nil {
+		t.Fatalf("неверный FEN %q: %v", fen, err)
+	}
+	bp, err := NewBitboardPosition(board)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	return bp
+}
+
+// squaresOf переводит множество клеток в отсортированные обозначения
+func squaresOf(b Bitboard) []string {
+	var names []string
+	for ; b != 0; b &= b - 1 {
+		square := b.first()
+		names = append(names, domain.Square{File: square % 8, Rank: square / 8}.String())
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestBitboard_AttackTables(t *testing.T) {
+	testCases := []struct {
+		name     string
+		attacks  Bitboard
+		expected string
+	}{
+		{"конь в углу a1", knightAttacks[0], "b3 c2"},
+		{"конь в центре d4", knightAttacks[27], "b3 b5 c2 c6 e2 e6 f3 f5"},
+		{"король в углу h8", kingAttacks[63], "g7 g8 h7"},
+		{"белая пешка на a2", pawnAttacks[domain.White][8], "b3"},
+		{"черная пешка на e7", pawnAttacks[domain.Black][52], "d6 f6"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := strings.Join(squaresOf(tc.attacks), " "); actual != tc.expected {
+				t.Errorf("ожидалось %q, получено %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBitboard_SlidingAttacks(t *testing.T) {
+	// Блокирующие фигуры на d6, f4, b2 и g7
+	occupied := squareBit(43) | squareBit(29) | squareBit(9) | squareBit(54)
+
+	testCases := []struct {
+		name     string
+		attacks  Bitboard
+		expected string
+	}{
+		{"ладья на d4", rookAttacks(27, occupied),
+			"a4 b4 c4 d1 d2 d3 d5 d6 e4 f4"},
+		{"слон на d4", bishopAttacks(27, occupied),
+			"a7 b2 b6 c3 c5 e3 e5 f2 f6 g1 g7"},
+		{"ладья на пустой доске", rookAttacks(0, 0),
+			"a2 a3 a4 a5 a6 a7 a8 b1 c1 d1 e1 f1 g1 h1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := strings.Join(squaresOf(tc.attacks), " "); actual != tc.expected {
+				t.Errorf("ожидалось %q, получено %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBitboardPosition_Conversion(t *testing.T) {
+	for _, tc := range PerftSuite {
+		t.Run(tc.Name, func(t *testing.T) {
+			bp := mustBitboards(t, tc.FEN)
+
+			if fen := bp.Board().FEN(); fen != tc.FEN {
+				t.Errorf("ожидалось %q, получено %q", tc.FEN, fen)
+			}
+			if bp.Occupied() != bp.Colors(domain.White)|bp.Colors(domain.Black) {
+				t.Error("занятость должна совпадать с объединением фигур обоих цветов")
+			}
+			if bp.Colors(domain.White)&bp.Colors(domain.Black) != 0 {
+				t.Error("фигуры разных цветов не могут стоять на одной клетке")
+			}
+		})
+	}
+
+	t.Run("множества фигур", func(t *testing.T) {
+		bp := mustBitboards(t, domain.StartFEN)
+
+		if actual := strings.Join(squaresOf(bp.Pieces(domain.White, domain.Knight)), " "); actual != "b1 g1" {
+			t.Errorf("белые кони: ожидалось \"b1 g1\", получено %q", actual)
+		}
+		if count := bp.Pieces(domain.Black, domain.Pawn).Count(); count != 8 {
+			t.Errorf("ожидалось 8 черных пешек, получено %d", count)
+		}
+		if count := bp.Occupied().Count(); count != 32 {
+			t.Errorf("ожидалось 32 фигуры, получено %d", count)
+		}
+	})
+}
+
+func TestNewBitboardPosition_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		board    *domain.Board
+		errorMsg string
+	}{
+		{"доска 10x8", &domain.Board{Width: 10, Height: 8}, "только доску 8x8, получено 10x8"},
+		{"неверное число клеток", &domain.Board{Width: 8, Height: 8, Squares: make([]domain.Piece, 3)},
+			"число клеток (3)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewBitboardPosition(tc.board)
+			if err == nil || !strings.Contains(err.Error(), tc.errorMsg) {
+				t.Errorf("ожидалась ошибка с текстом '%s', получено: %v", tc.errorMsg, err)
+			}
+		})
+	}
+}
+
+// compareGenerators сверяет ходы генератора по клеткам и битового генератора
+// во всех узлах дерева глубины depth
+func compareGenerators(t *testing.T, p *position, bp *BitboardPosition, depth int) {
+	t.Helper()
+
+	expected := moveNames(p.domainMoves(p.legalMoves(nil)))
+	actual := moveNames(bp.LegalMoves())
+	if !slices.Equal(expected, actual) {
+		t.Fatalf("позиция %s: по клеткам %v, на битовых досках %v", p.board().FEN(), expected, actual)
+	}
+	if depth == 1 {
+		return
+	}
+
+	for _, m := range p.legalMoves(nil) {
+		u := p.makeMove(m)
+		next := bp.play(m)
+		if fen, bitFEN := p.board().FEN(), next.Board().FEN(); fen != bitFEN {
+			t.Fatalf("после хода %s позиции расходятся: %q и %q", p.domainMove(m), fen, bitFEN)
+		}
+		compareGenerators(t, p, &next, depth-1)
+		p.unmakeMove(m, u)
+	}
+}
+
+func TestBitboardPosition_MatchesNaiveGenerator(t *testing.T) {
+	depth := 3
+	if testing.Short() {
+		depth = 2
+	}
+
+	for _, tc := range PerftSuite {
+		t.Run(tc.Name, func(t *testing.T) {
+			compareGenerators(t, mustPosition(t, tc.FEN), mustBitboards(t, tc.FEN), depth)
+		})
+	}
+}
+
+// benchmarkPerftFEN - позиция kiwipete, в которой встречаются все виды ходов
+var benchmarkPerftFEN = PerftSuite[1].FEN
+
+func BenchmarkPerft_Naive(b *testing.B) {
+	p := mustPosition(b, benchmarkPerftFEN)
+	buffers := make([][]move, 4)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p.perft(3, buffers)
+	}
+}
+
+func BenchmarkPerft_Bitboard(b *testing.B) {
+	bp := mustBitboards(b, benchmarkPerftFEN)
+	buffers := make([][]move, 4)
+	positions := make([]BitboardPosition, 4)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		bp.perft(3, buffers, positions)
+	}
+}
+
+func BenchmarkLegalMoves_Bitboard(b *testing.B) {
+	bp := mustBitboards(b, benchmarkPerftFEN)
+	moves := make([]move, 0, 256)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		moves = bp.legalMoves(moves[:0])
+	}
+}
@@ -1,45 +1,157 @@
 package usecase
 
 import (
-	"fmt"
+	"io"
 	"strings"
+	"sync"
 
 	"chessboard/internal/domain"
 )
 
-const (
-	whiteSquare = " "
-	blackSquare = "#"
-)
-
 type boardUsecase struct {
-	repo domain.BoardRepository
+	repo     domain.BoardRepository
+	patterns *PatternRegistry
+
+	mu        sync.Mutex
+	pattern   string
+	lastBoard *domain.Board
 }
 
 func NewBoardUsecase(repo domain.BoardRepository) domain.BoardService {
-	return &boardUsecase{repo: repo}
+	return NewBoardUsecaseWithPatterns(repo, NewPatternRegistry())
 }
 
-func (uc *boardUsecase) CreateBoard(size int) *domain.Board {
-	if err := uc.ValidateSize(size); err != nil {
-		// Возвращаем доску размером по умолчанию при ошибке валидации
-		return uc.repo.GenerateBoard(domain.DefaultBoardSize)
+// NewBoardUsecaseWithPatterns создает сервис с собственным реестром узоров
+func NewBoardUsecaseWithPatterns(repo domain.BoardRepository, patterns *PatternRegistry) domain.BoardService {
+	return &boardUsecase{repo: repo, patterns: patterns, pattern: DefaultPattern}
+}
+
+// CreateBoard создает доску с расстановкой setup. Размер вне допустимых пределов
+// и расстановка, невозможная на доске такого размера, возвращаются как ошибка.
+func (uc *boardUsecase) CreateBoard(width, height int, setup domain.Setup) (*domain.Board, error) {
+	if err := uc.ValidateSize(width, height); err != nil {
+		return nil, err
+	}
+	if err := setup.Validate(width, height); err != nil {
+		return nil, err
 	}
-	return uc.repo.GenerateBoard(size)
+	board := uc.repo.GenerateBoard(width, height, setup)
+	uc.remember(board)
+	return board, nil
 }
 
-func (uc *boardUsecase) ValidateSize(size int) error {
-	if size < domain.MinBoardSize {
-		return fmt.Errorf("размер доски не может быть меньше %d", domain.MinBoardSize)
+// LoadFEN создает доску из позиции в нотации FEN. Размер доски, заданный
+// расстановкой, проверяется так же, как при создании доски.
+func (uc *boardUsecase) LoadFEN(fen string) (*domain.Board, error) {
+	board, err := domain.ParseFEN(fen)
+	if err != nil {
+		return nil, err
 	}
-	if size > domain.MaxBoardSize {
-		return fmt.Errorf("размер доски не может превышать %d", domain.MaxBoardSize)
+	if err := uc.ValidateSize(board.Width, board.Height); err != nil {
+		return nil, err
 	}
-	return nil
+	uc.remember(board)
+	return board, nil
+}
+
+// LegalMoves возвращает легальные ходы стороны, имеющей очередь хода
+func (uc *boardUsecase) LegalMoves(board *domain.Board) ([]domain.Move, error) {
+	return LegalMoves(board)
 }
 
+// Perft считает листья дерева легальных ходов глубины depth с разбивкой по первым ходам
+func (uc *boardUsecase) Perft(board *domain.Board, depth int) (domain.PerftResult, error) {
+	return Perft(board, depth)
+}
+
+// Status определяет состояние партии в позиции на доске
+func (uc *boardUsecase) Status(board *domain.Board) (domain.GameStatus, error) {
+	return Status(board)
+}
+
+// NewGame начинает партию с позиции на доске
+func (uc *boardUsecase) NewGame(board *domain.Board) (domain.Game, error) {
+	return NewGame(board)
+}
+
+// Hash возвращает хеш Зобриста позиции на доске
+func (uc *boardUsecase) Hash(board *domain.Board) (uint64, error) {
+	return Hash(board)
+}
+
+// remember запоминает доску как последнюю созданную
+func (uc *boardUsecase) remember(board *domain.Board) {
+	uc.mu.Lock()
+	uc.lastBoard = board
+	uc.mu.Unlock()
+}
+
+// ValidateSize проверяет ширину и высоту доски на попадание в допустимый диапазон.
+// Ошибка - *domain.SizeError с неверным значением и пределами.
+func (uc *boardUsecase) ValidateSize(width, height int) error {
+	return domain.ValidateSize(width, height)
+}
+
+// GeneratePattern возвращает последнюю созданную доску, отрисованную выбранным узором
 func (uc *boardUsecase) GeneratePattern() string {
-	return ""
+	uc.mu.Lock()
+	board := uc.lastBoard
+	uc.mu.Unlock()
+
+	if board == nil {
+		return ""
+	}
+
+	var result strings.Builder
+	if err := uc.Render(&result, board, domain.RenderOptions{}); err != nil {
+		return ""
+	}
+	return result.String()
+}
+
+// Render потоково выводит доску в w в формате opts.Format. Если узор
+// в opts не указан, используется узор, выбранный через SetPattern.
+func (uc *boardUsecase) Render(w io.Writer, board *domain.Board, opts domain.RenderOptions) error {
+	name := opts.Pattern
+	if name == "" {
+		uc.mu.Lock()
+		name = uc.pattern
+		uc.mu.Unlock()
+	}
+
+	fn, err := uc.patterns.Lookup(name)
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case "", domain.FormatText:
+		return WriteBoard(w, board, fn, opts)
+	case domain.FormatSVG:
+		return WriteSVG(w, board, fn, opts)
+	case domain.FormatPNG:
+		return WritePNG(w, board, fn, opts)
+	case domain.FormatJSON:
+		opts.Pattern = name
+		return WriteJSON(w, board, fn, opts)
+	default:
+		return &domain.UnknownValueError{Label: "unknown.format", Value: string(opts.Format)}
+	}
+}
+
+func (uc *boardUsecase) SetPattern(name string) error {
+	if _, err := uc.patterns.Lookup(name); err != nil {
+		return err
+	}
+
+	uc.mu.Lock()
+	uc.pattern = strings.TrimSpace(name)
+	uc.mu.Unlock()
+	return nil
+}
+
+func (uc *boardUsecase) Patterns() []string {
+	return uc.patterns.Names()
 }
 
 // BoardRepository реализация
@@ -49,28 +161,27 @@ func NewBoardRepository() domain.BoardRepository {
 	return &boardRepository{}
 }
 
-func (r *boardRepository) GenerateBoard(size int) *domain.Board {
-	return &domain.Board{Size: size}
+func (r *boardRepository) GenerateBoard(width, height int, setup domain.Setup) *domain.Board {
+	board := &domain.Board{Width: width, Height: height}
+	if setup == domain.SetupStandard && setup.Validate(width, height) == nil {
+		board.PlaceStandardPieces()
+	}
+	return board
 }
 
 // GenerateChessboard генерирует строку с шахматной доской
 func GenerateChessboard(board *domain.Board) string {
+	return RenderPattern(board, CheckerPattern)
+}
+
+// RenderPattern генерирует строку с доской, раскрашенной указанным узором.
+// Для больших досок предпочтительнее WritePattern, который не держит доску в памяти.
+func RenderPattern(board *domain.Board, pattern PatternFunc) string {
 	var result strings.Builder
+	result.Grow(board.Width*board.Height + board.Height)
 
-	for i := 0; i < board.Size; i++ {
-		for j := 0; j < board.Size; j++ {
-			// Логика чередования: сумма координат четная - пробел, нечетная - #
-			if (i+j)%2 == 0 {
-				result.WriteString(whiteSquare)
-			} else {
-				result.WriteString(blackSquare)
-			}
-		}
-		// Добавляем символ новой строки после каждой строки, кроме последней
-		if i < board.Size-1 {
-			result.WriteString("\n")
-		}
-	}
+	// strings.Builder никогда не возвращает ошибку записи
+	_ = WritePattern(&result, board, pattern)
 
 	return result.String()
 }
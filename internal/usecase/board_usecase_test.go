@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 
@@ -12,11 +13,11 @@ type MockBoardRepository struct {
 	generateError error
 }
 
-func (m *MockBoardRepository) GenerateBoard(size int) *domain.Board {
+func (m *MockBoardRepository) GenerateBoard(width, height int, setup domain.Setup) *domain.Board {
 	if m.generateError != nil {
 		return nil
 	}
-	return &domain.Board{Size: size}
+	return &domain.Board{Width: width, Height: height}
 }
 
 func TestNewBoardUsecase(t *testing.T) {
@@ -34,58 +35,86 @@ func TestBoardUsecase_ValidateSize(t *testing.T) {
 
 	testCases := []struct {
 		name        string
-		size        int
+		width       int
+		height      int
 		expectError bool
 		errorMsg    string
 	}{
 		{
 			name:        "валидный минимальный размер",
-			size:        domain.MinBoardSize,
+			width:       domain.MinBoardSize,
+			height:      domain.MinBoardSize,
 			expectError: false,
 		},
 		{
 			name:        "валидный средний размер",
-			size:        8,
+			width:       8,
+			height:      8,
 			expectError: false,
 		},
 		{
 			name:        "валидный максимальный размер",
-			size:        domain.MaxBoardSize,
+			width:       domain.MaxBoardSize,
+			height:      domain.MaxBoardSize,
+			expectError: false,
+		},
+		{
+			name:        "валидная прямоугольная доска",
+			width:       10,
+			height:      8,
 			expectError: false,
 		},
 		{
 			name:        "размер меньше минимального",
-			size:        0,
+			width:       0,
+			height:      0,
 			expectError: true,
 			errorMsg:    "размер доски не может быть меньше",
 		},
 		{
 			name:        "отрицательный размер",
-			size:        -5,
+			width:       -5,
+			height:      -5,
 			expectError: true,
 			errorMsg:    "размер доски не может быть меньше",
 		},
 		{
 			name:        "размер больше максимального",
-			size:        domain.MaxBoardSize + 1,
+			width:       domain.MaxBoardSize + 1,
+			height:      domain.MaxBoardSize + 1,
 			expectError: true,
 			errorMsg:    "размер доски не может превышать",
 		},
 		{
 			name:        "очень большой размер",
-			size:        1000,
+			width:       100000,
+			height:      100000,
 			expectError: true,
 			errorMsg:    "размер доски не может превышать",
 		},
+		{
+			name:        "слишком узкая доска",
+			width:       2,
+			height:      8,
+			expectError: true,
+			errorMsg:    "ширина 2",
+		},
+		{
+			name:        "слишком высокая доска",
+			width:       8,
+			height:      domain.MaxBoardSize + 1,
+			expectError: true,
+			errorMsg:    "высота",
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := usecase.ValidateSize(tc.size)
+			err := usecase.ValidateSize(tc.width, tc.height)
 
 			if tc.expectError {
 				if err == nil {
-					t.Errorf("ожидалась ошибка для размера %d, но ошибки нет", tc.size)
+					t.Errorf("ожидалась ошибка для размера %dx%d, но ошибки нет", tc.width, tc.height)
 				}
 				if tc.errorMsg != "" && err != nil {
 					if !strings.Contains(err.Error(), tc.errorMsg) {
@@ -94,60 +123,71 @@ func TestBoardUsecase_ValidateSize(t *testing.T) {
 				}
 			} else {
 				if err != nil {
-					t.Errorf("неожиданная ошибка для размера %d: %v", tc.size, err)
+					t.Errorf("неожиданная ошибка для размера %dx%d: %v", tc.width, tc.height, err)
 				}
 			}
 		})
 	}
 }
 
+// mustCreateBoard создает доску через сервис или прерывает тест
+func mustCreateBoard(t *testing.T, service domain.BoardService, width, height int, setup domain.Setup) *domain.Board {
+	t.Helper()
+
+	board, err := service.CreateBoard(width, height, setup)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка для доски %dx%d: %v", width, height, err)
+	}
+	return board
+}
+
 func TestBoardUsecase_CreateBoard(t *testing.T) {
 	t.Run("создание доски с валидным размером", func(t *testing.T) {
-		repo := &MockBoardRepository{}
-		usecase := NewBoardUsecase(repo)
+		usecase := NewBoardUsecase(&MockBoardRepository{})
 
-		board := usecase.CreateBoard(8)
+		board := mustCreateBoard(t, usecase, 8, 8, domain.SetupEmpty)
 
-		if board == nil {
-			t.Error("CreateBoard вернул nil")
-			return
-		}
-		if board.Size != 8 {
-			t.Errorf("ожидался размер доски 8, получен %d", board.Size)
+		if board.Width != 8 || board.Height != 8 {
+			t.Errorf("ожидался размер доски 8x8, получен %dx%d", board.Width, board.Height)
 		}
 	})
 
-	t.Run("создание доски с невалидным размером - использует размер по умолчанию", func(t *testing.T) {
-		repo := &MockBoardRepository{}
-		usecase := NewBoardUsecase(repo)
+	t.Run("создание прямоугольной доски", func(t *testing.T) {
+		usecase := NewBoardUsecase(&MockBoardRepository{})
 
-		board := usecase.CreateBoard(0) // Невалидный размер
+		board := mustCreateBoard(t, usecase, 9, 10, domain.SetupEmpty)
 
-		if board == nil {
-			t.Error("CreateBoard вернул nil")
-			return
-		}
-		if board.Size != domain.DefaultBoardSize {
-			t.Errorf("при невалидном размере ожидался размер по умолчанию %d, получен %d",
-				domain.DefaultBoardSize, board.Size)
+		if board.Width != 9 || board.Height != 10 {
+			t.Errorf("ожидался размер доски 9x10, получен %dx%d", board.Width, board.Height)
 		}
 	})
 
-	t.Run("создание доски с слишком большим размером - использует размер по умолчанию", func(t *testing.T) {
-		repo := &MockBoardRepository{}
-		usecase := NewBoardUsecase(repo)
-
-		board := usecase.CreateBoard(domain.MaxBoardSize + 10)
+	errorCases := []struct {
+		name          string
+		width, height int
+		errorMsg      string
+	}{
+		{"слишком маленькая доска", 0, 0, "размер доски не может быть меньше 4 (ширина 0)"},
+		{"слишком большая доска", domain.MaxBoardSize + 10, 8, "размер доски не может превышать"},
+		{"неверная высота", 8, 2, "(высота 2)"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			usecase := NewBoardUsecase(&MockBoardRepository{})
 
-		if board == nil {
-			t.Error("CreateBoard вернул nil")
-			return
-		}
-		if board.Size != domain.DefaultBoardSize {
-			t.Errorf("при невалидном размере ожидался размер по умолчанию %d, получен %d",
-				domain.DefaultBoardSize, board.Size)
-		}
-	})
+			board, err := usecase.CreateBoard(tc.width, tc.height, domain.SetupEmpty)
+			if err == nil || !strings.Contains(err.Error(), tc.errorMsg) {
+				t.Errorf("ожидалась ошибка с текстом '%s', получено: %v", tc.errorMsg, err)
+			}
+			if board != nil {
+				t.Errorf("при ошибке доска не создается, получено %dx%d", board.Width, board.Height)
+			}
+			// Неудачный вызов не подменяет последнюю доску доской по умолчанию
+			if pattern := usecase.GeneratePattern(); pattern != "" {
+				t.Errorf("последняя доска не должна меняться, получено:\n%s", pattern)
+			}
+		})
+	}
 }
 
 func TestGenerateChessboard(t *testing.T) {
@@ -159,31 +199,37 @@ func TestGenerateChessboard(t *testing.T) {
 	}{
 		{
 			name:     "доска 1x1",
-			board:    &domain.Board{Size: 1},
+			board:    &domain.Board{Width: 1, Height: 1},
 			expected: " ",
 			desc:     "одна белая клетка",
 		},
 		{
 			name:     "доска 2x2",
-			board:    &domain.Board{Size: 2},
+			board:    &domain.Board{Width: 2, Height: 2},
 			expected: " #\n# ",
 			desc:     "правильное чередование 2x2",
 		},
 		{
 			name:     "доска 3x3",
-			board:    &domain.Board{Size: 3},
+			board:    &domain.Board{Width: 3, Height: 3},
 			expected: " # \n# #\n # ",
 			desc:     "правильное чередование 3x3",
 		},
 		{
 			name:     "доска 4x4",
-			board:    &domain.Board{Size: 4},
+			board:    &domain.Board{Width: 4, Height: 4},
 			expected: " # #\n# # \n # #\n# # ",
 			desc:     "правильное чередование 4x4",
 		},
+		{
+			name:     "доска 3x2",
+			board:    &domain.Board{Width: 3, Height: 2},
+			expected: " # \n# #",
+			desc:     "прямоугольная доска",
+		},
 		{
 			name:     "доска 8x8",
-			board:    &domain.Board{Size: 8},
+			board:    &domain.Board{Width: 8, Height: 8},
 			expected: " # # # #\n# # # # \n # # # #\n# # # # \n # # # #\n# # # # \n # # # #\n# # # # ",
 			desc:     "стандартная шахматная доска",
 		},
@@ -195,11 +241,11 @@ func TestGenerateChessboard(t *testing.T) {
 
 			if result != tc.expected {
 				t.Errorf("для доски %dx%d:\nожидалось:\n%s\n\nполучено:\n%s\n",
-					tc.board.Size, tc.board.Size, tc.expected, result)
+					tc.board.Width, tc.board.Height, tc.expected, result)
 			}
 
 			// Дополнительная проверка: количество символов новой строки
-			expectedNewlines := tc.board.Size - 1
+			expectedNewlines := tc.board.Height - 1
 			actualNewlines := strings.Count(result, "\n")
 			if actualNewlines != expectedNewlines {
 				t.Errorf("неправильное количество новых строк: ожидалось %d, получено %d",
@@ -207,7 +253,7 @@ func TestGenerateChessboard(t *testing.T) {
 			}
 
 			// Проверка общего количества символов (без учета \n)
-			expectedChars := tc.board.Size * tc.board.Size
+			expectedChars := tc.board.Width * tc.board.Height
 			actualChars := len(result) - actualNewlines
 			if actualChars != expectedChars {
 				t.Errorf("неправильное количество символов: ожидалось %d, получено %d",
@@ -219,7 +265,7 @@ func TestGenerateChessboard(t *testing.T) {
 
 func TestGenerateChessboard_EdgeCases(t *testing.T) {
 	t.Run("нулевая доска", func(t *testing.T) {
-		board := &domain.Board{Size: 0}
+		board := &domain.Board{Width: 0, Height: 0}
 		result := GenerateChessboard(board)
 
 		if result != "" {
@@ -228,7 +274,7 @@ func TestGenerateChessboard_EdgeCases(t *testing.T) {
 	})
 
 	t.Run("очень большая доска", func(t *testing.T) {
-		board := &domain.Board{Size: 100}
+		board := &domain.Board{Width: 100, Height: 100}
 		result := GenerateChessboard(board)
 
 		// Проверяем, что строка не пустая и имеет правильную структуру
@@ -269,7 +315,7 @@ func TestGenerateChessboard_Pattern(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run("проверка паттерна", func(t *testing.T) {
-			board := &domain.Board{Size: tc.size}
+			board := &domain.Board{Width: tc.size, Height: tc.size}
 			result := GenerateChessboard(board)
 			lines := strings.Split(result, "\n")
 
@@ -292,38 +338,162 @@ func TestNewBoardRepository(t *testing.T) {
 	}
 
 	// Проверяем, что репозиторий работает
-	board := repo.GenerateBoard(8)
+	board := repo.GenerateBoard(10, 8, domain.SetupEmpty)
 	if board == nil {
 		t.Error("GenerateBoard вернул nil")
 		return
 	}
-	if board.Size != 8 {
-		t.Errorf("ожидался размер доски 8, получен %d", board.Size)
+	if board.Width != 10 || board.Height != 8 {
+		t.Errorf("ожидался размер доски 10x8, получен %dx%d", board.Width, board.Height)
+	}
+}
+
+func TestBoardUsecase_CreateBoard_Setup(t *testing.T) {
+	usecase := NewBoardUsecase(NewBoardRepository())
+
+	t.Run("стандартная расстановка на 8x8", func(t *testing.T) {
+		board := mustCreateBoard(t, usecase, 8, 8, domain.SetupStandard)
+
+		if board.PieceAt(4, 0) != (domain.Piece{Color: domain.White, Kind: domain.King}) {
+			t.Errorf("на e1 ожидался белый король, получено %v", board.PieceAt(4, 0))
+		}
+		if board.PieceAt(3, 7) != (domain.Piece{Color: domain.Black, Kind: domain.Queen}) {
+			t.Errorf("на d8 ожидался черный ферзь, получено %v", board.PieceAt(3, 7))
+		}
+	})
+
+	t.Run("стандартная расстановка невозможна на 10x8", func(t *testing.T) {
+		_, err := usecase.CreateBoard(10, 8, domain.SetupStandard)
+
+		if err == nil || !strings.Contains(err.Error(), "только на доске 8x8") {
+			t.Errorf("ожидалась ошибка о невозможной расстановке, получено: %v", err)
+		}
+	})
+
+	t.Run("пустая расстановка", func(t *testing.T) {
+		if board := mustCreateBoard(t, usecase, 8, 8, domain.SetupEmpty); board.HasPieces() {
+			t.Error("доска без расстановки не должна содержать фигур")
+		}
+	})
+}
+
+func TestBoardUsecase_LoadFEN(t *testing.T) {
+	t.Run("корректная позиция запоминается", func(t *testing.T) {
+		usecase := NewBoardUsecase(&MockBoardRepository{})
+
+		board, err := usecase.LoadFEN("4k3/8/8/8/8/8/8/4K3 w - - 0 1")
+		if err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		if board.PieceAt(4, 7) != (domain.Piece{Color: domain.Black, Kind: domain.King}) {
+			t.Errorf("на e8 ожидался черный король, получено %v", board.PieceAt(4, 7))
+		}
+		if pattern := usecase.GeneratePattern(); !strings.Contains(pattern, "k") {
+			t.Errorf("позиция из FEN должна стать последней доской, получено:\n%s", pattern)
+		}
+	})
+
+	testCases := []struct {
+		name     string
+		fen      string
+		errorMsg string
+	}{
+		{"ошибка разбора", "8/7/8/8 w - - 0 1", "горизонталь 2 содержит 7 клеток, ожидалось 8"},
+		{"слишком маленькая доска", "k2/3/2K w - - 0 1", "размер доски не может быть меньше 4"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			usecase := NewBoardUsecase(&MockBoardRepository{})
+
+			_, err := usecase.LoadFEN(tc.fen)
+			if err == nil || !strings.Contains(err.Error(), tc.errorMsg) {
+				t.Errorf("ожидалась ошибка с текстом '%s', получено: %v", tc.errorMsg, err)
+			}
+		})
 	}
 }
 
 func TestBoardUsecase_GeneratePattern(t *testing.T) {
-	repo := &MockBoardRepository{}
-	usecase := NewBoardUsecase(repo)
+	t.Run("без созданной доски", func(t *testing.T) {
+		usecase := NewBoardUsecase(&MockBoardRepository{})
 
-	pattern := usecase.GeneratePattern()
+		if pattern := usecase.GeneratePattern(); pattern != "" {
+			t.Errorf("ожидалась пустая строка, получено: '%s'", pattern)
+		}
+	})
+
+	t.Run("последняя доска с узором по умолчанию", func(t *testing.T) {
+		usecase := NewBoardUsecase(&MockBoardRepository{})
+		mustCreateBoard(t, usecase, 6, 6, domain.SetupEmpty)
+		board := mustCreateBoard(t, usecase, 4, 4, domain.SetupEmpty)
+
+		pattern := usecase.GeneratePattern()
+		if expected := GenerateChessboard(board); pattern != expected {
+			t.Errorf("ожидалось:\n%s\nполучено:\n%s", expected, pattern)
+		}
+	})
+
+	t.Run("выбранный узор", func(t *testing.T) {
+		usecase := NewBoardUsecase(&MockBoardRepository{})
+		if err := usecase.SetPattern("stripes"); err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		mustCreateBoard(t, usecase, 4, 4, domain.SetupEmpty)
+
+		expected := "    \n####\n    \n####"
+		if pattern := usecase.GeneratePattern(); pattern != expected {
+			t.Errorf("ожидалось:\n%s\nполучено:\n%s", expected, pattern)
+		}
+	})
+}
+
+func TestBoardUsecase_SetPattern(t *testing.T) {
+	usecase := NewBoardUsecase(&MockBoardRepository{})
+
+	if err := usecase.SetPattern("rings"); err != nil {
+		t.Errorf("неожиданная ошибка для известного узора: %v", err)
+	}
+	if err := usecase.SetPattern("zigzag"); err == nil {
+		t.Error("ожидалась ошибка для неизвестного узора")
+	}
+
+	names := usecase.Patterns()
+	if !strings.Contains(strings.Join(names, ","), "rings") {
+		t.Errorf("список узоров должен содержать 'rings', получено %v", names)
+	}
+}
+
+func TestNewBoardUsecaseWithPatterns(t *testing.T) {
+	registry := NewPatternRegistry()
+	if err := registry.Register("border", PredicatePattern(func(row, col int) bool {
+		return row == 0 || col == 0
+	})); err != nil {
+		t.Fatalf("неожиданная ошибка регистрации: %v", err)
+	}
+
+	usecase := NewBoardUsecaseWithPatterns(&MockBoardRepository{}, registry)
+	if err := usecase.SetPattern("border"); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	mustCreateBoard(t, usecase, 4, 4, domain.SetupEmpty)
 
-	if pattern != "" {
-		t.Errorf("ожидалась пустая строка, получено: '%s'", pattern)
+	expected := "####\n#   \n#   \n#   "
+	if pattern := usecase.GeneratePattern(); pattern != expected {
+		t.Errorf("ожидалось:\n%s\nполучено:\n%s", expected, pattern)
 	}
 }
 
 // Бенчмарк тесты
 func BenchmarkGenerateChessboard(b *testing.B) {
 	boards := []*domain.Board{
-		{Size: 8},
-		{Size: 16},
-		{Size: 64},
-		{Size: 100},
+		{Width: 8, Height: 8},
+		{Width: 16, Height: 16},
+		{Width: 64, Height: 64},
+		{Width: 100, Height: 100},
 	}
 
 	for _, board := range boards {
-		b.Run(benchmarkName(board.Size), func(b *testing.B) {
+		b.Run(benchmarkName(board.Width), func(b *testing.B) {
 			for i := 0; i < b.N; i++ {
 				GenerateChessboard(board)
 			}
@@ -336,18 +506,18 @@ func BenchmarkValidateSize(b *testing.B) {
 	usecase := NewBoardUsecase(repo)
 
 	// Предварительная проверка что ошибок не будет
-	if err := usecase.ValidateSize(8); err != nil {
+	if err := usecase.ValidateSize(8, 8); err != nil {
 		b.Skipf("skipping benchmark due to setup error: %v", err)
 		return
 	}
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = usecase.ValidateSize(8)
+		_ = usecase.ValidateSize(8, 8)
 	}
 }
 
 // Вспомогательная функция для именования бенчмарков
 func benchmarkName(size int) string {
-	return string(rune(size)) + "x" + string(rune(size))
+	return strconv.Itoa(size) + "x" + strconv.Itoa(size)
 }
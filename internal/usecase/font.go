@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"image"
+	"image/draw"
+)
+
+const (
+	glyphWidth  = 5
+	glyphHeight = 7
+	// glyphSpacing - промежуток между символами в пикселях шрифта
+	glyphSpacing = 1
+)
+
+// glyphs - встроенный растровый шрифт 5x7 для координатных меток и фигур:
+// строчные латинские буквы, цифры и заглавные буквы фигур KQRBNP.
+// Символ '#' - закрашенный пиксель.
+var glyphs = map[rune][glyphHeight]string{
+	'0': {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'1': {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2': {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3': {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	'4': {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5': {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6': {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7': {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8': {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9': {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+	'B': {"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####."},
+	'K': {"#...#", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "#...#"},
+	'N': {"#...#", "#...#", "##..#", "#.#.#", "#..##", "#...#", "#...#"},
+	'P': {"####.", "#...#", "#...#", "####.", "#....", "#....", "#...."},
+	'Q': {".###.", "#...#", "#...#", "#...#", "#.#.#", "#..#.", ".##.#"},
+	'R': {"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#"},
+	'a': {".....", ".....", ".###.", "....#", ".####", "#...#", ".####"},
+	'b': {"#....", "#....", "#.##.", "##..#", "#...#", "#...#", "####."},
+	'c': {".....", ".....", ".###.", "#....", "#....", "#...#", ".###."},
+	'd': {"....#", "....#", ".##.#", "#..##", "#...#", "#...#", ".####"},
+	'e': {".....", ".....", ".###.", "#...#", "#####", "#....", ".###."},
+	'f': {"..##.", ".#..#", ".#...", "###..", ".#...", ".#...", ".#..."},
+	'g': {".....", ".####", "#...#", "#...#", ".####", "....#", ".###."},
+	'h': {"#....", "#....", "#.##.", "##..#", "#...#", "#...#", "#...#"},
+	'i': {"..#..", ".....", ".##..", "..#..", "..#..", "..#..", ".###."},
+	'j': {"...#.", ".....", "..##.", "...#.", "...#.", "#..#.", ".##.."},
+	'k': {"#....", "#....", "#..#.", "#.#..", "##...", "#.#..", "#..#."},
+	'l': {".##..", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'm': {".....", ".....", "##.#.", "#.#.#", "#.#.#", "#...#", "#...#"},
+	'n': {".....", ".....", "#.##.", "##..#", "#...#", "#...#", "#...#"},
+	'o': {".....", ".....", ".###.", "#...#", "#...#", "#...#", ".###."},
+	'p': {".....", ".....", "####.", "#...#", "####.", "#....", "#...."},
+	'q': {".....", ".....", ".##.#", "#..##", ".####", "....#", "....#"},
+	'r': {".....", ".....", "#.##.", "##..#", "#....", "#....", "#...."},
+	's': {".....", ".....", ".###.", "#....", ".###.", "....#", "####."},
+	't': {".#...", ".#...", "###..", ".#...", ".#...", ".#..#", "..##."},
+	'u': {".....", ".....", "#...#", "#...#", "#...#", "#..##", ".##.#"},
+	'v': {".....", ".....", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'w': {".....", ".....", "#...#", "#...#", "#.#.#", "#.#.#", ".#.#."},
+	'x': {".....", ".....", "#...#", ".#.#.", "..#..", ".#.#.", "#...#"},
+	'y': {".....", ".....", "#...#", "#...#", ".####", "....#", ".###."},
+	'z': {".....", ".....", "#####", "...#.", "..#..", ".#...", "#####"},
+}
+
+// textSize возвращает размер строки в пикселях при заданном масштабе шрифта
+func textSize(text string, scale int) (int, int) {
+	n := len([]rune(text))
+	if n == 0 {
+		return 0, 0
+	}
+	return (n*(glyphWidth+glyphSpacing) - glyphSpacing) * scale, glyphHeight * scale
+}
+
+// drawText рисует строку встроенным шрифтом с центром в точке (cx, cy).
+// Символы, отсутствующие в шрифте, пропускаются с сохранением интервала.
+func drawText(dst draw.Image, text string, cx, cy, scale int, src image.Image) {
+	width, height := textSize(text, scale)
+	x0, y0 := cx-width/2, cy-height/2
+
+	for i, ch := range []rune(text) {
+		glyph, ok := glyphs[ch]
+		if !ok {
+			continue
+		}
+		gx := x0 + i*(glyphWidth+glyphSpacing)*scale
+		for row, line := range glyph {
+			for col := 0; col < glyphWidth; col++ {
+				if line[col] != '#' {
+					continue
+				}
+				pixel := image.Rect(gx+col*scale, y0+row*scale, gx+(col+1)*scale, y0+(row+1)*scale)
+				draw.Draw(dst, pixel, src, image.Point{}, draw.Src)
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"chessboard/internal/domain"
+)
+
+func TestGlyphs_WellFormed(t *testing.T) {
+	for ch, glyph := range glyphs {
+		for row, line := range glyph {
+			if len(line) != glyphWidth {
+				t.Errorf("символ '%c', строка %d: ожидалась ширина %d, получено %d", ch, row, glyphWidth, len(line))
+			}
+			for _, pixel := range line {
+				if pixel != '.' && pixel != '#' {
+					t.Errorf("символ '%c', строка %d: недопустимый пиксель '%c'", ch, row, pixel)
+				}
+			}
+		}
+	}
+}
+
+func TestGlyphs_CoverCoordinates(t *testing.T) {
+	// Шрифт должен покрывать все символы, встречающиеся в координатных метках
+	for _, ch := range domain.FileName(25) + "abcdefghijklmnopqrstuvwxy0123456789" {
+		if _, ok := glyphs[ch]; !ok {
+			t.Errorf("в шрифте нет символа '%c'", ch)
+		}
+	}
+}
+
+func TestGlyphs_CoverPieces(t *testing.T) {
+	// В графических форматах фигуры обозначаются заглавными буквами
+	for _, ch := range "KQRBNP" {
+		if _, ok := glyphs[ch]; !ok {
+			t.Errorf("в шрифте нет символа '%c'", ch)
+		}
+	}
+}
+
+func TestTextSize(t *testing.T) {
+	testCases := []struct {
+		text          string
+		scale         int
+		width, height int
+	}{
+		{"", 1, 0, 0},
+		{"a", 1, 5, 7},
+		{"10", 1, 11, 7},
+		{"ab", 3, 33, 21},
+	}
+
+	for _, tc := range testCases {
+		width, height := textSize(tc.text, tc.scale)
+		if width != tc.width || height != tc.height {
+			t.Errorf("'%s' x%d: ожидалось %dx%d, получено %dx%d",
+				tc.text, tc.scale, tc.width, tc.height, width, height)
+		}
+	}
+}
+
+func TestDrawText(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 5, 7))
+	drawText(img, "1", 2, 3, 1, image.NewUniform(color.White))
+
+	for row, line := range glyphs['1'] {
+		for col := 0; col < glyphWidth; col++ {
+			lit := img.GrayAt(col, row).Y != 0
+			if lit != (line[col] == '#') {
+				t.Errorf("пиксель (%d,%d) отрисован неверно", col, row)
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+// game - партия на позиции для генерации ходов. Для каждого хода хранятся данные
+// для его отмены, а для каждой позиции - хеш Зобриста, по которому ищутся повторения.
+type game struct {
+	pos     *position
+	history []playedMove
+	// keys - хеши начальной позиции и позиций после каждого хода
+	keys []uint64
+}
+
+// playedMove - сыгранный ход и данные для его отмены
+type playedMove struct {
+	move move
+	undo undo
+}
+
+// NewGame начинает партию с позиции на доске
+func NewGame(board *domain.Board) (domain.Game, error) {
+	p, err := newPosition(board)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return &game{pos: p, keys: []uint64{p.positionKey()}}, nil
+}
+
+func (g *game) Board() *domain.Board {
+	return g.pos.board()
+}
+
+func (g *game) Moves() []domain.Move {
+	moves := make([]domain.Move, len(g.history))
+	for i, played := range g.history {
+		moves[i] = g.pos.domainMove(played.move)
+	}
+	return moves
+}
+
+func (g *game) LegalMoves() []domain.Move {
+	return g.pos.domainMoves(g.pos.legalMoves(nil))
+}
+
+func (g *game) Play(m domain.Move) error {
+	for _, candidate := range g.pos.legalMoves(nil) {
+		if g.pos.domainMove(candidate) != m {
+			continue
+		}
+		u := g.pos.makeMove(candidate)
+		g.history = append(g.history, playedMove{move: candidate, undo: u})
+		g.keys = append(g.keys, g.pos.positionKey())
+		return nil
+	}
+	return i18n.Errorf("error.with_value", domain.ErrIllegalMove, m)
+}
+
+func (g *game) Undo() (domain.Move, bool) {
+	if len(g.history) == 0 {
+		return domain.Move{}, false
+	}
+	last := g.history[len(g.history)-1]
+	g.pos.unmakeMove(last.move, last.undo)
+	g.history = g.history[:len(g.history)-1]
+	g.keys = g.keys[:len(g.keys)-1]
+	return g.pos.domainMove(last.move), true
+}
+
+func (g *game) Repetitions() int {
+	current := len(g.keys) - 1
+	count := 1
+	// После взятия или хода пешкой прежние позиции повториться не могут, поэтому
+	// просматриваются только последние halfmove позиций с той же очередью хода
+	limit := min(g.pos.halfmove, current)
+	for back := 2; back <= limit; back += 2 {
+		if g.keys[current-back] == g.keys[current] {
+			count++
+		}
+	}
+	return count
+}
+
+func (g *game) Status() domain.GameStatus {
+	return g.pos.status(g.Repetitions())
+}
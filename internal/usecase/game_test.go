@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/usecase/usecasetest"
+)
+
+// playMoves выполняет ходы, записанные через пробел в виде "e2e4"
+func playMoves(t *testing.T, game domain.Game, moves string) {
+	t.Helper()
+
+	for _, name := range strings.Fields(moves) {
+		var found bool
+		for _, m := range game.LegalMoves() {
+			if m.String() == name {
+				if err := game.Play(m); err != nil {
+					t.Fatalf("ход %s: %v", name, err)
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("ход %s не найден в позиции %s", name, game.Board().FEN())
+		}
+	}
+}
+
+// knightShuffle - ходы конями туда и обратно, возвращающие начальную позицию
+const knightShuffle = "g1f3 g8f6 f3g1 f6g8 "
+
+func TestGame_Repetitions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fen      string
+		moves    string
+		expected int
+	}{
+		{"без ходов", domain.StartFEN, "", 1},
+		{"повтор после маневра конями", domain.StartFEN, knightShuffle, 2},
+		{"троекратное повторение", domain.StartFEN, strings.Repeat(knightShuffle, 2), 3},
+		{"другая очередь хода", domain.StartFEN, "g1f3 g8f6 f3g1 f6g8 g1f3", 2},
+		{
+			// После перемещения королей права на рокировку потеряны, и позиция другая
+			name:     "потеря прав на рокировку",
+			fen:      "r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1",
+			moves:    "e1f1 e8f8 f1e1 f8e8",
+			expected: 1,
+		},
+		{
+			// Черные могли взять на проходе, поэтому первая позиция не равна следующим
+			name:     "возможное взятие на проходе",
+			fen:      "4k3/8/8/8/3pP3/8/8/4K3 b - e3 0 1",
+			moves:    "e8e7 e1e2 e7e8 e2e1 e8e7 e1e2 e7e8 e2e1",
+			expected: 2,
+		},
+		{
+			// Клетка взятия указана, но взять на проходе некому
+			name:     "невозможное взятие на проходе",
+			fen:      "4k3/8/8/8/4P3/8/8/4K3 b - e3 0 1",
+			moves:    "e8e7 e1e2 e7e8 e2e1",
+			expected: 2,
+		},
+		{
+			// Взятие на проходе открыло бы линию ладьи на своего короля
+			name:     "связанная пешка не берет на проходе",
+			fen:      "8/8/8/8/k2pP2R/8/8/4K3 b - e3 0 1",
+			moves:    "a4a5 e1e2 a5a4 e2e1",
+			expected: 2,
+		},
+		{
+			name:     "ход пешкой делает повторение невозможным",
+			fen:      "4k3/8/8/8/8/8/4P3/4K3 w - - 0 1",
+			moves:    "e1d1 e8d8 d1e1 d8e8 e2e3 e8d8 e1d1 d8e8 d1e1",
+			expected: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			game := usecasetest.MustGame(t, NewGame, tc.fen)
+			playMoves(t, game, tc.moves)
+
+			if actual := game.Repetitions(); actual != tc.expected {
+				t.Errorf("ожидалось %d повторений, получено %d", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGame_RepetitionDraws(t *testing.T) {
+	game := usecasetest.MustGame(t, NewGame, domain.StartFEN)
+
+	playMoves(t, game, strings.Repeat(knightShuffle, 2))
+	status := game.Status()
+	if !status.ThreefoldRepetition || status.IsOver() || !status.CanClaimDraw() {
+		t.Errorf("после троекратного повторения можно потребовать ничью, получено %+v", status)
+	}
+
+	playMoves(t, game, strings.Repeat(knightShuffle, 2))
+	status = game.Status()
+	if !status.FivefoldRepetition || status.Result != domain.ResultDraw {
+		t.Errorf("после пятикратного повторения ожидалась ничья, получено %+v", status)
+	}
+}
+
+func TestGame_MoveCountDraws(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fen      string
+		moves    string
+		expected domain.GameStatus
+	}{
+		{
+			name:     "49 ходов без взятий",
+			fen:      "4k3/8/8/8/8/8/8/R3K3 w - - 98 80",
+			moves:    "a1a2",
+			expected: domain.GameStatus{SideToMove: domain.Black},
+		},
+		{
+			name:     "правило 50 ходов",
+			fen:      "4k3/8/8/8/8/8/8/R3K3 w - - 99 80",
+			moves:    "a1a2",
+			expected: domain.GameStatus{SideToMove: domain.Black, FiftyMoves: true},
+		},
+		{
+			name:  "правило 75 ходов",
+			fen:   "4k3/8/8/8/8/8/8/R3K3 w - - 149 100",
+			moves: "a1a2",
+			expected: domain.GameStatus{
+				SideToMove: domain.Black, FiftyMoves: true, SeventyFiveMoves: true, Result: domain.ResultDraw,
+			},
+		},
+		{
+			name:     "взятие сбрасывает счетчик",
+			fen:      "4k3/8/8/8/8/8/4K3/r6R w - - 149 100",
+			moves:    "h1a1",
+			expected: domain.GameStatus{SideToMove: domain.Black},
+		},
+		{
+			name:  "мат последним ходом важнее правила 75 ходов",
+			fen:   "k7/8/1K6/8/8/8/8/7R w - - 149 100",
+			moves: "h1h8",
+			expected: domain.GameStatus{
+				SideToMove: domain.Black, InCheck: true, Checkmate: true,
+				FiftyMoves: true, SeventyFiveMoves: true, Result: domain.ResultWhiteWins,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			game := usecasetest.MustGame(t, NewGame, tc.fen)
+			playMoves(t, game, tc.moves)
+
+			if status := game.Status(); status != tc.expected {
+				t.Errorf("ожидалось %+v, получено %+v", tc.expected, status)
+			}
+		})
+	}
+}
+
+func TestGame_PlayAndUndo(t *testing.T) {
+	game := usecasetest.MustGame(t, NewGame, domain.StartFEN)
+
+	if _, ok := game.Undo(); ok {
+		t.Error("в партии без ходов отменять нечего")
+	}
+
+	playMoves(t, game, "e2e4 e7e5")
+	if fen := game.Board().FEN(); fen != "rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2" {
+		t.Errorf("неожиданная позиция после ходов: %q", fen)
+	}
+	if moves := moveNames(game.Moves()); strings.Join(moves, " ") != "e2e4 e7e5" {
+		t.Errorf("ожидались ходы e2e4 e7e5, получено %v", moves)
+	}
+
+	undone, ok := game.Undo()
+	if !ok || undone.String() != "e7e5" {
+		t.Errorf("ожидалась отмена хода e7e5, получено %v, %v", undone, ok)
+	}
+	if fen := game.Board().FEN(); fen != "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1" {
+		t.Errorf("неожиданная позиция после отмены: %q", fen)
+	}
+
+	t.Run("нелегальный ход", func(t *testing.T) {
+		before := game.Board().FEN()
+		illegal := domain.Move{From: domain.Square{File: 4, Rank: 6}, To: domain.Square{File: 4, Rank: 3}}
+
+		err := game.Play(illegal)
+		if err == nil || !strings.Contains(err.Error(), "недопустимый ход: 'e7e4'") {
+			t.Errorf("ожидалась ошибка о недопустимом ходе, получено: %v", err)
+		}
+		if after := game.Board().FEN(); after != before {
+			t.Errorf("нелегальный ход изменил позицию: %q", after)
+		}
+	})
+
+	t.Run("отмена восстанавливает повторения", func(t *testing.T) {
+		game := usecasetest.MustGame(t, NewGame, domain.StartFEN)
+		playMoves(t, game, knightShuffle)
+		game.Undo()
+		game.Undo()
+		if actual := game.Repetitions(); actual != 1 {
+			t.Errorf("ожидалось 1 повторение, получено %d", actual)
+		}
+	})
+}
+
+func TestNewGame_Errors(t *testing.T) {
+	board, err := domain.ParseFEN("4k3/4R3/8/8/8/8/8/4K3 w - - 0 1")
+	if err != nil {
+		t.Fatalf("неверный FEN: %v", err)
+	}
+
+	_, err = NewBoardUsecase(NewBoardRepository()).NewGame(board)
+	if err == nil || !strings.Contains(err.Error(), "король черных под шахом") {
+		t.Errorf("ожидалась ошибка о шахе стороне без хода, получено: %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+const (
+	// JSONSchema - название схемы описания доски в формате json
+	JSONSchema = "chessboard/board"
+	// JSONSchemaVersion - версия схемы. Версия увеличивается при несовместимых
+	// изменениях; новые необязательные поля добавляются без смены версии, и
+	// читатель должен пропускать незнакомые поля.
+	JSONSchemaVersion = 1
+)
+
+// maxJSONSquares ограничивает число клеток доски с фигурами, читаемой из JSON.
+// Пустая доска не хранит клеток и может быть любого допустимого размера, а для
+// расстановки, как и для FEN, сетка клеток создается целиком.
+const maxJSONSquares = 1 << 20
+
+// ErrInvalidBoardJSON - описание доски в JSON не соответствует схеме
+var ErrInvalidBoardJSON = i18n.New("json.invalid_board")
+
+// jsonBoard - описание доски в формате json для чтения. Порядок полей совпадает
+// с порядком вывода WriteJSON; клетки перечисляются построчно от a1, как
+// в domain.Board.Squares.
+type jsonBoard struct {
+	Schema  string       `json:"schema"`
+	Version int          `json:"version"`
+	Width   int          `json:"width"`
+	Height  int          `json:"height"`
+	Pattern string       `json:"pattern,omitempty"`
+	FEN     string       `json:"fen,omitempty"`
+	Squares []jsonSquare `json:"squares"`
+}
+
+// jsonSquare - клетка доски: обозначение, номера вертикали и горизонтали с нуля,
+// цвет клетки в узоре ("light" или "dark") и фигура, если клетка занята
+type jsonSquare struct {
+	Square string     `json:"square"`
+	File   int        `json:"file"`
+	Rank   int        `json:"rank"`
+	Color  string     `json:"color"`
+	Piece  *jsonPiece `json:"piece,omitempty"`
+}
+
+// jsonPiece - фигура: цвет ("white" или "black") и тип
+type jsonPiece struct {
+	Color string `json:"color"`
+	Kind  string `json:"kind"`
+}
+
+// pieceKindNames - названия типов фигур в JSON в порядке domain.PieceKind
+var pieceKindNames = [...]string{
+	domain.Pawn:   "pawn",
+	domain.Knight: "knight",
+	domain.Bishop: "bishop",
+	domain.Rook:   "rook",
+	domain.Queen:  "queen",
+	domain.King:   "king",
+}
+
+// WriteJSON потоково записывает описание доски в формате json: схему и ее
+// версию, размер, имя узора из opts.Pattern, позицию FEN, если на доске есть
+// фигуры, и все клетки с цветом и фигурой. Каждая клетка выводится отдельной
+// строкой, поэтому память расходуется только на буфер одной клетки. Цвет клетки
+// определяется узором при взгляде со стороны белых; остальные параметры
+// отрисовки на описание не влияют.
+func WriteJSON(w io.Writer, board *domain.Board, pattern PatternFunc, opts domain.RenderOptions) error {
+	// Заголовок записывается по полям и заканчивается началом массива клеток,
+	// который дописывается по одной клетке
+	header := make([]byte, 0, 256)
+	header = append(header, `{"schema":`...)
+	header = appendJSONString(header, JSONSchema)
+	header = append(header, `,"version":`...)
+	header = strconv.AppendInt(header, JSONSchemaVersion, 10)
+	header = append(header, `,"width":`...)
+	header = strconv.AppendInt(header, int64(board.Width), 10)
+	header = append(header, `,"height":`...)
+	header = strconv.AppendInt(header, int64(board.Height), 10)
+	if opts.Pattern != "" {
+		header = append(header, `,"pattern":`...)
+		header = appendJSONString(header, opts.Pattern)
+	}
+	if board.HasPieces() {
+		header = append(header, `,"fen":`...)
+		header = appendJSONString(header, board.FEN())
+	}
+	header = append(header, `,"squares":[`...)
+
+	out := bufio.NewWriter(w)
+	if _, err := out.Write(header); err != nil {
+		return err
+	}
+
+	square := make([]byte, 0, 128)
+	for rank := 0; rank < board.Height; rank++ {
+		for file := 0; file < board.Width; file++ {
+			square = square[:0]
+			if rank > 0 || file > 0 {
+				square = append(square, ',')
+			}
+			square = append(square, "\n"...)
+			square = appendJSONSquare(square, board, pattern, file, rank)
+			if _, err := out.Write(square); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := out.WriteString("\n]}\n"); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// appendJSONString дописывает к dst строку в JSON с экранированием: имя узора
+// задается пользователем и может содержать любые символы
+func appendJSONString(dst []byte, s string) []byte {
+	// Кодирование строки не возвращает ошибок
+	data, _ := json.Marshal(s)
+	return append(dst, data...)
+}
+
+// appendJSONSquare дописывает к dst клетку в JSON. Обозначения клеток, цвета
+// и типы фигур состоят из латинских букв и цифр, поэтому экранирование не нужно.
+func appendJSONSquare(dst []byte, board *domain.Board, pattern PatternFunc, file, rank int) []byte {
+	dst = append(dst, `{"square":"`...)
+	dst = append(dst, domain.FileName(file)...)
+	dst = append(dst, domain.RankName(rank)...)
+	dst = append(dst, `","file":`...)
+	dst = strconv.AppendInt(dst, int64(file), 10)
+	dst = append(dst, `,"rank":`...)
+	dst = strconv.AppendInt(dst, int64(rank), 10)
+	// Узор считает строки сверху вниз, а горизонтали нумеруются снизу вверх
+	if pattern(board, board.Height-1-rank, file) {
+		dst = append(dst, `,"color":"dark"`...)
+	} else {
+		dst = append(dst, `,"color":"light"`...)
+	}
+	if piece := board.PieceAt(file, rank); !piece.IsEmpty() {
+		dst = append(dst, `,"piece":{"color":"`...)
+		dst = append(dst, piece.Color.String()...)
+		dst = append(dst, `","kind":"`...)
+		dst = append(dst, pieceKindNames[piece.Kind]...)
+		dst = append(dst, `"}`...)
+	}
+	return append(dst, '}')
+}
+
+// ReadJSON читает доску, записанную WriteJSON. Клетки без фигур можно не
+// перечислять; на доске с фигурами не больше maxJSONSquares клеток. Клетка
+// определяется полем square; поля file, rank и color, а также имя узора служат
+// для чтения человеком и программами и при разборе не используются. Если задано поле fen, из него берутся очередь хода, права
+// рокировки, взятие на проходе и счетчики ходов, а расстановка в нем должна
+// совпадать с фигурами на клетках. Ошибки соответствуют ErrInvalidBoardJSON.
+func ReadJSON(r io.Reader) (*domain.Board, error) {
+	var doc jsonBoard
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, invalidBoardJSON(err)
+	}
+	if doc.Schema != JSONSchema {
+		return nil, invalidBoardJSON(i18n.Errorf("json.unknown_schema", doc.Schema, JSONSchema))
+	}
+	if doc.Version < 1 || doc.Version > JSONSchemaVersion {
+		return nil, invalidBoardJSON(i18n.Errorf("json.unsupported_version", doc.Version, JSONSchemaVersion))
+	}
+	if err := domain.ValidateSize(doc.Width, doc.Height); err != nil {
+		return nil, invalidBoardJSON(err)
+	}
+	board := &domain.Board{Width: doc.Width, Height: doc.Height}
+	seen := make(map[domain.Square]bool, len(doc.Squares))
+	for _, entry := range doc.Squares {
+		square, err := domain.ParseSquare(entry.Square)
+		if err != nil {
+			return nil, invalidBoardJSON(err)
+		}
+		if !board.Contains(square.File, square.Rank) {
+			return nil, invalidBoardJSON(i18n.Errorf("json.square_outside", entry.Square, board.Width, board.Height))
+		}
+		if seen[square] {
+			return nil, invalidBoardJSON(i18n.Errorf("json.duplicate_square", entry.Square))
+		}
+		seen[square] = true
+		if entry.Piece == nil {
+			continue
+		}
+		piece, ok := entry.Piece.decode()
+		if !ok {
+			return nil, invalidBoardJSON(i18n.Errorf("json.unknown_piece", entry.Piece.Color, entry.Piece.Kind, entry.Square))
+		}
+		// Первая фигура создает сетку всех клеток доски
+		if len(board.Squares) == 0 && board.Width*board.Height > maxJSONSquares {
+			return nil, invalidBoardJSON(i18n.Errorf("json.board_too_large", board.Width, board.Height, maxJSONSquares))
+		}
+		board.SetPiece(square.File, square.Rank, piece)
+	}
+
+	if doc.FEN == "" {
+		return board, nil
+	}
+	position, err := domain.ParseFEN(doc.FEN)
+	if err != nil {
+		return nil, invalidBoardJSON(err)
+	}
+	if !samePlacement(position, board) {
+		return nil, invalidBoardJSON(i18n.New("json.fen_mismatch"))
+	}
+	return position, nil
+}
+
+// invalidBoardJSON оборачивает причину ошибки разбора, сохраняя ее класс
+func invalidBoardJSON(reason error) error {
+	return i18n.Errorf("json.error", ErrInvalidBoardJSON, reason)
+}
+
+// decode возвращает фигуру по цвету и названию типа
+func (p *jsonPiece) decode() (domain.Piece, bool) {
+	var color domain.Color
+	switch p.Color {
+	case domain.White.String():
+		color = domain.White
+	case domain.Black.String():
+		color = domain.Black
+	default:
+		return domain.Piece{}, false
+	}
+	for kind, name := range pieceKindNames {
+		if name != "" && name == p.Kind {
+			return domain.Piece{Color: color, Kind: domain.PieceKind(kind)}, true
+		}
+	}
+	return domain.Piece{}, false
+}
+
+// samePlacement сообщает, что на досках одного размера стоят одни и те же фигуры
+func samePlacement(a, b *domain.Board) bool {
+	if a.Width != b.Width || a.Height != b.Height {
+		return false
+	}
+	for rank := 0; rank < a.Height; rank++ {
+		for file := 0; file < a.Width; file++ {
+			if a.PieceAt(file, rank) != b.PieceAt(file, rank) {
+				return false
+			}
+		}
+	}
+	return true
+}
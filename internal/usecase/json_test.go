@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+)
+
+func TestWriteJSON_Golden(t *testing.T) {
+	board := &domain.Board{Width: 4, Height: 4}
+	board.SetPiece(0, 0, domain.Piece{Color: domain.White, Kind: domain.King})
+	board.SetPiece(3, 3, domain.Piece{Color: domain.Black, Kind: domain.King})
+	board.FullmoveNumber = 1
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, board, CheckerPattern, domain.RenderOptions{Pattern: "checker"}); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	assertGolden(t, "board_4x4.json", buf.Bytes())
+}
+
+func TestWriteJSON_Schema(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, &domain.Board{Width: 5, Height: 4}, CheckerPattern, domain.RenderOptions{}); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	var doc jsonBoard
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("вывод должен быть корректным JSON: %v\n%s", err, buf.String())
+	}
+	if doc.Schema != JSONSchema || doc.Version != JSONSchemaVersion || doc.Width != 5 || doc.Height != 4 {
+		t.Errorf("неверный заголовок: %+v", doc)
+	}
+	if doc.FEN != "" || doc.Pattern != "" {
+		t.Errorf("у пустой доски без имени узора не должно быть полей fen и pattern: %+v", doc)
+	}
+	if len(doc.Squares) != 20 {
+		t.Fatalf("ожидалось 20 клеток, получено %d", len(doc.Squares))
+	}
+
+	// Клетки идут построчно от a1; a1 темная, как в текстовом выводе
+	testCases := []struct {
+		index  int
+		square jsonSquare
+	}{
+		{0, jsonSquare{Square: "a1", File: 0, Rank: 0, Color: "dark"}},
+		{1, jsonSquare{Square: "b1", File: 1, Rank: 0, Color: "light"}},
+		{5, jsonSquare{Square: "a2", File: 0, Rank: 1, Color: "light"}},
+		{19, jsonSquare{Square: "e4", File: 4, Rank: 3, Color: "light"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.square.Square, func(t *testing.T) {
+			if got := doc.Squares[tc.index]; !reflect.DeepEqual(got, tc.square) {
+				t.Errorf("ожидалась клетка %+v, получено %+v", tc.square, got)
+			}
+		})
+	}
+}
+
+func TestWriteJSON_Pattern(t *testing.T) {
+	// Цвет клетки берется из узора: у полос все клетки одной горизонтали одного цвета
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, &domain.Board{Width: 4, Height: 4}, StripesPattern, domain.RenderOptions{}); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	var doc jsonBoard
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("вывод должен быть корректным JSON: %v", err)
+	}
+	for _, square := range doc.Squares {
+		if square.Color != doc.Squares[square.Rank*4].Color {
+			t.Errorf("клетка %s должна быть цвета %s", square.Square, doc.Squares[square.Rank*4].Color)
+		}
+	}
+}
+
+func TestWriteJSON_Header(t *testing.T) {
+	board := &domain.Board{Width: 4, Height: 4}
+	board.SetPiece(0, 0, domain.Piece{Color: domain.White, Kind: domain.King})
+
+	testCases := []struct {
+		name     string
+		board    *domain.Board
+		pattern  string
+		expected string
+	}{
+		{"без узора и фигур", &domain.Board{Width: 4, Height: 5}, "",
+			`{"schema":"chessboard/board","version":1,"width":4,"height":5,"squares":[`},
+		{"с узором и позицией", board, "rings",
+			`{"schema":"chessboard/board","version":1,"width":4,"height":4,"pattern":"rings","fen":"4/4/4/K3 w - - 0 1","squares":[`},
+		{"узор с кавычками", &domain.Board{Width: 4, Height: 4}, `mask:"#"`,
+			`{"schema":"chessboard/board","version":1,"width":4,"height":4,"pattern":"mask:\"#\"","squares":[`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteJSON(&buf, tc.board, CheckerPattern, domain.RenderOptions{Pattern: tc.pattern}); err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if !strings.HasPrefix(buf.String(), tc.expected+"\n") {
+				t.Errorf("ожидался заголовок %q, получено:\n%s", tc.expected, buf.String())
+			}
+			if !json.Valid(buf.Bytes()) {
+				t.Errorf("вывод должен быть корректным JSON:\n%s", buf.String())
+			}
+		})
+	}
+}
+
+func TestReadJSON_RoundTrip(t *testing.T) {
+	moved, err := domain.ParseFEN("rnbqkbnr/pp1ppppp/8/2p5/4P3/5N2/PPPP1PPP/RNBQKB1R b KQkq - 1 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	enPassant, err := domain.ParseFEN("4k3/8/8/3Pp3/8/8/8/4K3 w - e6 0 12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	large, err := domain.ParseFEN("r8k/10/10/10/10/10/10/10/10/K8R w - - 0 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name  string
+		board *domain.Board
+	}{
+		{"пустая доска", &domain.Board{Width: 4, Height: 4}},
+		{"прямоугольная пустая доска", &domain.Board{Width: 12, Height: 5}},
+		{"начальная позиция", standardBoard()},
+		{"позиция после ходов", moved},
+		{"взятие на проходе", enPassant},
+		{"доска 10x10", large},
+		{"большая пустая доска", &domain.Board{Width: 1100, Height: 1000}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteJSON(&buf, tc.board, CheckerPattern, domain.RenderOptions{Pattern: "checker"}); err != nil {
+				t.Fatalf("неожиданная ошибка записи: %v", err)
+			}
+			got, err := ReadJSON(&buf)
+			if err != nil {
+				t.Fatalf("неожиданная ошибка чтения: %v", err)
+			}
+			if got.Width != tc.board.Width || got.Height != tc.board.Height || !samePlacement(got, tc.board) {
+				t.Errorf("доска изменилась: %dx%d, %q", got.Width, got.Height, got.FEN())
+			}
+			if tc.board.HasPieces() && got.FEN() != tc.board.FEN() {
+				t.Errorf("ожидалась позиция %q, получено %q", tc.board.FEN(), got.FEN())
+			}
+		})
+	}
+}
+
+func TestReadJSON_OnlyOccupiedSquares(t *testing.T) {
+	// Пустые клетки и незнакомые поля можно не указывать и пропускать
+	input := `{"schema":"chessboard/board","version":1,"width":6,"height":6,"comment":"этюд",
+		"squares":[{"square":"a1","piece":{"color":"white","kind":"king"}},{"square":"f6","piece":{"color":"black","kind":"queen"}},{"square":"c3"}]}`
+
+	board, err := ReadJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if board.Width != 6 || board.Height != 6 {
+		t.Errorf("ожидалась доска 6x6, получено %dx%d", board.Width, board.Height)
+	}
+	if got := board.PieceAt(0, 0); got != (domain.Piece{Color: domain.White, Kind: domain.King}) {
+		t.Errorf("на a1 ожидался белый король, получено %v", got)
+	}
+	if got := board.PieceAt(5, 5); got != (domain.Piece{Color: domain.Black, Kind: domain.Queen}) {
+		t.Errorf("на f6 ожидался черный ферзь, получено %v", got)
+	}
+}
+
+func TestReadJSON_Errors(t *testing.T) {
+	header := `"schema":"chessboard/board","version":1,"width":4,"height":4`
+
+	testCases := []struct {
+		name     string
+		input    string
+		class    error
+		expected string
+	}{
+		{"не JSON", `<board/>`, nil, "неверное описание доски в JSON"},
+		{"другая схема", `{"schema":"chess/board","version":1}`, nil, "неизвестная схема 'chess/board'"},
+		{"без схемы", `{"width":4,"height":4}`, nil, "неизвестная схема ''"},
+		{"новая версия", `{"schema":"chessboard/board","version":2,"width":4,"height":4}`, nil, "версия схемы 2 не поддерживается"},
+		{"маленькая доска", `{"schema":"chessboard/board","version":1,"width":3,"height":4}`, domain.ErrInvalidSize, "не может быть меньше 4"},
+		{"слишком много клеток", `{"schema":"chessboard/board","version":1,"width":10000,"height":10000,"squares":[{"square":"a1","piece":{"color":"white","kind":"king"}}]}`,
+			nil, "на доске 10000x10000 с фигурами не может быть больше 1048576 клеток"},
+		{"неверная клетка", `{` + header + `,"squares":[{"square":"4a"}]}`, nil, "'4a'"},
+		{"клетка вне доски", `{` + header + `,"squares":[{"square":"e1"}]}`, nil, "клетка 'e1' вне доски 4x4"},
+		{"клетка дважды", `{` + header + `,"squares":[{"square":"a1"},{"square":"a1"}]}`, nil, "клетка 'a1' указана дважды"},
+		{"неизвестная фигура", `{` + header + `,"squares":[{"square":"a1","piece":{"color":"red","kind":"king"}}]}`, nil, "неизвестная фигура 'red king'"},
+		{"неизвестный тип", `{` + header + `,"squares":[{"square":"a1","piece":{"color":"white","kind":"archbishop"}}]}`, nil, "'white archbishop'"},
+		{"неверный FEN", `{` + header + `,"fen":"4/4/4","squares":[]}`, domain.ErrInvalidFEN, "неверный FEN"},
+		{"FEN не совпадает с клетками", `{` + header + `,"fen":"k3/4/4/3K w - - 0 1","squares":[{"square":"a1","piece":{"color":"white","kind":"king"}}]}`, nil, "не совпадает с фигурами"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ReadJSON(strings.NewReader(tc.input))
+			if err == nil {
+				t.Fatal("ожидалась ошибка")
+			}
+			if !errors.Is(err, ErrInvalidBoardJSON) {
+				t.Errorf("ошибка должна соответствовать ErrInvalidBoardJSON: %v", err)
+			}
+			if tc.class != nil && !errors.Is(err, tc.class) {
+				t.Errorf("ошибка должна сохранять класс %v: %v", tc.class, err)
+			}
+			if !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("ожидалось сообщение с %q, получено %q", tc.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestBoardUsecase_RenderJSON(t *testing.T) {
+	usecase := NewBoardUsecase(&MockBoardRepository{})
+	if err := usecase.SetPattern("stripes"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Имя узора, выбранного через SetPattern, попадает в описание доски
+	var buf bytes.Buffer
+	if err := usecase.Render(&buf, standardBoard(), domain.RenderOptions{Format: domain.FormatJSON}); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	var doc jsonBoard
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("вывод должен быть корректным JSON: %v", err)
+	}
+	if doc.Pattern != "stripes" || doc.FEN != domain.StartFEN {
+		t.Errorf("ожидались узор 'stripes' и начальная позиция, получено %q и %q", doc.Pattern, doc.FEN)
+	}
+}
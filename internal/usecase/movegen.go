@@ -0,0 +1,548 @@
+package usecase
+
+import (
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+// sideNames - названия сторон в родительном падеже для сообщений об ошибках
+var sideNames = [2]i18n.Key{"side.white.genitive", "side.black.genitive"}
+
+// position - изменяемая позиция для генерации ходов. Клетки хранятся одним срезом
+// в порядке domain.Board, а ходы делаются и отменяются на месте без копирования доски.
+type position struct {
+	width    int
+	height   int
+	squares  []domain.Piece
+	side     domain.Color
+	castling domain.CastlingRights
+	// epSquare - индекс клетки взятия на проходе или -1
+	epSquare int
+	halfmove int
+	fullmove int
+	// kings - индексы королей белых и черных или -1, если короля нет
+	kings [2]int
+	// hash - хеш Зобриста, который обновляется при каждом ходе
+	hash uint64
+}
+
+// newPosition копирует доску в позицию для генерации ходов
+func newPosition(board *domain.Board) (*position, error) {
+	size := board.Width * board.Height
+	if len(board.Squares) != 0 && len(board.Squares) != size {
+		return nil, i18n.Errorf("position.squares_mismatch", len(board.Squares), board.Width, board.Height)
+	}
+
+	p := &position{
+		width:    board.Width,
+		height:   board.Height,
+		squares:  make([]domain.Piece, size),
+		side:     board.SideToMove,
+		castling: board.Castling,
+		epSquare: -1,
+		halfmove: board.HalfmoveClock,
+		fullmove: max(board.FullmoveNumber, 1),
+		kings:    [2]int{-1, -1},
+	}
+	copy(p.squares, board.Squares)
+
+	for i, piece := range p.squares {
+		if piece.Kind != domain.King {
+			continue
+		}
+		if p.kings[piece.Color] != -1 {
+			return nil, invalidPosition(i18n.Errorf("position.two_kings", sideNames[piece.Color]))
+		}
+		p.kings[piece.Color] = i
+	}
+	if ep := board.EnPassant; ep != nil && board.Contains(ep.File, ep.Rank) {
+		p.epSquare = p.index(ep.File, ep.Rank)
+	}
+	p.hash = p.computeHash()
+	return p, nil
+}
+
+// board возвращает позицию в виде доски домена
+func (p *position) board() *domain.Board {
+	board := &domain.Board{
+		Width:          p.width,
+		Height:         p.height,
+		Squares:        append([]domain.Piece(nil), p.squares...),
+		SideToMove:     p.side,
+		Castling:       p.castling,
+		HalfmoveClock:  p.halfmove,
+		FullmoveNumber: p.fullmove,
+	}
+	if p.epSquare >= 0 {
+		square := p.square(p.epSquare)
+		board.EnPassant = &square
+	}
+	return board
+}
+
+func (p *position) index(file, rank int) int {
+	return rank*p.width + file
+}
+
+func (p *position) square(index int) domain.Square {
+	return domain.Square{File: index % p.width, Rank: index / p.width}
+}
+
+// direction - смещение по вертикалям и горизонталям
+type direction struct {
+	file int
+	rank int
+}
+
+var (
+	knightSteps      = []direction{{1, 2}, {2, 1}, {2, -1}, {1, -2}, {-1, -2}, {-2, -1}, {-2, 1}, {-1, 2}}
+	kingSteps        = []direction{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+	bishopDirections = []direction{{1, 1}, {-1, 1}, {-1, -1}, {1, -1}}
+	rookDirections   = []direction{{1, 0}, {0, 1}, {-1, 0}, {0, -1}}
+	promotionKinds   = []domain.PieceKind{domain.Queen, domain.Rook, domain.Bishop, domain.Knight}
+)
+
+// step возвращает индекс клетки, смещенной от index на d, если она на доске
+func (p *position) step(index int, d direction) (int, bool) {
+	file := index%p.width + d.file
+	rank := index/p.width + d.rank
+	if file < 0 || file >= p.width || rank < 0 || rank >= p.height {
+		return 0, false
+	}
+	return p.index(file, rank), true
+}
+
+// pawnDirection возвращает направление движения пешек стороны по горизонталям
+func pawnDirection(color domain.Color) int {
+	if color == domain.Black {
+		return -1
+	}
+	return 1
+}
+
+// homeRank возвращает крайнюю горизонталь стороны
+func (p *position) homeRank(color domain.Color) int {
+	if color == domain.Black {
+		return p.height - 1
+	}
+	return 0
+}
+
+// moveFlags уточняют вид хода для его выполнения и отмены
+type moveFlags uint8
+
+const (
+	flagCapture moveFlags = 1 << iota
+	flagEnPassant
+	flagCastling
+	flagDoublePush
+)
+
+// move - ход во внутреннем представлении с индексами клеток
+type move struct {
+	from      int
+	to        int
+	promotion domain.PieceKind
+	flags     moveFlags
+}
+
+// domainMove переводит ход во внешнее представление
+func (p *position) domainMove(m move) domain.Move {
+	return domain.Move{From: p.square(m.from), To: p.square(m.to), Promotion: m.promotion}
+}
+
+// pseudoLegalMoves добавляет к moves все ходы стороны, имеющей очередь хода, по правилам
+// перемещения фигур. Рокировка генерируется только через непобитые поля; оставляет ли
+// ход своего короля под шахом, не проверяется.
+func (p *position) pseudoLegalMoves(moves []move) []move {
+	for from, piece := range p.squares {
+		if piece.IsEmpty() || piece.Color != p.side {
+			continue
+		}
+		switch piece.Kind {
+		case domain.Pawn:
+			moves = p.pawnMoves(moves, from)
+		case domain.Knight:
+			moves = p.stepMoves(moves, from, knightSteps)
+		case domain.Bishop:
+			moves = p.slideMoves(moves, from, bishopDirections)
+		case domain.Rook:
+			moves = p.slideMoves(moves, from, rookDirections)
+		case domain.Queen:
+			moves = p.slideMoves(moves, from, bishopDirections)
+			moves = p.slideMoves(moves, from, rookDirections)
+		case domain.King:
+			moves = p.stepMoves(moves, from, kingSteps)
+			moves = p.castlingMoves(moves, from)
+		}
+	}
+	return moves
+}
+
+// legalMoves добавляет к moves ходы, после которых свой король не остается под шахом
+func (p *position) legalMoves(moves []move) []move {
+	start := len(moves)
+	moves = p.pseudoLegalMoves(moves)
+
+	legal := moves[:start]
+	for _, m := range moves[start:] {
+		if p.isLegal(m) {
+			legal = append(legal, m)
+		}
+	}
+	return legal
+}
+
+// isLegal проверяет псевдолегальный ход: после него король ходившей стороны не под шахом
+func (p *position) isLegal(m move) bool {
+	mover := p.side
+	u := p.makeMove(m)
+	legal := p.kings[mover] < 0 || !p.isAttacked(p.kings[mover], mover.Opposite())
+	p.unmakeMove(m, u)
+	return legal
+}
+
+func (p *position) pawnMoves(moves []move, from int) []move {
+	dir := pawnDirection(p.side)
+
+	if to, ok := p.step(from, direction{0, dir}); ok && p.squares[to].IsEmpty() {
+		moves = p.addPawnMove(moves, move{from: from, to: to})
+		// Двойной ход возможен со второй от края горизонтали
+		if from/p.width == p.homeRank(p.side)+dir {
+			if to2, ok := p.step(to, direction{0, dir}); ok && p.squares[to2].IsEmpty() {
+				moves = append(moves, move{from: from, to: to2, flags: flagDoublePush})
+			}
+		}
+	}
+
+	for _, df := range [2]int{-1, 1} {
+		to, ok := p.step(from, direction{df, dir})
+		if !ok {
+			continue
+		}
+		target := p.squares[to]
+		switch {
+		case !target.IsEmpty() && target.Color != p.side:
+			moves = p.addPawnMove(moves, move{from: from, to: to, flags: flagCapture})
+		case to == p.epSquare && target.IsEmpty():
+			moves = append(moves, move{from: from, to: to, flags: flagCapture | flagEnPassant})
+		}
+	}
+	return moves
+}
+
+// addPawnMove добавляет ход пешки, раскрывая его в превращения на последней горизонтали
+func (p *position) addPawnMove(moves []move, m move) []move {
+	if m.to/p.width != p.homeRank(p.side.Opposite()) {
+		return append(moves, m)
+	}
+	for _, kind := range promotionKinds {
+		m.promotion = kind
+		moves = append(moves, m)
+	}
+	return moves
+}
+
+func (p *position) stepMoves(moves []move, from int, steps []direction) []move {
+	for _, d := range steps {
+		to, ok := p.step(from, d)
+		if !ok {
+			continue
+		}
+		target := p.squares[to]
+		if target.IsEmpty() {
+			moves = append(moves, move{from: from, to: to})
+		} else if target.Color != p.side {
+			moves = append(moves, move{from: from, to: to, flags: flagCapture})
+		}
+	}
+	return moves
+}
+
+func (p *position) slideMoves(moves []move, from int, directions []direction) []move {
+	for _, d := range directions {
+		for to, ok := p.step(from, d); ok; to, ok = p.step(to, d) {
+			target := p.squares[to]
+			if target.IsEmpty() {
+				moves = append(moves, move{from: from, to: to})
+				continue
+			}
+			if target.Color != p.side {
+				moves = append(moves, move{from: from, to: to, flags: flagCapture})
+			}
+			break
+		}
+	}
+	return moves
+}
+
+// castlingRight возвращает право рокировки стороны в короткую или длинную сторону
+func castlingRight(color domain.Color, kingside bool) domain.CastlingRights {
+	switch {
+	case color == domain.White && kingside:
+		return domain.WhiteKingside
+	case color == domain.White:
+		return domain.WhiteQueenside
+	case kingside:
+		return domain.BlackKingside
+	default:
+		return domain.BlackQueenside
+	}
+}
+
+// castlingMoves добавляет рокировки: король идет на две клетки к угловой ладье,
+// ладья становится на поле, которое он пересек. Поля между королем и ладьей
+// должны быть свободны, а король не может быть под шахом или проходить через битое поле.
+func (p *position) castlingMoves(moves []move, from int) []move {
+	rank := p.homeRank(p.side)
+	if from/p.width != rank || p.castling&(castlingRight(p.side, true)|castlingRight(p.side, false)) == 0 {
+		return moves
+	}
+	enemy := p.side.Opposite()
+	if p.isAttacked(from, enemy) {
+		return moves
+	}
+
+	file := from % p.width
+	rook := domain.Piece{Color: p.side, Kind: domain.Rook}
+	for _, kingside := range [2]bool{true, false} {
+		rookFile, dir := 0, -1
+		if kingside {
+			rookFile, dir = p.width-1, 1
+		}
+		target := file + 2*dir
+		if !p.castling.Has(castlingRight(p.side, kingside)) ||
+			p.squares[p.index(rookFile, rank)] != rook || target <= 0 || target >= p.width-1 {
+			continue
+		}
+
+		clear := true
+		for f := file + dir; f != rookFile; f += dir {
+			if !p.squares[p.index(f, rank)].IsEmpty() {
+				clear = false
+				break
+			}
+		}
+		if !clear || p.isAttacked(from+dir, enemy) || p.isAttacked(from+2*dir, enemy) {
+			continue
+		}
+		moves = append(moves, move{from: from, to: from + 2*dir, flags: flagCastling})
+	}
+	return moves
+}
+
+// isAttacked сообщает, бьет ли сторона by клетку index
+func (p *position) isAttacked(index int, by domain.Color) bool {
+	pawn := domain.Piece{Color: by, Kind: domain.Pawn}
+	for _, df := range [2]int{-1, 1} {
+		if from, ok := p.step(index, direction{df, -pawnDirection(by)}); ok && p.squares[from] == pawn {
+			return true
+		}
+	}
+	if p.attackedByStep(index, knightSteps, domain.Piece{Color: by, Kind: domain.Knight}) ||
+		p.attackedByStep(index, kingSteps, domain.Piece{Color: by, Kind: domain.King}) {
+		return true
+	}
+	return p.attackedBySlide(index, bishopDirections, by, domain.Bishop) ||
+		p.attackedBySlide(index, rookDirections, by, domain.Rook)
+}
+
+func (p *position) attackedByStep(index int, steps []direction, attacker domain.Piece) bool {
+	for _, d := range steps {
+		if from, ok := p.step(index, d); ok && p.squares[from] == attacker {
+			return true
+		}
+	}
+	return false
+}
+
+// attackedBySlide ищет вдоль направлений ближайшую фигуру: дальнобойную фигуру kind или ферзя стороны by
+func (p *position) attackedBySlide(index int, directions []direction, by domain.Color, kind domain.PieceKind) bool {
+	for _, d := range directions {
+		for from, ok := p.step(index, d); ok; from, ok = p.step(from, d) {
+			piece := p.squares[from]
+			if piece.IsEmpty() {
+				continue
+			}
+			if piece.Color == by && (piece.Kind == kind || piece.Kind == domain.Queen) {
+				return true
+			}
+			break
+		}
+	}
+	return false
+}
+
+// inCheck сообщает, находится ли под шахом король стороны, имеющей очередь хода
+func (p *position) inCheck() bool {
+	king := p.kings[p.side]
+	return king >= 0 && p.isAttacked(king, p.side.Opposite())
+}
+
+// undo хранит состояние позиции, которое нельзя восстановить по самому ходу
+type undo struct {
+	captured domain.Piece
+	castling domain.CastlingRights
+	epSquare int
+	halfmove int
+	kings    [2]int
+	hash     uint64
+}
+
+// capturedSquare возвращает клетку фигуры, взятой ходом m стороной side
+func (p *position) capturedSquare(m move, side domain.Color) int {
+	if m.flags&flagEnPassant != 0 {
+		return m.to - pawnDirection(side)*p.width
+	}
+	return m.to
+}
+
+// castlingRook возвращает исходную и конечную клетки ладьи при рокировке m
+func (p *position) castlingRook(m move) (int, int) {
+	rankStart := m.to - m.to%p.width
+	if m.to > m.from {
+		return rankStart + p.width - 1, m.to - 1
+	}
+	return rankStart, m.to + 1
+}
+
+// cornerRights возвращает права рокировки, связанные с угловой клеткой index
+func (p *position) cornerRights(index int) domain.CastlingRights {
+	switch index {
+	case p.index(0, 0):
+		return domain.WhiteQueenside
+	case p.index(p.width-1, 0):
+		return domain.WhiteKingside
+	case p.index(0, p.height-1):
+		return domain.BlackQueenside
+	case p.index(p.width-1, p.height-1):
+		return domain.BlackKingside
+	}
+	return domain.NoCastling
+}
+
+// makeMove выполняет ход и возвращает данные для его отмены
+func (p *position) makeMove(m move) undo {
+	u := undo{castling: p.castling, epSquare: p.epSquare, halfmove: p.halfmove, kings: p.kings, hash: p.hash}
+	// Ключи, зависящие от состояния позиции, снимаются до хода и ставятся после него
+	p.hash ^= castlingKeys[p.castling] ^ sideToMoveKey(p.side) ^ p.epKey()
+
+	piece := p.squares[m.from]
+	captured := p.capturedSquare(m, p.side)
+	u.captured = p.squares[captured]
+	if !u.captured.IsEmpty() {
+		p.hash ^= pieceKey(u.captured, captured)
+	}
+	p.squares[captured] = domain.Piece{}
+	p.squares[m.from] = domain.Piece{}
+	p.hash ^= pieceKey(piece, m.from)
+
+	p.halfmove++
+	if piece.Kind == domain.Pawn || !u.captured.IsEmpty() {
+		p.halfmove = 0
+	}
+	if m.promotion != domain.NoPiece {
+		piece.Kind = m.promotion
+	}
+	p.squares[m.to] = piece
+	p.hash ^= pieceKey(piece, m.to)
+
+	if m.flags&flagCastling != 0 {
+		rookFrom, rookTo := p.castlingRook(m)
+		rook := p.squares[rookFrom]
+		p.squares[rookTo] = rook
+		p.squares[rookFrom] = domain.Piece{}
+		p.hash ^= pieceKey(rook, rookFrom) ^ pieceKey(rook, rookTo)
+	}
+	if piece.Kind == domain.King {
+		p.kings[p.side] = m.to
+		p.castling &^= castlingRight(p.side, true) | castlingRight(p.side, false)
+	}
+	p.castling &^= p.cornerRights(m.from) | p.cornerRights(m.to)
+
+	p.epSquare = -1
+	if m.flags&flagDoublePush != 0 {
+		p.epSquare = m.from + pawnDirection(p.side)*p.width
+	}
+
+	if p.side == domain.Black {
+		p.fullmove++
+	}
+	p.side = p.side.Opposite()
+	p.hash ^= castlingKeys[p.castling] ^ sideToMoveKey(p.side) ^ p.epKey()
+	return u
+}
+
+// unmakeMove отменяет ход m, выполненный makeMove
+func (p *position) unmakeMove(m move, u undo) {
+	p.side = p.side.Opposite()
+	if p.side == domain.Black {
+		p.fullmove--
+	}
+
+	piece := p.squares[m.to]
+	if m.promotion != domain.NoPiece {
+		piece.Kind = domain.Pawn
+	}
+	p.squares[m.to] = domain.Piece{}
+	p.squares[m.from] = piece
+	p.squares[p.capturedSquare(m, p.side)] = u.captured
+
+	if m.flags&flagCastling != 0 {
+		rookFrom, rookTo := p.castlingRook(m)
+		p.squares[rookFrom] = p.squares[rookTo]
+		p.squares[rookTo] = domain.Piece{}
+	}
+
+	p.castling = u.castling
+	p.epSquare = u.epSquare
+	p.halfmove = u.halfmove
+	p.kings = u.kings
+	p.hash = u.hash
+}
+
+// validate проверяет, что в позиции можно искать легальные ходы: король
+// стороны, не имеющей очереди хода, не может быть под шахом
+func (p *position) validate() error {
+	enemy := p.side.Opposite()
+	if king := p.kings[enemy]; king >= 0 && p.isAttacked(king, p.side) {
+		return invalidPosition(i18n.Errorf("position.king_in_check", sideNames[enemy], sideNames[p.side]))
+	}
+	return nil
+}
+
+// invalidPosition оборачивает причину, по которой позиция невозможна, в ошибку
+// класса domain.ErrInvalidPosition
+func invalidPosition(reason error) error {
+	return i18n.Errorf("position.error", domain.ErrInvalidPosition, reason)
+}
+
+// PseudoLegalMoves возвращает ходы стороны, имеющей очередь хода, по правилам
+// перемещения фигур без проверки, остается ли свой король под шахом
+func PseudoLegalMoves(board *domain.Board) ([]domain.Move, error) {
+	p, err := newPosition(board)
+	if err != nil {
+		return nil, err
+	}
+	return p.domainMoves(p.pseudoLegalMoves(nil)), nil
+}
+
+// LegalMoves возвращает все легальные ходы стороны, имеющей очередь хода
+func LegalMoves(board *domain.Board) ([]domain.Move, error) {
+	p, err := newPosition(board)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return p.domainMoves(p.legalMoves(nil)), nil
+}
+
+func (p *position) domainMoves(moves []move) []domain.Move {
+	result := make([]domain.Move, len(moves))
+	for i, m := range moves {
+		result[i] = p.domainMove(m)
+	}
+	return result
+}
@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+)
+
+// mustPosition создает позицию из FEN или прерывает тест
+func mustPosition(t testing.TB, fen string) *position {
+	t.Helper()
+
+	board, err := domain.ParseFEN(fen)
+	if err != nil {
+		t.Fatalf("неверный FEN %q: %v", fen, err)
+	}
+	p, err := newPosition(board)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	return p
+}
+
+// moveNames возвращает отсортированные ходы в записи "e2e4"
+func moveNames(moves []domain.Move) []string {
+	names := make([]string, len(moves))
+	for i, m := range moves {
+		names[i] = m.String()
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestLegalMoves_SpecialMoves(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fen      string
+		included []string
+		excluded []string
+	}{
+		{
+			name:     "рокировка в обе стороны",
+			fen:      "r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1",
+			included: []string{"e1g1", "e1c1"},
+		},
+		{
+			name:     "рокировка через битое поле",
+			fen:      "r3k2r/8/8/8/8/8/5r2/R3K2R w KQkq - 0 1",
+			excluded: []string{"e1g1"},
+			included: []string{"e1c1"},
+		},
+		{
+			name:     "рокировка под шахом",
+			fen:      "r3k2r/8/8/8/4r3/8/8/R3K2R w KQkq - 0 1",
+			excluded: []string{"e1g1", "e1c1"},
+		},
+		{
+			// Через b1 проходит только ладья, поэтому длинная рокировка разрешена
+			name:     "длинная рокировка при битом поле b1",
+			fen:      "1r2k2r/8/8/8/8/8/8/R3K2R w KQk - 0 1",
+			included: []string{"e1c1", "e1g1"},
+		},
+		{
+			name:     "без права рокировки",
+			fen:      "r3k2r/8/8/8/8/8/8/R3K2R w kq - 0 1",
+			excluded: []string{"e1g1", "e1c1"},
+		},
+		{
+			name:     "взятие на проходе",
+			fen:      "4k3/8/8/3pP3/8/8/8/4K3 w - d6 0 2",
+			included: []string{"e5d6", "e5e6"},
+		},
+		{
+			name:     "взятие на проходе со связкой по горизонтали",
+			fen:      "8/8/8/K2pP2r/8/8/8/7k w - d6 0 2",
+			excluded: []string{"e5d6"},
+		},
+		{
+			name:     "превращение",
+			fen:      "3r3k/4P3/8/8/8/8/8/K7 w - - 0 1",
+			included: []string{"e7e8q", "e7e8r", "e7e8b", "e7e8n", "e7d8q", "e7d8n"},
+			excluded: []string{"e7e8"},
+		},
+		{
+			name:     "связанная фигура",
+			fen:      "4k3/4r3/8/8/8/8/4N3/4K3 w - - 0 1",
+			excluded: []string{"e2c3", "e2g3"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			board, err := domain.ParseFEN(tc.fen)
+			if err != nil {
+				t.Fatalf("неверный FEN: %v", err)
+			}
+			moves, err := LegalMoves(board)
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+
+			names := moveNames(moves)
+			for _, name := range tc.included {
+				if !slices.Contains(names, name) {
+					t.Errorf("ожидался ход %s среди %v", name, names)
+				}
+			}
+			for _, name := range tc.excluded {
+				if slices.Contains(names, name) {
+					t.Errorf("ход %s не должен быть легальным", name)
+				}
+			}
+		})
+	}
+}
+
+func TestPseudoLegalMoves(t *testing.T) {
+	// Конь связан ладьей: псевдолегальные ходы включают его, легальные - нет
+	board, err := domain.ParseFEN("4k3/4r3/8/8/8/8/4N3/4K3 w - - 0 1")
+	if err != nil {
+		t.Fatalf("неверный FEN: %v", err)
+	}
+
+	pseudo, err := PseudoLegalMoves(board)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	legal, err := LegalMoves(board)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	if !slices.Contains(moveNames(pseudo), "e2c3") {
+		t.Error("псевдолегальные ходы должны включать ход связанного коня")
+	}
+	if len(legal) >= len(pseudo) {
+		t.Errorf("легальных ходов (%d) должно быть меньше псевдолегальных (%d)", len(legal), len(pseudo))
+	}
+}
+
+func TestLegalMoves_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		board    func() *domain.Board
+		errorMsg string
+	}{
+		{
+			name: "два белых короля",
+			board: func() *domain.Board {
+				board, _ := domain.ParseFEN("4k3/8/8/8/8/8/8/K3K3 w - - 0 1")
+				return board
+			},
+			errorMsg: "у белых больше одного короля",
+		},
+		{
+			name: "шах стороне без хода",
+			board: func() *domain.Board {
+				board, _ := domain.ParseFEN("4k3/4R3/8/8/8/8/8/4K3 w - - 0 1")
+				return board
+			},
+			errorMsg: "король черных под шахом",
+		},
+		{
+			name: "неверное число клеток",
+			board: func() *domain.Board {
+				return &domain.Board{Width: 8, Height: 8, Squares: make([]domain.Piece, 10)}
+			},
+			errorMsg: "число клеток (10) не соответствует размеру доски 8x8",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LegalMoves(tc.board())
+			if err == nil || !strings.Contains(err.Error(), tc.errorMsg) {
+				t.Errorf("ожидалась ошибка с текстом '%s', получено: %v", tc.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestLegalMoves_BoardSizes(t *testing.T) {
+	t.Run("пустая доска", func(t *testing.T) {
+		moves, err := LegalMoves(&domain.Board{Width: 8, Height: 8})
+		if err != nil || len(moves) != 0 {
+			t.Errorf("на пустой доске не должно быть ходов, получено %v, %v", moves, err)
+		}
+	})
+
+	t.Run("доска 10x10", func(t *testing.T) {
+		board, err := domain.ParseFEN("10/10/10/10/10/10/10/10/4P5/4K5 w - - 0 1")
+		if err != nil {
+			t.Fatalf("неверный FEN: %v", err)
+		}
+		moves, err := LegalMoves(board)
+		if err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		// Пешка со второй горизонтали ходит на одну или две клетки, король - на 4 свободных поля
+		names := moveNames(moves)
+		for _, name := range []string{"e2e3", "e2e4", "e1d1", "e1d2", "e1f1", "e1f2"} {
+			if !slices.Contains(names, name) {
+				t.Errorf("ожидался ход %s среди %v", name, names)
+			}
+		}
+		if len(names) != 6 {
+			t.Errorf("ожидалось 6 ходов, получено %v", names)
+		}
+	})
+}
+
+func TestPosition_MakeUnmake(t *testing.T) {
+	p := mustPosition(t, "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	before := p.board().FEN()
+
+	for _, m := range p.legalMoves(nil) {
+		u := p.makeMove(m)
+		p.unmakeMove(m, u)
+		if after := p.board().FEN(); after != before {
+			t.Fatalf("после отмены хода %s позиция изменилась: %q", p.domainMove(m), after)
+		}
+	}
+}
+
+func TestPosition_MakeMoveState(t *testing.T) {
+	p := mustPosition(t, "r3k2r/8/8/8/8/8/4P3/R3K2R w KQkq - 5 10")
+
+	steps := []struct {
+		move     string
+		expected string
+	}{
+		{"e2e4", "r3k2r/8/8/8/4P3/8/8/R3K2R b KQkq e3 0 10"},
+		{"h8h1", "r3k3/8/8/8/4P3/8/8/R3K2r w Qq - 0 11"},
+		{"e1e2", "r3k3/8/8/8/4P3/8/4K3/R6r b q - 1 11"},
+	}
+	for _, step := range steps {
+		var found bool
+		for _, m := range p.legalMoves(nil) {
+			if p.domainMove(m).String() == step.move {
+				p.makeMove(m)
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("ход %s не найден", step.move)
+		}
+		if fen := p.board().FEN(); fen != step.expected {
+			t.Errorf("после %s ожидалось %q, получено %q", step.move, step.expected, fen)
+		}
+	}
+}
+
+func TestBoardUsecase_LegalMoves(t *testing.T) {
+	usecase := NewBoardUsecase(NewBoardRepository())
+	board := mustCreateBoard(t, usecase, 8, 8, domain.SetupStandard)
+
+	moves, err := usecase.LegalMoves(board)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if len(moves) != 20 {
+		t.Errorf("в начальной позиции ожидалось 20 ходов, получено %d", len(moves))
+	}
+}
+
+func BenchmarkLegalMoves_Naive(b *testing.B) {
+	p := mustPosition(b, "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1")
+	moves := make([]move, 0, 256)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		moves = p.legalMoves(moves[:0])
+	}
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+// DefaultPattern - имя узора, который используется по умолчанию
+const DefaultPattern = "checker"
+
+const (
+	// MaskPrefix начинает имя узора, заданного маской (см. MaskPattern)
+	MaskPrefix = "mask:"
+	// MaxMaskSize ограничивает ширину и высоту маски узора
+	MaxMaskSize = 64
+)
+
+// PatternFunc определяет, является ли клетка (row, col) тёмной.
+// Строки нумеруются сверху вниз, столбцы - слева направо.
+type PatternFunc func(board *domain.Board, row, col int) bool
+
+// PatternRegistry хранит именованные генераторы узоров
+type PatternRegistry struct {
+	mu       sync.RWMutex
+	patterns map[string]PatternFunc
+}
+
+// NewPatternRegistry создает реестр со встроенными узорами
+func NewPatternRegistry() *PatternRegistry {
+	return &PatternRegistry{
+		patterns: map[string]PatternFunc{
+			"checker":  CheckerPattern,
+			"stripes":  StripesPattern,
+			"diagonal": DiagonalPattern,
+			"rings":    RingsPattern,
+		},
+	}
+}
+
+// Register добавляет узор в реестр под указанным именем. Ошибки соответствуют
+// domain.ErrInvalidPattern.
+func (r *PatternRegistry) Register(name string, fn PatternFunc) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return invalidPattern(i18n.New("pattern.empty_name"))
+	}
+	if fn == nil {
+		return invalidPattern(i18n.Errorf("pattern.no_func", name))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.patterns[name]; exists {
+		return invalidPattern(i18n.Errorf("pattern.duplicate", name))
+	}
+	r.patterns[name] = fn
+	return nil
+}
+
+// invalidPattern оборачивает причину ошибки узора, сохраняя ее класс
+func invalidPattern(reason error) error {
+	return i18n.Errorf("pattern.error", domain.ErrInvalidPattern, reason)
+}
+
+// Lookup возвращает узор по имени. Имя вида "mask:#./.#" задает узор маской
+// без регистрации (см. MaskPattern).
+func (r *PatternRegistry) Lookup(name string) (PatternFunc, error) {
+	if mask, ok := strings.CutPrefix(strings.TrimSpace(name), MaskPrefix); ok {
+		return MaskPattern(mask)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fn, ok := r.patterns[strings.TrimSpace(name)]
+	if !ok {
+		return nil, &domain.UnknownValueError{Label: "unknown.pattern", Value: name, Allowed: sortedKeys(r.patterns)}
+	}
+	return fn, nil
+}
+
+// Names возвращает отсортированный список имен зарегистрированных узоров
+func (r *PatternRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.patterns))
+	for name := range r.patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CheckerPattern - классическое шахматное чередование клеток
+func CheckerPattern(_ *domain.Board, row, col int) bool {
+	return (row+col)%2 != 0
+}
+
+// StripesPattern - горизонтальные полосы шириной в одну клетку
+func StripesPattern(_ *domain.Board, row, _ int) bool {
+	return row%2 != 0
+}
+
+// DiagonalPattern - диагональные полосы шириной в две клетки
+func DiagonalPattern(_ *domain.Board, row, col int) bool {
+	return ((row+col)/2)%2 != 0
+}
+
+// RingsPattern - концентрические кольца от края доски к центру
+func RingsPattern(board *domain.Board, row, col int) bool {
+	ring := min(row, col, board.Height-1-row, board.Width-1-col)
+	return ring%2 != 0
+}
+
+// MaskPattern создает узор из маски: строки маски разделяются '/', '#' означает
+// темную клетку, '.' - светлую. Маска повторяется по всей доске, начиная
+// с левого верхнего угла, например "#./.#" - шахматный порядок, начинающийся
+// с темной клетки. Ошибки соответствуют domain.ErrInvalidPattern.
+func MaskPattern(mask string) (PatternFunc, error) {
+	if mask == "" {
+		return nil, invalidPattern(i18n.New("pattern.mask_empty"))
+	}
+	rows := strings.Split(mask, "/")
+	if len(rows) > MaxMaskSize {
+		return nil, invalidPattern(i18n.Errorf("pattern.mask_too_large", MaxMaskSize, MaxMaskSize))
+	}
+
+	dark := make([][]bool, len(rows))
+	for i, row := range rows {
+		// Длина строки проверяется до разбора символов, чтобы длинная строка
+		// после короткой не разбиралась целиком
+		if len(row) > MaxMaskSize {
+			return nil, invalidPattern(i18n.Errorf("pattern.mask_too_large", MaxMaskSize, MaxMaskSize))
+		}
+		if row == "" || len(row) != len(rows[0]) {
+			return nil, invalidPattern(i18n.Errorf("pattern.mask_ragged", mask))
+		}
+		dark[i] = make([]bool, 0, len(row))
+		for _, c := range row {
+			if c != '#' && c != '.' {
+				return nil, invalidPattern(i18n.Errorf("pattern.mask_char", mask, c))
+			}
+			dark[i] = append(dark[i], c == '#')
+		}
+	}
+
+	return PredicatePattern(func(row, col int) bool {
+		line := dark[row%len(dark)]
+		return line[col%len(line)]
+	}), nil
+}
+
+// PredicatePattern превращает произвольный предикат координат в узор,
+// пригодный для регистрации в PatternRegistry
+func PredicatePattern(pred func(row, col int) bool) PatternFunc {
+	return func(_ *domain.Board, row, col int) bool {
+		return pred(row, col)
+	}
+}
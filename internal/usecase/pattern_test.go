@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+func TestPatternRegistry_BuiltinPatterns(t *testing.T) {
+	registry := NewPatternRegistry()
+
+	expected := []string{"checker", "diagonal", "rings", "stripes"}
+	if names := registry.Names(); !reflect.DeepEqual(names, expected) {
+		t.Errorf("ожидались узоры %v, получено %v", expected, names)
+	}
+}
+
+func TestPatternRegistry_Register(t *testing.T) {
+	t.Run("регистрация нового узора", func(t *testing.T) {
+		registry := NewPatternRegistry()
+		err := registry.Register("cross", PredicatePattern(func(row, col int) bool {
+			return row == col
+		}))
+		if err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+
+		fn, err := registry.Lookup("cross")
+		if err != nil {
+			t.Fatalf("зарегистрированный узор не найден: %v", err)
+		}
+		if !fn(&domain.Board{Width: 4, Height: 4}, 2, 2) || fn(&domain.Board{Width: 4, Height: 4}, 2, 1) {
+			t.Error("пользовательский предикат отработал неверно")
+		}
+	})
+
+	testCases := []struct {
+		name    string
+		pattern string
+		fn      PatternFunc
+	}{
+		{"пустое имя", "  ", CheckerPattern},
+		{"пустая функция", "empty", nil},
+		{"повторная регистрация", "checker", CheckerPattern},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewPatternRegistry().Register(tc.pattern, tc.fn)
+			if err == nil {
+				t.Fatalf("ожидалась ошибка при регистрации узора '%s'", tc.pattern)
+			}
+			if !errors.Is(err, domain.ErrInvalidPattern) {
+				t.Errorf("ошибка должна соответствовать ErrInvalidPattern: %v", err)
+			}
+		})
+	}
+}
+
+func TestPatternRegistry_LookupUnknown(t *testing.T) {
+	_, err := NewPatternRegistry().Lookup("zigzag")
+	var unknown *domain.UnknownValueError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("ожидалась *domain.UnknownValueError, получено %v", err)
+	}
+	if unknown.Value != "zigzag" || len(unknown.Allowed) == 0 {
+		t.Errorf("ошибка должна содержать узор и список допустимых узоров: %+v", unknown)
+	}
+}
+
+func TestRenderPattern(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pattern  PatternFunc
+		width    int
+		height   int
+		expected string
+	}{
+		{"шахматный", CheckerPattern, 4, 4, " # #\n# # \n # #\n# # "},
+		{"полосы", StripesPattern, 4, 4, "    \n####\n    \n####"},
+		{"диагональ", DiagonalPattern, 4, 4, "  ##\n ## \n##  \n#  #"},
+		{"кольца", RingsPattern, 5, 5, "     \n ### \n # # \n ### \n     "},
+		{"кольца на прямоугольной доске", RingsPattern, 6, 4, "      \n #### \n #### \n      "},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := RenderPattern(&domain.Board{Width: tc.width, Height: tc.height}, tc.pattern)
+			if result != tc.expected {
+				t.Errorf("ожидалось:\n%s\nполучено:\n%s", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestPatternRegistry_RegisterLanguage(t *testing.T) {
+	err := NewPatternRegistry().Register("checker", CheckerPattern)
+	if got := i18n.NewPrinter(i18n.English).Error(err); got != "invalid pattern: pattern 'checker' is already registered" {
+		t.Errorf("ожидалось сообщение на английском, получено %q", got)
+	}
+	if got := err.Error(); got != "неверный узор: узор 'checker' уже зарегистрирован" {
+		t.Errorf("ожидалось сообщение на русском, получено %q", got)
+	}
+}
+
+func TestMaskPattern(t *testing.T) {
+	board := &domain.Board{Width: 8, Height: 8}
+	testCases := []struct {
+		name     string
+		pattern  string
+		expected string
+	}{
+		{"шахматный порядок", "mask:#./.#", "#.#.#.#.\n.#.#.#.#\n#.#.#.#.\n.#.#.#.#\n#.#.#.#.\n.#.#.#.#\n#.#.#.#.\n.#.#.#.#\n"},
+		{"квадраты 2x2", " mask:##../##../..##/..## ", "##..##..\n##..##..\n..##..##\n..##..##\n##..##..\n##..##..\n..##..##\n..##..##\n"},
+		{"одна строка", "mask:#..", "#..#..#.\n#..#..#.\n#..#..#.\n#..#..#.\n#..#..#.\n#..#..#.\n#..#..#.\n#..#..#.\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fn, err := NewPatternRegistry().Lookup(tc.pattern)
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			var got strings.Builder
+			for row := 0; row < board.Height; row++ {
+				for col := 0; col < board.Width; col++ {
+					if fn(board, row, col) {
+						got.WriteByte('#')
+					} else {
+						got.WriteByte('.')
+					}
+				}
+				got.WriteByte('\n')
+			}
+			if got.String() != tc.expected {
+				t.Errorf("ожидалось:\n%s\nполучено:\n%s", tc.expected, got.String())
+			}
+		})
+	}
+}
+
+func TestMaskPattern_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mask     string
+		expected string
+	}{
+		{"пустая маска", "", "пустая маска узора"},
+		{"недопустимый символ", "#x", "недопустимый символ 'x'"},
+		{"строки разной длины", "#./#", "одной длины"},
+		{"пустая строка", "#./", "одной длины"},
+		{"слишком большая маска", strings.Repeat("#", MaxMaskSize+1), "больше 64x64"},
+		{"длинная строка после короткой", "#./" + strings.Repeat("#", 1<<20) + "x", "больше 64x64"},
+		{"слишком много строк", strings.Repeat("#/", MaxMaskSize) + "#", "больше 64x64"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewPatternRegistry().Lookup(MaskPrefix + tc.mask)
+			if !errors.Is(err, domain.ErrInvalidPattern) || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("ожидалась ошибка узора с %q, получено %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"sort"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+// MaxPerftDepth ограничивает глубину perft: число узлов растет экспоненциально
+const MaxPerftDepth = 10
+
+// PerftCase - эталонная позиция и ожидаемое число листьев на глубинах 1, 2, ...
+type PerftCase struct {
+	Name  string
+	FEN   string
+	Nodes []int64
+}
+
+// PerftSuite - эталонные позиции с https://www.chessprogramming.org/Perft_Results,
+// на которых проверяется генератор ходов
+var PerftSuite = []PerftCase{
+	{
+		Name:  "начальная позиция",
+		FEN:   domain.StartFEN,
+		Nodes: []int64{20, 400, 8902, 197281, 4865609},
+	},
+	{
+		Name:  "kiwipete",
+		FEN:   "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1",
+		Nodes: []int64{48, 2039, 97862, 4085603},
+	},
+	{
+		Name:  "позиция 3",
+		FEN:   "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+		Nodes: []int64{14, 191, 2812, 43238, 674624},
+	},
+	{
+		Name:  "позиция 4",
+		FEN:   "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1",
+		Nodes: []int64{6, 264, 9467, 422333},
+	},
+	{
+		Name:  "позиция 5",
+		FEN:   "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8",
+		Nodes: []int64{44, 1486, 62379, 2103487},
+	},
+	{
+		Name:  "позиция 6",
+		FEN:   "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10",
+		Nodes: []int64{46, 2079, 89890, 3894594},
+	},
+}
+
+// Perft считает листья дерева легальных ходов глубины depth и разбивает
+// их число по ходам первого уровня (divide)
+func Perft(board *domain.Board, depth int) (domain.PerftResult, error) {
+	if depth < 1 || depth > MaxPerftDepth {
+		return domain.PerftResult{}, i18n.Errorf("perft.depth", MaxPerftDepth, depth)
+	}
+	p, err := newPosition(board)
+	if err != nil {
+		return domain.PerftResult{}, err
+	}
+	if err := p.validate(); err != nil {
+		return domain.PerftResult{}, err
+	}
+
+	// Буферы ходов для каждого уровня, чтобы обход не выделял память
+	buffers := make([][]move, depth)
+	result := domain.PerftResult{Depth: depth}
+	add := func(m move, nodes int64) {
+		result.Nodes += nodes
+		result.Divide = append(result.Divide, domain.MoveCount{Move: p.domainMove(m), Nodes: nodes})
+	}
+	if p.width == domain.StandardBoardSize && p.height == domain.StandardBoardSize {
+		// Доска 8x8 обходится в битовом представлении, которое заметно быстрее
+		bp := p.bitboards()
+		positions := make([]BitboardPosition, depth)
+		for _, m := range bp.legalMoves(nil) {
+			positions[depth-1] = bp.play(m)
+			add(m, positions[depth-1].perft(depth-1, buffers, positions))
+		}
+	} else {
+		for _, m := range p.legalMoves(nil) {
+			u := p.makeMove(m)
+			nodes := p.perft(depth-1, buffers)
+			p.unmakeMove(m, u)
+			add(m, nodes)
+		}
+	}
+
+	sort.Slice(result.Divide, func(i, j int) bool {
+		return result.Divide[i].Move.String() < result.Divide[j].Move.String()
+	})
+	return result, nil
+}
+
+// perft считает листья дерева глубины depth; на последнем уровне ходы только считаются
+func (p *position) perft(depth int, buffers [][]move) int64 {
+	if depth == 0 {
+		return 1
+	}
+
+	moves := p.legalMoves(buffers[depth][:0])
+	buffers[depth] = moves
+	if depth == 1 {
+		return int64(len(moves))
+	}
+
+	var nodes int64
+	for _, m := range moves {
+		u := p.makeMove(m)
+		nodes += p.perft(depth-1, buffers)
+		p.unmakeMove(m, u)
+	}
+	return nodes
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+)
+
+// perftTestNodes ограничивает размер проверяемых деревьев, чтобы тесты шли секунды;
+// в режиме -short проверяются только небольшие глубины
+const perftTestNodes = 1_000_000
+
+func TestPerft_Suite(t *testing.T) {
+	limit := int64(perftTestNodes)
+	if testing.Short() {
+		limit = 10_000
+	}
+
+	for _, tc := range PerftSuite {
+		t.Run(tc.Name, func(t *testing.T) {
+			board, err := domain.ParseFEN(tc.FEN)
+			if err != nil {
+				t.Fatalf("неверный FEN эталонной позиции: %v", err)
+			}
+
+			for i, expected := range tc.Nodes {
+				if expected > limit {
+					break
+				}
+				result, err := Perft(board, i+1)
+				if err != nil {
+					t.Fatalf("неожиданная ошибка: %v", err)
+				}
+				if result.Nodes != expected {
+					t.Errorf("глубина %d: ожидалось %d, получено %d", i+1, expected, result.Nodes)
+				}
+			}
+
+			// Подсчет не должен менять исходную доску
+			if fen := board.FEN(); fen != tc.FEN {
+				t.Errorf("после perft позиция изменилась: %q", fen)
+			}
+		})
+	}
+}
+
+func TestPerft_Divide(t *testing.T) {
+	board, err := domain.ParseFEN(domain.StartFEN)
+	if err != nil {
+		t.Fatalf("неверный FEN: %v", err)
+	}
+
+	result, err := Perft(board, 3)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	if len(result.Divide) != 20 {
+		t.Fatalf("ожидалось 20 ходов первого уровня, получено %d", len(result.Divide))
+	}
+	var sum int64
+	for i, entry := range result.Divide {
+		sum += entry.Nodes
+		if i > 0 && result.Divide[i-1].Move.String() >= entry.Move.String() {
+			t.Errorf("разбивка не отсортирована: %s перед %s", result.Divide[i-1].Move, entry.Move)
+		}
+	}
+	if sum != result.Nodes || result.Depth != 3 {
+		t.Errorf("сумма разбивки %d не совпадает с итогом %d", sum, result.Nodes)
+	}
+
+	// Значения с https://www.chessprogramming.org/Perft_Results для начальной позиции
+	expected := map[string]int64{"a2a3": 380, "b1c3": 440, "e2e4": 600, "g1h3": 400}
+	for _, entry := range result.Divide {
+		if nodes, ok := expected[entry.Move.String()]; ok && nodes != entry.Nodes {
+			t.Errorf("ход %s: ожидалось %d, получено %d", entry.Move, nodes, entry.Nodes)
+		}
+	}
+}
+
+func TestPerft_Errors(t *testing.T) {
+	board, err := domain.ParseFEN(domain.StartFEN)
+	if err != nil {
+		t.Fatalf("неверный FEN: %v", err)
+	}
+
+	for _, depth := range []int{0, -1, MaxPerftDepth + 1} {
+		if _, err := Perft(board, depth); err == nil || !strings.Contains(err.Error(), "глубина perft") {
+			t.Errorf("глубина %d: ожидалась ошибка о глубине, получено %v", depth, err)
+		}
+	}
+}
+
+func TestBoardUsecase_Perft(t *testing.T) {
+	usecase := NewBoardUsecase(NewBoardRepository())
+	board := mustCreateBoard(t, usecase, 8, 8, domain.SetupStandard)
+
+	result, err := usecase.Perft(board, 2)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if result.Nodes != 400 {
+		t.Errorf("ожидалось 400 узлов, получено %d", result.Nodes)
+	}
+}
+
+func BenchmarkPerft(b *testing.B) {
+	board, err := domain.ParseFEN(PerftSuite[1].FEN)
+	if err != nil {
+		b.Fatalf("неверный FEN: %v", err)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Perft(board, 3); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
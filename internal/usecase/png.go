@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+// MaxImagePixels ограничивает площадь PNG-изображения, чтобы не исчерпать память
+const MaxImagePixels = 64 << 20
+
+// Индексы цветов в палитре PNG-изображения
+const (
+	pngFrame = iota
+	pngLight
+	pngDark
+	pngWhitePiece
+	pngBlackPiece
+)
+
+// WritePNG записывает доску в w как PNG-изображение. Используется
+// палитровое изображение - по байту на пиксель независимо от числа цветов.
+// Фигуры всегда рисуются буквами встроенного шрифта: Unicode-символов в нем нет.
+func WritePNG(w io.Writer, board *domain.Board, pattern PatternFunc, opts domain.RenderOptions) error {
+	layout, err := newImageLayout(board, opts)
+	if err != nil {
+		return err
+	}
+	// Площадь сравнивается делением, чтобы произведение сторон не переполнилось
+	if layout.width > MaxImagePixels/layout.height {
+		return i18n.Errorf("image.too_large", layout.width, layout.height, MaxImagePixels)
+	}
+	palette, err := ResolvePalette(opts)
+	if err != nil {
+		return err
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, layout.width, layout.height), color.Palette{
+		pngFrame: frameColor.rgba(),
+		pngLight: palette.Light.rgba(),
+		pngDark:  palette.Dark.rgba(),
+
+		pngWhitePiece: whitePieceColor.rgba(),
+		pngBlackPiece: blackPieceColor.rgba(),
+	})
+	// Нулевой индекс палитры - цвет рамки, поэтому рамку отдельно рисовать не нужно
+	light := image.NewUniform(palette.Light.rgba())
+	dark := image.NewUniform(palette.Dark.rgba())
+
+	for i := 0; i < board.Height; i++ {
+		for j := 0; j < board.Width; j++ {
+			r, c := boardCoords(board, opts.Orientation, i, j)
+			src := light
+			if pattern(board, r, c) {
+				src = dark
+			}
+			x, y := layout.squareOrigin(i, j)
+			draw.Draw(img, image.Rect(x, y, x+layout.square, y+layout.square), src, image.Point{}, draw.Src)
+		}
+	}
+
+	if board.HasPieces() {
+		drawPNGPieces(img, board, layout, opts.Orientation)
+	}
+	if opts.Coordinates && layout.border > 0 {
+		drawPNGLabels(img, board, layout, opts.Orientation, light)
+	}
+
+	return png.Encode(w, img)
+}
+
+// drawPNGLabels рисует обозначения вертикалей и горизонталей в рамке вокруг доски
+func drawPNGLabels(img draw.Image, board *domain.Board, layout imageLayout, orientation domain.Orientation, src image.Image) {
+	// Масштаб шрифта подбирается так, чтобы метка занимала около 60% ширины рамки
+	scale := max(layout.border*3/5/glyphHeight, 1)
+	half := layout.border / 2
+
+	for j := 0; j < board.Width; j++ {
+		_, c := boardCoords(board, orientation, 0, j)
+		x, _ := layout.squareOrigin(0, j)
+		x += layout.square / 2
+		label := domain.FileName(c)
+		drawText(img, label, x, half, scale, src)
+		drawText(img, label, x, layout.height-half, scale, src)
+	}
+	for i := 0; i < board.Height; i++ {
+		r, _ := boardCoords(board, orientation, i, 0)
+		_, y := layout.squareOrigin(i, 0)
+		y += layout.square / 2
+		label := domain.RankName(board.Height - 1 - r)
+		drawText(img, label, half, y, scale, src)
+		drawText(img, label, layout.width-half, y, scale, src)
+	}
+}
+
+// drawPNGPieces рисует фигуры буквами встроенного шрифта по центру клеток
+func drawPNGPieces(img draw.Image, board *domain.Board, layout imageLayout, orientation domain.Orientation) {
+	scale := max(layout.square*3/5/glyphHeight, 1)
+	colors := [2]image.Image{
+		image.NewUniform(whitePieceColor.rgba()),
+		image.NewUniform(blackPieceColor.rgba()),
+	}
+
+	for i := 0; i < board.Height; i++ {
+		for j := 0; j < board.Width; j++ {
+			r, c := boardCoords(board, orientation, i, j)
+			piece := board.PieceAt(c, board.Height-1-r)
+			if piece.IsEmpty() {
+				continue
+			}
+			// Для белых и черных используется одна заглавная буква, цвет различает стороны
+			letter := string(domain.Piece{Color: domain.White, Kind: piece.Kind}.Letter())
+			x, y := layout.squareOrigin(i, j)
+			x, y = x+layout.square/2, y+layout.square/2
+			if piece.Color == domain.White {
+				// Тень делает белую букву заметной на светлой клетке
+				drawText(img, letter, x+scale, y+scale, scale, colors[domain.Black])
+			}
+			drawText(img, letter, x, y, scale, colors[piece.Color])
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"testing"
+
+	"chessboard/internal/domain"
+)
+
+// decodePNG отрисовывает доску в PNG и декодирует результат
+func decodePNG(t *testing.T, board *domain.Board, opts domain.RenderOptions) image.Image {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := WritePNG(&buf, board, CheckerPattern, opts); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("результат не является корректным PNG: %v", err)
+	}
+	return img
+}
+
+// sameColor сравнивает цвет пикселя с ожидаемым цветом палитры
+func sameColor(actual color.Color, expected Color) bool {
+	r, g, b, _ := actual.RGBA()
+	return uint8(r>>8) == expected.R && uint8(g>>8) == expected.G && uint8(b>>8) == expected.B
+}
+
+func TestWritePNG_Squares(t *testing.T) {
+	board := &domain.Board{Width: 10, Height: 8}
+	opts := domain.RenderOptions{SquareSize: 10, LightColor: "#ffffff", DarkColor: "#000000"}
+	img := decodePNG(t, board, opts)
+
+	if bounds := img.Bounds(); bounds.Dx() != 100 || bounds.Dy() != 80 {
+		t.Fatalf("ожидался размер 100x80, получен %dx%d", bounds.Dx(), bounds.Dy())
+	}
+
+	white, black := Color{255, 255, 255}, Color{0, 0, 0}
+	testCases := []struct {
+		name     string
+		x, y     int
+		expected Color
+	}{
+		{"левый верхний угол светлый", 5, 5, white},
+		{"соседняя клетка темная", 15, 5, black},
+		{"вторая строка начинается с темной", 5, 15, black},
+		{"правый нижний угол светлый", 95, 75, white},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := img.At(tc.x, tc.y); !sameColor(actual, tc.expected) {
+				t.Errorf("пиксель (%d,%d): ожидался цвет %s, получен %v", tc.x, tc.y, tc.expected.Hex(), actual)
+			}
+		})
+	}
+}
+
+func TestWritePNG_BlackOrientation(t *testing.T) {
+	// На доске нечетной ширины поворот меняет цвет левой верхней клетки
+	board := &domain.Board{Width: 5, Height: 4}
+	opts := domain.RenderOptions{
+		SquareSize:  4,
+		Orientation: domain.OrientationBlack,
+		LightColor:  "#ffffff",
+		DarkColor:   "#000000",
+	}
+	img := decodePNG(t, board, opts)
+
+	if !sameColor(img.At(1, 1), Color{0, 0, 0}) {
+		t.Errorf("при виде со стороны черных левая верхняя клетка доски 5x4 должна быть темной")
+	}
+}
+
+func TestWritePNG_Pieces(t *testing.T) {
+	board := &domain.Board{Width: 4, Height: 4}
+	board.SetPiece(0, 3, domain.Piece{Color: domain.White, Kind: domain.Pawn})
+	board.SetPiece(3, 3, domain.Piece{Color: domain.Black, Kind: domain.Pawn})
+	opts := domain.RenderOptions{SquareSize: 20, LightColor: "#808080", DarkColor: "#808080"}
+	img := decodePNG(t, board, opts)
+
+	// countColor считает пиксели заданного цвета в клетке, выводимой в столбце j первой строки
+	countColor := func(j int, expected Color) int {
+		count := 0
+		for y := 0; y < 20; y++ {
+			for x := j * 20; x < (j+1)*20; x++ {
+				if sameColor(img.At(x, y), expected) {
+					count++
+				}
+			}
+		}
+		return count
+	}
+
+	if countColor(0, whitePieceColor) == 0 {
+		t.Error("на клетке a4 должна быть нарисована белая фигура")
+	}
+	if countColor(3, blackPieceColor) == 0 {
+		t.Error("на клетке d4 должна быть нарисована черная фигура")
+	}
+	if countColor(1, whitePieceColor)+countColor(1, blackPieceColor) != 0 {
+		t.Error("пустая клетка b4 не должна содержать фигур")
+	}
+}
+
+func TestWritePNG_Coordinates(t *testing.T) {
+	board := &domain.Board{Width: 8, Height: 8}
+	opts := domain.RenderOptions{SquareSize: 20, Border: 20, Coordinates: true, LightColor: "#ffffff"}
+	img := decodePNG(t, board, opts)
+
+	if bounds := img.Bounds(); bounds.Dx() != 200 || bounds.Dy() != 200 {
+		t.Fatalf("ожидался размер 200x200 с рамкой, получен %dx%d", bounds.Dx(), bounds.Dy())
+	}
+
+	// В рамке над вертикалью "a" должны быть пиксели метки цвета светлых клеток
+	labelPixels := 0
+	for y := 0; y < 20; y++ {
+		for x := 20; x < 40; x++ {
+			if sameColor(img.At(x, y), Color{255, 255, 255}) {
+				labelPixels++
+			}
+		}
+	}
+	if labelPixels == 0 {
+		t.Error("в рамке не найдены пиксели координатной метки")
+	}
+	// Угол рамки остается цвета рамки
+	if !sameColor(img.At(0, 0), frameColor) {
+		t.Errorf("угол рамки должен быть цвета %s", frameColor.Hex())
+	}
+}
+
+func TestWritePNG_Errors(t *testing.T) {
+	errorCases := []struct {
+		name  string
+		board *domain.Board
+		opts  domain.RenderOptions
+	}{
+		{"слишком большое изображение", &domain.Board{Width: 1000, Height: 1000}, domain.RenderOptions{}},
+		{"неверный размер клетки", &domain.Board{Width: 8, Height: 8}, domain.RenderOptions{SquareSize: -4}},
+		{"огромная рамка", &domain.Board{Width: 8, Height: 8}, domain.RenderOptions{Border: 4611686018427387904}},
+		{"огромная доска", &domain.Board{Width: 40000, Height: 40000}, domain.RenderOptions{SquareSize: MaxSquareSize}},
+		{"неизвестная палитра", &domain.Board{Width: 8, Height: 8}, domain.RenderOptions{Palette: "rainbow"}},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := WritePNG(io.Discard, tc.board, CheckerPattern, tc.opts); err == nil {
+				t.Error("ожидалась ошибка, но ошибки нет")
+			}
+		})
+	}
+}
+
+func TestBoardUsecase_RenderPNG(t *testing.T) {
+	usecase := NewBoardUsecase(&MockBoardRepository{})
+
+	var buf bytes.Buffer
+	opts := domain.RenderOptions{Format: domain.FormatPNG, SquareSize: 8}
+	if err := usecase.Render(&buf, &domain.Board{Width: 4, Height: 4}, opts); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if _, err := png.Decode(&buf); err != nil {
+		t.Errorf("сервис вернул некорректный PNG: %v", err)
+	}
+}
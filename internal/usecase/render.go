@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"chessboard/internal/domain"
+)
+
+// ansiReset сбрасывает цвета терминала в конце строки доски
+const ansiReset = "\x1b[0m"
+
+// WritePattern потоково записывает доску в w построчно.
+// Память расходуется только на буфер одной строки, поэтому размер доски
+// ограничен лишь скоростью получателя. Как и RenderPattern, не добавляет
+// перевод строки после последней строки.
+func WritePattern(w io.Writer, board *domain.Board, pattern PatternFunc) error {
+	return WriteBoard(w, board, pattern, domain.RenderOptions{})
+}
+
+// WriteBoard потоково записывает доску в w с учетом параметров отрисовки:
+// темы и палитры, координатных меток по краям и стороны, с которой смотрят на доску.
+// Поле opts.Pattern не используется - узор передается явно.
+func WriteBoard(w io.Writer, board *domain.Board, pattern PatternFunc, opts domain.RenderOptions) error {
+	theme, err := LookupTheme(opts.Theme)
+	if err != nil {
+		return err
+	}
+
+	var palette Palette
+	if theme.Colored {
+		if palette, err = ResolvePalette(opts); err != nil {
+			return err
+		}
+	}
+
+	if board.Width <= 0 || board.Height <= 0 {
+		return nil
+	}
+
+	// Ширина клетки растет, если обозначения вертикалей длиннее одной буквы:
+	// метка плюс пробел, чтобы соседние метки не сливались
+	cellWidth, rankWidth := theme.CellWidth, 0
+	var files []byte
+	if opts.Coordinates {
+		rankWidth = len(domain.RankName(board.Height - 1))
+		if labelWidth := len(domain.FileName(board.Width - 1)); labelWidth > 1 {
+			cellWidth = labelWidth + 1
+		}
+		files = append(fileLabels(board, opts.Orientation, rankWidth, cellWidth), '\n')
+
+		if _, err := w.Write(files); err != nil {
+			return err
+		}
+	}
+
+	// Цвет фона задается escape-последовательностью в начале каждой клетки,
+	// цвет фигуры - escape-последовательностью цвета текста
+	var lightBackground, darkBackground string
+	var pieceForeground [2]string
+	var highlights map[domain.Square]string
+	if theme.Colored {
+		lightBackground = palette.Light.ansiBackground()
+		darkBackground = palette.Dark.ansiBackground()
+		pieceForeground = [2]string{whitePieceColor.ansiForeground(), blackPieceColor.ansiForeground()}
+		if highlights, err = highlightBackgrounds(opts.Highlights); err != nil {
+			return err
+		}
+	}
+	lightCell := lightBackground + strings.Repeat(theme.Light, cellWidth)
+	darkCell := darkBackground + strings.Repeat(theme.Dark, cellWidth)
+	pieceCell := len(darkBackground) + len(pieceForeground[0]) + utf8.UTFMax + len(darkCell)
+
+	row := make([]byte, 0, 2*(rankWidth+1)+board.Width*max(len(lightCell), len(darkCell), pieceCell)+len(ansiReset)+1)
+
+	for i := 0; i < board.Height; i++ {
+		r, _ := boardCoords(board, opts.Orientation, i, 0)
+
+		row = row[:0]
+		var rank string
+		if opts.Coordinates {
+			rank = domain.RankName(board.Height - 1 - r)
+			row = appendPadded(row, rank, rankWidth, true)
+			row = append(row, ' ')
+		}
+
+		for j := 0; j < board.Width; j++ {
+			_, c := boardCoords(board, opts.Orientation, i, j)
+			piece := board.PieceAt(c, board.Height-1-r)
+
+			background, fill, cell := lightBackground, theme.Light, lightCell
+			if pattern(board, r, c) {
+				background, fill, cell = darkBackground, theme.Dark, darkCell
+			}
+			if highlights != nil {
+				if highlight, ok := highlights[domain.Square{File: c, Rank: board.Height - 1 - r}]; ok {
+					background, cell = highlight, highlight+strings.Repeat(fill, cellWidth)
+				}
+			}
+			if piece.IsEmpty() {
+				row = append(row, cell...)
+				continue
+			}
+			// Фигура занимает первый символ клетки, остаток заполняется фоном клетки
+			row = append(row, background...)
+			row = append(row, pieceForeground[piece.Color&1]...)
+			row = utf8.AppendRune(row, textPieceSymbol(piece, opts.Pieces, theme))
+			for k := 1; k < cellWidth; k++ {
+				row = append(row, fill...)
+			}
+		}
+		if theme.Colored {
+			row = append(row, ansiReset...)
+		}
+
+		if opts.Coordinates {
+			// Метка справа не дополняется пробелами, чтобы строка не кончалась ими
+			row = append(row, ' ')
+			row = append(row, rank...)
+		}
+		// После последней строки перевод строки не пишем
+		if i < board.Height-1 || opts.Coordinates {
+			row = append(row, '\n')
+		}
+		if _, err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	if opts.Coordinates {
+		// Нижняя строка меток совпадает с верхней, но без перевода строки
+		if _, err := w.Write(files[:len(files)-1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// highlightBackgrounds переводит цвета подсвеченных клеток в escape-последовательности фона
+func highlightBackgrounds(colors map[domain.Square]string) (map[domain.Square]string, error) {
+	if len(colors) == 0 {
+		return nil, nil
+	}
+	backgrounds := make(map[domain.Square]string, len(colors))
+	for square, value := range colors {
+		c, err := ParseColor(value)
+		if err != nil {
+			return nil, err
+		}
+		backgrounds[square] = c.ansiBackground()
+	}
+	return backgrounds, nil
+}
+
+// textPieceSymbol возвращает символ фигуры для текстовой доски. В цветной теме
+// стороны различаются цветом текста, поэтому для обеих используются залитые символы.
+func textPieceSymbol(piece domain.Piece, style domain.PieceStyle, theme Theme) rune {
+	if theme.Colored && style == domain.PieceStyleUnicode {
+		piece.Color = domain.Black
+	}
+	return piece.Symbol(style)
+}
+
+// fileLabels строит строку с обозначениями вертикалей, выровненную по клеткам.
+// Последняя метка не дополняется пробелами.
+func fileLabels(board *domain.Board, orientation domain.Orientation, rankWidth, cellWidth int) []byte {
+	line := make([]byte, 0, rankWidth+1+board.Width*cellWidth)
+	line = appendPadded(line, "", rankWidth+1, false)
+
+	for j := 0; j < board.Width; j++ {
+		_, file := boardCoords(board, orientation, 0, j)
+		label := domain.FileName(file)
+		if j == board.Width-1 {
+			return append(line, label...)
+		}
+		line = appendPadded(line, label, cellWidth, false)
+	}
+	return line
+}
+
+// appendPadded дополняет метку пробелами до ширины width слева или справа
+func appendPadded(dst []byte, label string, width int, alignRight bool) []byte {
+	pad := width - len(label)
+	if alignRight {
+		for ; pad > 0; pad-- {
+			dst = append(dst, ' ')
+		}
+		return append(dst, label...)
+	}
+
+	dst = append(dst, label...)
+	for ; pad > 0; pad-- {
+		dst = append(dst, ' ')
+	}
+	return dst
+}
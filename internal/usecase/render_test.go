@@ -0,0 +1,378 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+)
+
+// failingWriter возвращает ошибку после заданного числа успешных записей
+type failingWriter struct {
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.writes == 0 {
+		return 0, errors.New("запись невозможна")
+	}
+	w.writes--
+	return len(p), nil
+}
+
+func TestWritePattern_MatchesRenderPattern(t *testing.T) {
+	boards := []*domain.Board{
+		{Width: 1, Height: 1},
+		{Width: 4, Height: 4},
+		{Width: 10, Height: 8},
+		{Width: 9, Height: 10},
+		{Width: 100, Height: 100},
+	}
+	patterns := map[string]PatternFunc{
+		"checker": CheckerPattern,
+		"rings":   RingsPattern,
+	}
+
+	for name, pattern := range patterns {
+		for _, board := range boards {
+			var buf bytes.Buffer
+			if err := WritePattern(&buf, board, pattern); err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+
+			if expected := RenderPattern(board, pattern); buf.String() != expected {
+				t.Errorf("узор %s, доска %dx%d: потоковый вывод отличается от строкового",
+					name, board.Width, board.Height)
+			}
+		}
+	}
+}
+
+func TestWritePattern_EmptyBoard(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePattern(&buf, &domain.Board{}, CheckerPattern); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("для пустой доски ожидался пустой вывод, получено: '%s'", buf.String())
+	}
+}
+
+func TestWritePattern_WriterError(t *testing.T) {
+	writer := &failingWriter{writes: 2}
+	err := WritePattern(writer, &domain.Board{Width: 8, Height: 8}, CheckerPattern)
+
+	if err == nil {
+		t.Error("ожидалась ошибка записи, но ошибки нет")
+	}
+}
+
+func TestWritePattern_BoundedAllocations(t *testing.T) {
+	// Количество аллокаций не должно зависеть от высоты доски:
+	// в памяти держится только буфер одной строки
+	for _, height := range []int{8, 1000, domain.MaxBoardSize} {
+		board := &domain.Board{Width: 64, Height: height}
+		allocs := testing.AllocsPerRun(5, func() {
+			_ = WritePattern(io.Discard, board, CheckerPattern)
+		})
+
+		if allocs > 1 {
+			t.Errorf("доска 64x%d: ожидалось не более 1 аллокации, получено %.0f", height, allocs)
+		}
+	}
+}
+
+func TestWriteBoard_Coordinates(t *testing.T) {
+	testCases := []struct {
+		name     string
+		board    *domain.Board
+		opts     domain.RenderOptions
+		expected string
+	}{
+		{
+			name:  "вид со стороны белых",
+			board: &domain.Board{Width: 4, Height: 4},
+			opts:  domain.RenderOptions{Coordinates: true},
+			expected: "  abcd\n" +
+				"4  # # 4\n" +
+				"3 # #  3\n" +
+				"2  # # 2\n" +
+				"1 # #  1\n" +
+				"  abcd",
+		},
+		{
+			name:  "вид со стороны черных",
+			board: &domain.Board{Width: 4, Height: 4},
+			opts:  domain.RenderOptions{Coordinates: true, Orientation: domain.OrientationBlack},
+			expected: "  dcba\n" +
+				"1  # # 1\n" +
+				"2 # #  2\n" +
+				"3  # # 3\n" +
+				"4 # #  4\n" +
+				"  dcba",
+		},
+		{
+			name:  "двузначные горизонтали",
+			board: &domain.Board{Width: 4, Height: 10},
+			opts:  domain.RenderOptions{Coordinates: true},
+			expected: "   abcd\n" +
+				"10  # # 10\n" +
+				" 9 # #  9\n" +
+				" 8  # # 8\n" +
+				" 7 # #  7\n" +
+				" 6  # # 6\n" +
+				" 5 # #  5\n" +
+				" 4  # # 4\n" +
+				" 3 # #  3\n" +
+				" 2  # # 2\n" +
+				" 1 # #  1\n" +
+				"   abcd",
+		},
+		{
+			name:     "черные без координат",
+			board:    &domain.Board{Width: 3, Height: 2},
+			opts:     domain.RenderOptions{Orientation: domain.OrientationBlack},
+			expected: "# #\n # ",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteBoard(&buf, tc.board, CheckerPattern, tc.opts); err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if buf.String() != tc.expected {
+				t.Errorf("ожидалось:\n%s\nполучено:\n%s", tc.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestWriteBoard_Pieces(t *testing.T) {
+	board := &domain.Board{Width: 4, Height: 4}
+	board.SetPiece(0, 0, domain.Piece{Color: domain.White, Kind: domain.King})
+	board.SetPiece(3, 3, domain.Piece{Color: domain.Black, Kind: domain.Queen})
+	board.SetPiece(1, 2, domain.Piece{Color: domain.Black, Kind: domain.Pawn})
+
+	testCases := []struct {
+		name     string
+		opts     domain.RenderOptions
+		expected string
+	}{
+		{
+			name: "буквы",
+			opts: domain.RenderOptions{},
+			expected: " # q\n" +
+				"#p# \n" +
+				" # #\n" +
+				"K # ",
+		},
+		{
+			name: "символы unicode",
+			opts: domain.RenderOptions{Pieces: domain.PieceStyleUnicode, Coordinates: true},
+			expected: "  abcd\n" +
+				"4  # ♛ 4\n" +
+				"3 #♟#  3\n" +
+				"2  # # 2\n" +
+				"1 ♔ #  1\n" +
+				"  abcd",
+		},
+		{
+			name: "вид со стороны черных",
+			opts: domain.RenderOptions{Orientation: domain.OrientationBlack},
+			expected: " # K\n" +
+				"# # \n" +
+				" #p#\n" +
+				"q # ",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteBoard(&buf, board, CheckerPattern, tc.opts); err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if buf.String() != tc.expected {
+				t.Errorf("ожидалось:\n%s\nполучено:\n%s", tc.expected, buf.String())
+			}
+		})
+	}
+
+	t.Run("цветная тема", func(t *testing.T) {
+		var buf bytes.Buffer
+		opts := domain.RenderOptions{Theme: "ansi", Pieces: domain.PieceStyleUnicode}
+		if err := WriteBoard(&buf, board, CheckerPattern, opts); err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		// Стороны различаются цветом текста, поэтому белый король рисуется залитым символом
+		if !strings.Contains(buf.String(), whitePieceColor.ansiForeground()+"♚") {
+			t.Errorf("ожидался залитый белый король, получено %q", buf.String())
+		}
+		if !strings.Contains(buf.String(), blackPieceColor.ansiForeground()+"♛") {
+			t.Errorf("ожидался черный ферзь, получено %q", buf.String())
+		}
+	})
+}
+
+func TestWriteBoard_Highlights(t *testing.T) {
+	board := &domain.Board{Width: 4, Height: 4}
+	board.SetPiece(0, 0, domain.Piece{Color: domain.White, Kind: domain.King})
+	highlights := map[domain.Square]string{{File: 0, Rank: 0}: "#ff0000", {File: 3, Rank: 3}: "#00f"}
+	red, blue := "\x1b[48;2;255;0;0m", "\x1b[48;2;0;0;255m"
+
+	t.Run("цветная тема", func(t *testing.T) {
+		var buf bytes.Buffer
+		opts := domain.RenderOptions{Theme: "ansi", Highlights: highlights}
+		if err := WriteBoard(&buf, board, CheckerPattern, opts); err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		lines := strings.Split(buf.String(), "\n")
+		// Подсветка заменяет фон узора, а фигура остается на клетке
+		if !strings.HasPrefix(lines[3], red+whitePieceColor.ansiForeground()+"K ") {
+			t.Errorf("клетка a1 должна быть подсвечена, получено %q", lines[3])
+		}
+		if !strings.Contains(lines[0], blue+"  ") || strings.Count(buf.String(), red)+strings.Count(buf.String(), blue) != 2 {
+			t.Errorf("должны быть подсвечены только a1 и d4, получено %q", buf.String())
+		}
+	})
+
+	t.Run("текстовая тема без цвета", func(t *testing.T) {
+		var plain, highlighted bytes.Buffer
+		if err := WriteBoard(&plain, board, CheckerPattern, domain.RenderOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteBoard(&highlighted, board, CheckerPattern, domain.RenderOptions{Highlights: highlights}); err != nil {
+			t.Fatal(err)
+		}
+		if plain.String() != highlighted.String() {
+			t.Errorf("тема без цвета не должна менять вывод, получено %q", highlighted.String())
+		}
+	})
+
+	t.Run("неверный цвет", func(t *testing.T) {
+		opts := domain.RenderOptions{Theme: "ansi", Highlights: map[domain.Square]string{{}: "red"}}
+		if err := WriteBoard(io.Discard, board, CheckerPattern, opts); err == nil {
+			t.Error("ожидалась ошибка для неверного цвета подсветки")
+		}
+	})
+}
+
+func TestWriteBoard_MultiLetterFiles(t *testing.T) {
+	var buf bytes.Buffer
+	board := &domain.Board{Width: 28, Height: 4}
+	if err := WriteBoard(&buf, board, CheckerPattern, domain.RenderOptions{Coordinates: true}); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	if len(lines) != board.Height+2 {
+		t.Fatalf("ожидалось %d строк, получено %d", board.Height+2, len(lines))
+	}
+	if !strings.HasSuffix(lines[0], "z  aa ab") {
+		t.Errorf("метки вертикалей после 'z' должны быть двухбуквенными: '%s'", lines[0])
+	}
+	// Каждая клетка занимает три символа, чтобы вместить метку и разделитель
+	if expected := 2 + 28*3 + 2; len(lines[1]) != expected {
+		t.Errorf("ожидалась длина строки %d, получено %d", expected, len(lines[1]))
+	}
+	if lines[0] != lines[len(lines)-1] {
+		t.Error("верхняя и нижняя строки меток должны совпадать")
+	}
+}
+
+func TestWriteBoard_NoTrailingSpaces(t *testing.T) {
+	// С координатами каждая строка заканчивается меткой, а не пробелами
+	// выравнивания, в том числе при многобуквенных вертикалях и многозначных горизонталях
+	testCases := []struct {
+		name  string
+		board *domain.Board
+		opts  domain.RenderOptions
+	}{
+		{"8x8", &domain.Board{Width: 8, Height: 8}, domain.RenderOptions{Coordinates: true}},
+		{"30x4", &domain.Board{Width: 30, Height: 4}, domain.RenderOptions{Coordinates: true}},
+		{"4x10", &domain.Board{Width: 4, Height: 10}, domain.RenderOptions{Coordinates: true}},
+		{"вид со стороны черных", &domain.Board{Width: 30, Height: 12}, domain.RenderOptions{Coordinates: true, Orientation: domain.OrientationBlack}},
+		{"unicode", &domain.Board{Width: 28, Height: 4}, domain.RenderOptions{Theme: "unicode", Coordinates: true}},
+		{"ansi", &domain.Board{Width: 28, Height: 10}, domain.RenderOptions{Theme: "ansi", Coordinates: true}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteBoard(&buf, tc.board, CheckerPattern, tc.opts); err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			for i, line := range strings.Split(buf.String(), "\n") {
+				if trimmed := strings.TrimRight(line, " \t"); trimmed != line {
+					t.Errorf("строка %d заканчивается пробелами: %q", i+1, line)
+				}
+			}
+		})
+	}
+}
+
+func TestBoardUsecase_Render(t *testing.T) {
+	usecase := NewBoardUsecase(&MockBoardRepository{})
+	board := &domain.Board{Width: 4, Height: 4}
+
+	t.Run("узор из параметров", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := usecase.Render(&buf, board, domain.RenderOptions{Pattern: "stripes"}); err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		if expected := RenderPattern(board, StripesPattern); buf.String() != expected {
+			t.Errorf("ожидалось:\n%s\nполучено:\n%s", expected, buf.String())
+		}
+	})
+
+	t.Run("узор, выбранный в сервисе", func(t *testing.T) {
+		if err := usecase.SetPattern("rings"); err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := usecase.Render(&buf, board, domain.RenderOptions{}); err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		if expected := RenderPattern(board, RingsPattern); buf.String() != expected {
+			t.Errorf("ожидалось:\n%s\nполучено:\n%s", expected, buf.String())
+		}
+	})
+
+	t.Run("неизвестный узор", func(t *testing.T) {
+		if err := usecase.Render(io.Discard, board, domain.RenderOptions{Pattern: "zigzag"}); err == nil {
+			t.Error("ожидалась ошибка для неизвестного узора")
+		}
+	})
+}
+
+// Бенчмарки сравнивают построение строки с потоковой записью.
+// Запуск: go test -bench=Render -benchmem ./internal/usecase/...
+func BenchmarkRenderString(b *testing.B) {
+	for _, size := range []int{8, 100, 1000} {
+		board := &domain.Board{Width: size, Height: size}
+		b.Run(benchmarkName(size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = RenderPattern(board, CheckerPattern)
+			}
+		})
+	}
+}
+
+func BenchmarkRenderStream(b *testing.B) {
+	for _, size := range []int{8, 100, 1000} {
+		board := &domain.Board{Width: size, Height: size}
+		b.Run(benchmarkName(size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = WritePattern(io.Discard, board, CheckerPattern)
+			}
+		})
+	}
+}
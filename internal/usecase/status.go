@@ -0,0 +1,48 @@
+package usecase
+
+import "chessboard/internal/domain"
+
+// Status определяет состояние партии: шах, мат, пат, ничью по правилам 50 и 75 ходов,
+// недостаточность материала и победившую сторону. Повторения позиции по одной
+// доске определить нельзя, для них нужна история партии (NewGame).
+func Status(board *domain.Board) (domain.GameStatus, error) {
+	p, err := newPosition(board)
+	if err != nil {
+		return domain.GameStatus{}, err
+	}
+	if err := p.validate(); err != nil {
+		return domain.GameStatus{}, err
+	}
+	return p.status(1), nil
+}
+
+// status определяет состояние партии в позиции, которая встретилась repetitions раз.
+// Мат и пат важнее ничьих по счетчикам: мат последним ходом завершает партию,
+// даже если одновременно истекли 75 ходов.
+func (p *position) status(repetitions int) domain.GameStatus {
+	status := domain.GameStatus{
+		SideToMove:          p.side,
+		InCheck:             p.inCheck(),
+		FiftyMoves:          p.halfmove >= domain.FiftyMovePlies,
+		SeventyFiveMoves:    p.halfmove >= domain.SeventyFiveMovePlies,
+		ThreefoldRepetition: repetitions >= domain.RepetitionClaimCount,
+		FivefoldRepetition:  repetitions >= domain.RepetitionDrawCount,
+	}
+
+	noMoves := len(p.legalMoves(nil)) == 0
+	board := domain.Board{Width: p.width, Height: p.height, Squares: p.squares}
+	switch {
+	case noMoves && status.InCheck:
+		status.Checkmate = true
+		status.Result = domain.WinResult(p.side.Opposite())
+	case noMoves:
+		status.Stalemate = true
+		status.Result = domain.ResultDraw
+	case status.SeventyFiveMoves || status.FivefoldRepetition:
+		status.Result = domain.ResultDraw
+	case board.InsufficientMaterial():
+		status.InsufficientMaterial = true
+		status.Result = domain.ResultDraw
+	}
+	return status
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+)
+
+func TestStatus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fen      string
+		expected domain.GameStatus
+	}{
+		{
+			name:     "начальная позиция",
+			fen:      domain.StartFEN,
+			expected: domain.GameStatus{SideToMove: domain.White},
+		},
+		{
+			name:     "шах",
+			fen:      "4k3/8/8/8/8/8/8/4R1K1 b - - 0 1",
+			expected: domain.GameStatus{SideToMove: domain.Black, InCheck: true},
+		},
+		{
+			name: "детский мат",
+			fen:  "r1bqkb1r/pppp1Qpp/2n2n2/4p3/2B1P3/8/PPPP1PPP/RNB1K1NR b KQkq - 0 4",
+			expected: domain.GameStatus{
+				SideToMove: domain.Black, InCheck: true, Checkmate: true, Result: domain.ResultWhiteWins,
+			},
+		},
+		{
+			name: "мат черных",
+			fen:  "rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3",
+			expected: domain.GameStatus{
+				SideToMove: domain.White, InCheck: true, Checkmate: true, Result: domain.ResultBlackWins,
+			},
+		},
+		{
+			name: "пат",
+			fen:  "7k/5Q2/6K1/8/8/8/8/8 b - - 0 1",
+			expected: domain.GameStatus{
+				SideToMove: domain.Black, Stalemate: true, Result: domain.ResultDraw,
+			},
+		},
+		{
+			name: "недостаточно материала",
+			fen:  "4k3/8/8/8/8/8/8/4KB2 w - - 0 1",
+			expected: domain.GameStatus{
+				SideToMove: domain.White, InsufficientMaterial: true, Result: domain.ResultDraw,
+			},
+		},
+		{
+			name: "правило 75 ходов по счетчику в FEN",
+			fen:  "4k3/8/8/8/8/8/8/R3K3 b - - 150 100",
+			expected: domain.GameStatus{
+				SideToMove: domain.Black, FiftyMoves: true, SeventyFiveMoves: true, Result: domain.ResultDraw,
+			},
+		},
+		{
+			name: "мат двумя ладьями на доске 10x10",
+			fen:  "k9/10/10/10/10/10/10/10/10/RR6K1 b - - 0 1",
+			expected: domain.GameStatus{
+				SideToMove: domain.Black, InCheck: true, Checkmate: true, Result: domain.ResultWhiteWins,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			board, err := domain.ParseFEN(tc.fen)
+			if err != nil {
+				t.Fatalf("неверный FEN: %v", err)
+			}
+			status, err := Status(board)
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if status != tc.expected {
+				t.Errorf("ожидалось %+v, получено %+v", tc.expected, status)
+			}
+		})
+	}
+}
+
+func TestStatus_Errors(t *testing.T) {
+	board, err := domain.ParseFEN("4k3/4R3/8/8/8/8/8/4K3 w - - 0 1")
+	if err != nil {
+		t.Fatalf("неверный FEN: %v", err)
+	}
+
+	_, err = Status(board)
+	if err == nil || !strings.Contains(err.Error(), "король черных под шахом") {
+		t.Errorf("ожидалась ошибка о шахе стороне без хода, получено: %v", err)
+	}
+}
+
+func TestBoardUsecase_Status(t *testing.T) {
+	usecase := NewBoardUsecase(NewBoardRepository())
+	board := mustCreateBoard(t, usecase, 8, 8, domain.SetupStandard)
+
+	status, err := usecase.Status(board)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if status.IsOver() || status.InCheck {
+		t.Errorf("в начальной позиции партия продолжается без шаха, получено %+v", status)
+	}
+}
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+const (
+	// DefaultSquareSize - размер клетки в пикселях для графических форматов
+	DefaultSquareSize = 40
+	// MaxSquareSize ограничивает размер клетки, чтобы изображение оставалось разумным
+	MaxSquareSize = 512
+	// MaxBorder ограничивает ширину рамки вокруг доски
+	MaxBorder = MaxSquareSize
+	// MaxImageSide ограничивает сторону изображения в пикселях. Доска наибольшего
+	// размера с наибольшими клетками и рамкой в него помещается; предел защищает
+	// вычисление размера от переполнения.
+	MaxImageSide = 1 << 24
+)
+
+// frameColor - цвет рамки вокруг доски в графических форматах
+var frameColor = Color{64, 64, 64}
+
+// imageLayout описывает геометрию доски в пикселях
+type imageLayout struct {
+	square int
+	border int
+	width  int
+	height int
+}
+
+// newImageLayout вычисляет геометрию изображения. Если включены координаты,
+// а рамка не задана, рамка выбирается по размеру клетки, чтобы вместить метки.
+func newImageLayout(board *domain.Board, opts domain.RenderOptions) (imageLayout, error) {
+	square := opts.SquareSize
+	if square == 0 {
+		square = DefaultSquareSize
+	}
+	if square < 1 || square > MaxSquareSize {
+		return imageLayout{}, i18n.Errorf("image.square_size", MaxSquareSize, square)
+	}
+
+	border := opts.Border
+	if border < 0 || border > MaxBorder {
+		return imageLayout{}, i18n.Errorf("image.border", MaxBorder, border)
+	}
+	if border == 0 && opts.Coordinates {
+		border = max(square/2, 12)
+	}
+
+	width, err := imageSide(board.Width, square, border)
+	if err != nil {
+		return imageLayout{}, err
+	}
+	height, err := imageSide(board.Height, square, border)
+	if err != nil {
+		return imageLayout{}, err
+	}
+	return imageLayout{square: square, border: border, width: width, height: height}, nil
+}
+
+// imageSide возвращает длину стороны изображения из cells клеток размера square
+// с рамкой border с обеих сторон. Длина проверяется до умножения, поэтому
+// вычисление не переполняется.
+func imageSide(cells, square, border int) (int, error) {
+	if cells > (MaxImageSide-2*border)/square {
+		return 0, i18n.Errorf("image.too_wide", MaxImageSide, cells, square, border)
+	}
+	return cells*square + 2*border, nil
+}
+
+// squareOrigin возвращает левый верхний угол клетки, выводимой в строке i и столбце j
+func (l imageLayout) squareOrigin(i, j int) (int, int) {
+	return l.border + j*l.square, l.border + i*l.square
+}
+
+// boardCoords переводит экранные строку и столбец в координаты доски с учетом стороны
+func boardCoords(board *domain.Board, orientation domain.Orientation, i, j int) (int, int) {
+	if orientation == domain.OrientationBlack {
+		return board.Height - 1 - i, board.Width - 1 - j
+	}
+	return i, j
+}
+
+// WriteSVG записывает доску в w как SVG-изображение
+func WriteSVG(w io.Writer, board *domain.Board, pattern PatternFunc, opts domain.RenderOptions) error {
+	layout, err := newImageLayout(board, opts)
+	if err != nil {
+		return err
+	}
+	palette, err := ResolvePalette(opts)
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintln(out, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		layout.width, layout.height, layout.width, layout.height)
+
+	if layout.border > 0 {
+		fmt.Fprintf(out, `<rect width="%d" height="%d" fill="%s"/>`+"\n",
+			layout.width, layout.height, frameColor.Hex())
+	}
+
+	// Светлые клетки рисуются одним прямоугольником, поверх - только темные
+	fmt.Fprintf(out, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+		layout.border, layout.border, board.Width*layout.square, board.Height*layout.square, palette.Light.Hex())
+
+	fmt.Fprintf(out, `<g fill="%s">`+"\n", palette.Dark.Hex())
+	for i := 0; i < board.Height; i++ {
+		for j := 0; j < board.Width; j++ {
+			r, c := boardCoords(board, opts.Orientation, i, j)
+			if !pattern(board, r, c) {
+				continue
+			}
+			x, y := layout.squareOrigin(i, j)
+			fmt.Fprintf(out, `<rect x="%d" y="%d" width="%d" height="%d"/>`+"\n", x, y, layout.square, layout.square)
+		}
+	}
+	fmt.Fprintln(out, `</g>`)
+
+	if board.HasPieces() {
+		writeSVGPieces(out, board, layout, opts)
+	}
+	if opts.Coordinates {
+		writeSVGLabels(out, board, layout, opts.Orientation, palette.Light)
+	}
+
+	fmt.Fprintln(out, `</svg>`)
+	return out.Flush()
+}
+
+// writeSVGLabels выводит обозначения вертикалей и горизонталей в рамке вокруг доски
+func writeSVGLabels(out io.Writer, board *domain.Board, layout imageLayout, orientation domain.Orientation, color Color) {
+	fontSize := max(layout.border*3/5, 6)
+	fmt.Fprintf(out, `<g fill="%s" font-family="sans-serif" font-size="%d" text-anchor="middle" dominant-baseline="central">`+"\n",
+		color.Hex(), fontSize)
+
+	half := layout.border / 2
+	for j := 0; j < board.Width; j++ {
+		_, c := boardCoords(board, orientation, 0, j)
+		x, _ := layout.squareOrigin(0, j)
+		x += layout.square / 2
+		label := domain.FileName(c)
+		fmt.Fprintf(out, `<text x="%d" y="%d">%s</text>`+"\n", x, half, label)
+		fmt.Fprintf(out, `<text x="%d" y="%d">%s</text>`+"\n", x, layout.height-half, label)
+	}
+	for i := 0; i < board.Height; i++ {
+		r, _ := boardCoords(board, orientation, i, 0)
+		_, y := layout.squareOrigin(i, 0)
+		y += layout.square / 2
+		label := domain.RankName(board.Height - 1 - r)
+		fmt.Fprintf(out, `<text x="%d" y="%d">%s</text>`+"\n", half, y, label)
+		fmt.Fprintf(out, `<text x="%d" y="%d">%s</text>`+"\n", layout.width-half, y, label)
+	}
+
+	fmt.Fprintln(out, `</g>`)
+}
+
+// writeSVGPieces выводит фигуры текстом по центру клеток; белые фигуры обводятся
+// контуром, чтобы оставаться заметными на светлых клетках
+func writeSVGPieces(out io.Writer, board *domain.Board, layout imageLayout, opts domain.RenderOptions) {
+	fmt.Fprintf(out, `<g font-family="sans-serif" font-size="%d" text-anchor="middle" dominant-baseline="central">`+"\n",
+		max(layout.square*3/4, 1))
+
+	for i := 0; i < board.Height; i++ {
+		for j := 0; j < board.Width; j++ {
+			r, c := boardCoords(board, opts.Orientation, i, j)
+			piece := board.PieceAt(c, board.Height-1-r)
+			if piece.IsEmpty() {
+				continue
+			}
+
+			x, y := layout.squareOrigin(i, j)
+			style := fmt.Sprintf(`fill="%s"`, blackPieceColor.Hex())
+			if piece.Color == domain.White {
+				style = fmt.Sprintf(`fill="%s" stroke="%s" stroke-width="1"`, whitePieceColor.Hex(), blackPieceColor.Hex())
+			}
+			fmt.Fprintf(out, `<text x="%d" y="%d" %s>%c</text>`+"\n",
+				x+layout.square/2, y+layout.square/2, style, piece.Symbol(opts.Pieces))
+		}
+	}
+
+	fmt.Fprintln(out, `</g>`)
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+)
+
+// Эталонные файлы обновляются командой: go test ./internal/usecase/... -update
+var update = flag.Bool("update", false, "перезаписать эталонные файлы в testdata")
+
+// assertGolden сравнивает результат с эталонным файлом из testdata
+func assertGolden(t *testing.T, name string, actual []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, actual, 0o644); err != nil {
+			t.Fatalf("не удалось обновить эталон %s: %v", path, err)
+		}
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("не удалось прочитать эталон %s: %v", path, err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("результат отличается от эталона %s (обновить: go test -update)", path)
+	}
+}
+
+// standardBoard возвращает доску 8x8 со стандартной расстановкой
+func standardBoard() *domain.Board {
+	board := &domain.Board{Width: 8, Height: 8}
+	board.PlaceStandardPieces()
+	return board
+}
+
+func TestWriteSVG_Golden(t *testing.T) {
+	testCases := []struct {
+		golden string
+		board  *domain.Board
+		opts   domain.RenderOptions
+	}{
+		{
+			golden: "board_4x4.svg",
+			board:  &domain.Board{Width: 4, Height: 4},
+			opts:   domain.RenderOptions{},
+		},
+		{
+			golden: "board_8x8_coords.svg",
+			board:  &domain.Board{Width: 8, Height: 8},
+			opts:   domain.RenderOptions{Coordinates: true, SquareSize: 32},
+		},
+		{
+			golden: "board_10x8_black.svg",
+			board:  &domain.Board{Width: 10, Height: 8},
+			opts: domain.RenderOptions{
+				Coordinates: true,
+				Orientation: domain.OrientationBlack,
+				Palette:     "green",
+				SquareSize:  20,
+				Border:      16,
+			},
+		},
+		{
+			golden: "board_8x8_standard.svg",
+			board:  standardBoard(),
+			opts:   domain.RenderOptions{Coordinates: true, SquareSize: 32, Pieces: domain.PieceStyleUnicode},
+		},
+		{
+			golden: "board_6x6_colors.svg",
+			board:  &domain.Board{Width: 6, Height: 6},
+			opts:   domain.RenderOptions{LightColor: "#ffffff", DarkColor: "#000000", SquareSize: 10, Border: 4},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.golden, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteSVG(&buf, tc.board, CheckerPattern, tc.opts); err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			assertGolden(t, tc.golden, buf.Bytes())
+		})
+	}
+}
+
+func TestWriteSVG_WellFormed(t *testing.T) {
+	var buf bytes.Buffer
+	board := &domain.Board{Width: 30, Height: 5}
+	if err := WriteSVG(&buf, board, RingsPattern, domain.RenderOptions{Coordinates: true}); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	decoder := xml.NewDecoder(&buf)
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("SVG не является корректным XML: %v", err)
+		}
+	}
+}
+
+func TestWriteSVG_Size(t *testing.T) {
+	var buf bytes.Buffer
+	board := &domain.Board{Width: 10, Height: 8}
+	if err := WriteSVG(&buf, board, CheckerPattern, domain.RenderOptions{SquareSize: 25}); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `width="250" height="200"`) {
+		t.Errorf("ожидался размер изображения 250x200:\n%s", buf.String())
+	}
+	// Без рамки и координат рисуются только клетки
+	if strings.Contains(buf.String(), "<text") {
+		t.Error("без координат не должно быть текстовых меток")
+	}
+}
+
+func TestWriteSVG_Errors(t *testing.T) {
+	board := &domain.Board{Width: 4, Height: 4}
+	errorCases := map[string]domain.RenderOptions{
+		"отрицательный размер клетки": {SquareSize: -1},
+		"слишком большая клетка":      {SquareSize: MaxSquareSize + 1},
+		"отрицательная рамка":         {Border: -2},
+		"слишком широкая рамка":       {Border: MaxBorder + 1},
+		"рамка с переполнением":       {Border: 1 << 62},
+		"неверный цвет":               {LightColor: "white"},
+	}
+
+	for name, opts := range errorCases {
+		t.Run(name, func(t *testing.T) {
+			if err := WriteSVG(io.Discard, board, CheckerPattern, opts); err == nil {
+				t.Errorf("ожидалась ошибка для параметров %+v", opts)
+			}
+		})
+	}
+}
+
+func TestWriteSVG_TooWide(t *testing.T) {
+	// Сторона изображения проверяется до вычисления, поэтому размер холста
+	// не переполняется и не становится отрицательным
+	board := &domain.Board{Width: 40000, Height: 4}
+	var buf bytes.Buffer
+	err := WriteSVG(&buf, board, CheckerPattern, domain.RenderOptions{SquareSize: MaxSquareSize, Border: MaxBorder})
+	if err == nil || !strings.Contains(err.Error(), "сторона изображения превышает") {
+		t.Errorf("ожидалась ошибка размера изображения, получено %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("при ошибке ничего не должно выводиться, получено:\n%s", buf.String())
+	}
+}
+
+func TestBoardUsecase_RenderSVG(t *testing.T) {
+	usecase := NewBoardUsecase(&MockBoardRepository{})
+	board := &domain.Board{Width: 4, Height: 4}
+
+	var buf bytes.Buffer
+	if err := usecase.Render(&buf, board, domain.RenderOptions{Format: domain.FormatSVG}); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "<?xml") {
+		t.Errorf("ожидался SVG-документ, получено:\n%s", buf.String())
+	}
+
+	if err := usecase.Render(io.Discard, board, domain.RenderOptions{Format: "pdf"}); err == nil {
+		t.Error("ожидалась ошибка для неизвестного формата")
+	}
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strconv"
+	"strings"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/i18n"
+)
+
+const (
+	// DefaultTheme - тема, совместимая с исходным выводом из пробелов и решеток
+	DefaultTheme = "ascii"
+	// DefaultPalette - палитра цветных тем по умолчанию
+	DefaultPalette = "brown"
+)
+
+const (
+	whiteSquare = " "
+	blackSquare = "#"
+)
+
+// Theme описывает внешний вид клеток текстовой доски
+type Theme struct {
+	// Light и Dark - символы светлой и темной клетки
+	Light string
+	Dark  string
+	// CellWidth - ширина клетки в символах
+	CellWidth int
+	// Colored включает 24-битные ANSI-цвета фона из палитры
+	Colored bool
+}
+
+var themes = map[string]Theme{
+	"ascii":   {Light: whiteSquare, Dark: blackSquare, CellWidth: 1},
+	"unicode": {Light: "░", Dark: "█", CellWidth: 2},
+	"ansi":    {Light: " ", Dark: " ", CellWidth: 2, Colored: true},
+}
+
+// LookupTheme возвращает тему по имени; пустое имя означает тему по умолчанию
+func LookupTheme(name string) (Theme, error) {
+	if name == "" {
+		name = DefaultTheme
+	}
+	theme, ok := themes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Theme{}, &domain.UnknownValueError{Label: "unknown.theme", Value: name, Allowed: ThemeNames()}
+	}
+	return theme, nil
+}
+
+// ThemeNames возвращает отсортированный список тем
+func ThemeNames() []string {
+	return sortedKeys(themes)
+}
+
+// Color - цвет в модели RGB
+type Color struct {
+	R, G, B uint8
+}
+
+// ParseColor разбирает цвет в формате "#rrggbb" или "#rgb" (решетка необязательна)
+func ParseColor(input string) (Color, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(input), "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return Color{}, i18n.Errorf("color.invalid", input)
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Color{}, i18n.Errorf("color.invalid", input)
+	}
+	return Color{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value)}, nil
+}
+
+// Hex возвращает цвет в формате "#rrggbb"
+func (c Color) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// rgba возвращает непрозрачный цвет для пакета image
+func (c Color) rgba() color.RGBA {
+	return color.RGBA{R: c.R, G: c.G, B: c.B, A: 0xff}
+}
+
+// ansiForeground возвращает escape-последовательность 24-битного цвета текста
+func (c Color) ansiForeground() string {
+	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", c.R, c.G, c.B)
+}
+
+// ansiBackground возвращает escape-последовательность 24-битного цвета фона
+func (c Color) ansiBackground() string {
+	return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", c.R, c.G, c.B)
+}
+
+// Цвета фигур в цветных и графических форматах
+var (
+	whitePieceColor = Color{255, 255, 255}
+	blackPieceColor = Color{0, 0, 0}
+)
+
+// Palette - пара цветов светлых и темных клеток
+type Palette struct {
+	Light Color
+	Dark  Color
+}
+
+var palettes = map[string]Palette{
+	"brown": {Light: Color{240, 217, 181}, Dark: Color{181, 136, 99}},
+	"green": {Light: Color{238, 238, 210}, Dark: Color{118, 150, 86}},
+	"blue":  {Light: Color{222, 227, 230}, Dark: Color{140, 162, 173}},
+	"gray":  {Light: Color{220, 220, 220}, Dark: Color{128, 128, 128}},
+}
+
+// LookupPalette возвращает палитру по имени; пустое имя означает палитру по умолчанию
+func LookupPalette(name string) (Palette, error) {
+	if name == "" {
+		name = DefaultPalette
+	}
+	palette, ok := palettes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Palette{}, &domain.UnknownValueError{Label: "unknown.palette", Value: name, Allowed: PaletteNames()}
+	}
+	return palette, nil
+}
+
+// PaletteNames возвращает отсортированный список палитр
+func PaletteNames() []string {
+	return sortedKeys(palettes)
+}
+
+// ResolvePalette выбирает палитру из параметров отрисовки и применяет
+// переопределения отдельных цветов LightColor и DarkColor
+func ResolvePalette(opts domain.RenderOptions) (Palette, error) {
+	palette, err := LookupPalette(opts.Palette)
+	if err != nil {
+		return Palette{}, err
+	}
+
+	if opts.LightColor != "" {
+		if palette.Light, err = ParseColor(opts.LightColor); err != nil {
+			return Palette{}, err
+		}
+	}
+	if opts.DarkColor != "" {
+		if palette.Dark, err = ParseColor(opts.DarkColor); err != nil {
+			return Palette{}, err
+		}
+	}
+	return palette, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+)
+
+func TestLookupTheme(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		expected    Theme
+		expectError bool
+	}{
+		{"тема по умолчанию", "", themes[DefaultTheme], false},
+		{"ascii", "ascii", Theme{Light: " ", Dark: "#", CellWidth: 1}, false},
+		{"unicode в верхнем регистре", "UNICODE", themes["unicode"], false},
+		{"ansi", "ansi", themes["ansi"], false},
+		{"неизвестная тема", "neon", Theme{}, true},
+		{"auto не является темой", "auto", Theme{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			theme, err := LookupTheme(tc.input)
+
+			if tc.expectError {
+				if !errors.Is(err, domain.ErrUnknownValue) {
+					t.Errorf("ожидалась ошибка ErrUnknownValue для темы '%s', получено %v", tc.input, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if theme != tc.expected {
+				t.Errorf("ожидалась тема %+v, получено %+v", tc.expected, theme)
+			}
+		})
+	}
+
+	if names := ThemeNames(); !reflect.DeepEqual(names, []string{"ansi", "ascii", "unicode"}) {
+		t.Errorf("неожиданный список тем: %v", names)
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expected    Color
+		expectError bool
+	}{
+		{"#f0d9b5", Color{240, 217, 181}, false},
+		{"B58863", Color{181, 136, 99}, false},
+		{"#fff", Color{255, 255, 255}, false},
+		{" #000000 ", Color{0, 0, 0}, false},
+		{"#12345", Color{}, true},
+		{"#gggggg", Color{}, true},
+		{"red", Color{}, true},
+		{"", Color{}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			color, err := ParseColor(tc.input)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("ожидалась ошибка для цвета '%s', но ошибки нет", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			if color != tc.expected {
+				t.Errorf("для '%s' ожидалось %+v, получено %+v", tc.input, tc.expected, color)
+			}
+		})
+	}
+}
+
+func TestColor_Hex(t *testing.T) {
+	if hex := (Color{240, 217, 181}).Hex(); hex != "#f0d9b5" {
+		t.Errorf("ожидалось '#f0d9b5', получено '%s'", hex)
+	}
+}
+
+func TestResolvePalette(t *testing.T) {
+	t.Run("палитра по умолчанию", func(t *testing.T) {
+		palette, err := ResolvePalette(domain.RenderOptions{})
+		if err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		if palette != palettes[DefaultPalette] {
+			t.Errorf("ожидалась палитра по умолчанию, получено %+v", palette)
+		}
+	})
+
+	t.Run("переопределение темного цвета", func(t *testing.T) {
+		palette, err := ResolvePalette(domain.RenderOptions{Palette: "green", DarkColor: "#000"})
+		if err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		if palette.Light != palettes["green"].Light || palette.Dark != (Color{}) {
+			t.Errorf("ожидался светлый цвет из палитры и черный темный, получено %+v", palette)
+		}
+	})
+
+	errorCases := map[string]domain.RenderOptions{
+		"неизвестная палитра":   {Palette: "rainbow"},
+		"неверный светлый цвет": {LightColor: "white"},
+		"неверный темный цвет":  {DarkColor: "#12"},
+	}
+	for name, opts := range errorCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ResolvePalette(opts); err == nil {
+				t.Errorf("ожидалась ошибка для параметров %+v", opts)
+			}
+		})
+	}
+
+	if names := PaletteNames(); len(names) != len(palettes) {
+		t.Errorf("ожидалось %d палитр, получено %v", len(palettes), names)
+	}
+}
+
+func TestWriteBoard_Themes(t *testing.T) {
+	board := &domain.Board{Width: 2, Height: 2}
+
+	t.Run("unicode", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := WriteBoard(&buf, board, CheckerPattern, domain.RenderOptions{Theme: "unicode"}); err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+		if expected := "░░██\n██░░"; buf.String() != expected {
+			t.Errorf("ожидалось:\n%s\nполучено:\n%s", expected, buf.String())
+		}
+	})
+
+	t.Run("ansi с палитрой", func(t *testing.T) {
+		var buf bytes.Buffer
+		opts := domain.RenderOptions{Theme: "ansi", LightColor: "#ffffff", DarkColor: "#000000"}
+		if err := WriteBoard(&buf, board, CheckerPattern, opts); err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+
+		light, dark := "\x1b[48;2;255;255;255m  ", "\x1b[48;2;0;0;0m  "
+		expected := light + dark + ansiReset + "\n" + dark + light + ansiReset
+		if buf.String() != expected {
+			t.Errorf("ожидалось %q, получено %q", expected, buf.String())
+		}
+	})
+
+	t.Run("ansi с координатами вне цветной области", func(t *testing.T) {
+		var buf bytes.Buffer
+		opts := domain.RenderOptions{Theme: "ansi", Coordinates: true}
+		if err := WriteBoard(&buf, board, CheckerPattern, opts); err != nil {
+			t.Fatalf("неожиданная ошибка: %v", err)
+		}
+
+		lines := strings.Split(buf.String(), "\n")
+		if lines[0] != "  a b" {
+			t.Errorf("метки вертикалей должны учитывать ширину клетки, получено %q", lines[0])
+		}
+		if !strings.HasPrefix(lines[1], "2 \x1b[") || !strings.HasSuffix(lines[1], ansiReset+" 2") {
+			t.Errorf("метки горизонталей должны быть вне цветной области, получено %q", lines[1])
+		}
+	})
+
+	t.Run("ошибки темы и палитры", func(t *testing.T) {
+		for _, opts := range []domain.RenderOptions{{Theme: "neon"}, {Theme: "ansi", Palette: "rainbow"}} {
+			if err := WriteBoard(&bytes.Buffer{}, board, CheckerPattern, opts); err == nil {
+				t.Errorf("ожидалась ошибка для параметров %+v", opts)
+			}
+		}
+	})
+}
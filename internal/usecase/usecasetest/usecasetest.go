@@ -0,0 +1,26 @@
+// Package usecasetest содержит вспомогательные функции для тестов пакетов,
+// работающих с партиями usecase.
+package usecasetest
+
+import (
+	"testing"
+
+	"chessboard/internal/domain"
+)
+
+// MustGame начинает партию с позиции FEN конструктором newGame или прерывает
+// тест. Конструктор передается параметром, а не вызывается напрямую: иначе
+// тесты самого пакета usecase не смогли бы импортировать этот пакет.
+func MustGame(t testing.TB, newGame func(*domain.Board) (domain.Game, error), fen string) domain.Game {
+	t.Helper()
+
+	board, err := domain.ParseFEN(fen)
+	if err != nil {
+		t.Fatalf("неверный FEN %q: %v", fen, err)
+	}
+	game, err := newGame(board)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	return game
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import "chessboard/internal/domain"
+
+// zobristSeed - фиксированное начальное значение генератора ключей, чтобы хеш
+// одной и той же позиции совпадал между запусками и версиями программы
+const zobristSeed uint64 = 0x9d39247e33776d41
+
+// Виды ключей Зобриста. Вид входит в номер ключа, поэтому ключи разных видов
+// берутся из непересекающихся частей последовательности генератора.
+const (
+	zobristPieceKind = iota
+	zobristSideKind
+	zobristCastlingKind
+	zobristEnPassantKind
+	zobristKinds
+)
+
+// zobristPieceStates - число разных фигур на клетке: 6 видов двух цветов
+const zobristPieceStates = 12
+
+var (
+	// pieceKeys - ключи фигур на первых 64 клетках, заранее вычисленные для доски 8x8
+	pieceKeys [64 * zobristPieceStates]uint64
+	// castlingKeys - ключи всех сочетаний прав на рокировку
+	castlingKeys [domain.AllCastling + 1]uint64
+	// sideKey добавляется к хешу, когда очередь хода за черными
+	sideKey = zobristKey(zobristSideKind, 0)
+)
+
+func init() {
+	for i := range pieceKeys {
+		pieceKeys[i] = zobristKey(zobristPieceKind, i)
+	}
+	for rights := range castlingKeys {
+		for bit := 0; bit < 4; bit++ {
+			if rights&(1<<bit) != 0 {
+				castlingKeys[rights] ^= zobristKey(zobristCastlingKind, bit)
+			}
+		}
+	}
+}
+
+// zobristKey возвращает n-й ключ вида kind - значение генератора splitmix64
+func zobristKey(kind, n int) uint64 {
+	z := zobristSeed + uint64(n*zobristKinds+kind+1)*0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
+
+// pieceKey возвращает ключ фигуры на клетке. На досках больше 8x8 ключи дальних
+// клеток вычисляются при обращении, а не хранятся в таблице.
+func pieceKey(piece domain.Piece, square int) uint64 {
+	n := square*zobristPieceStates + int(piece.Color)*6 + int(piece.Kind) - 1
+	if n < len(pieceKeys) {
+		return pieceKeys[n]
+	}
+	return zobristKey(zobristPieceKind, n)
+}
+
+// sideToMoveKey возвращает ключ очереди хода
+func sideToMoveKey(side domain.Color) uint64 {
+	if side == domain.Black {
+		return sideKey
+	}
+	return 0
+}
+
+// enPassantKey возвращает ключ вертикали взятия на проходе
+func enPassantKey(file int) uint64 {
+	return zobristKey(zobristEnPassantKind, file)
+}
+
+// computeHash вычисляет хеш позиции заново по всем клеткам
+func (p *position) computeHash() uint64 {
+	hash := castlingKeys[p.castling] ^ sideToMoveKey(p.side) ^ p.epKey()
+	for square, piece := range p.squares {
+		if !piece.IsEmpty() {
+			hash ^= pieceKey(piece, square)
+		}
+	}
+	return hash
+}
+
+// epKey возвращает ключ взятия на проходе или 0. Как и в формате Polyglot,
+// вертикаль учитывается, только если рядом с прошедшей пешкой стоит пешка
+// стороны, имеющей очередь хода, иначе позиции с клеткой взятия и без нее
+// различались бы, хотя ничем не отличаются.
+func (p *position) epKey() uint64 {
+	if p.epSquare < 0 {
+		return 0
+	}
+	pawn := domain.Piece{Color: p.side, Kind: domain.Pawn}
+	passed := p.epSquare - pawnDirection(p.side)*p.width
+	if passed < 0 || passed >= len(p.squares) {
+		return 0
+	}
+	file := p.epSquare % p.width
+	if (file > 0 && p.squares[passed-1] == pawn) || (file < p.width-1 && p.squares[passed+1] == pawn) {
+		return enPassantKey(file)
+	}
+	return 0
+}
+
+// positionKey возвращает хеш для сравнения позиций: в отличие от инкрементального
+// хеша, взятие на проходе учитывается, только если оно легально
+func (p *position) positionKey() uint64 {
+	key := p.hash
+	if epKey := p.epKey(); epKey != 0 && !p.hasEnPassantCapture() {
+		key ^= epKey
+	}
+	return key
+}
+
+// hasEnPassantCapture сообщает, есть ли среди легальных ходов взятие на проходе
+func (p *position) hasEnPassantCapture() bool {
+	if p.epSquare < 0 {
+		return false
+	}
+	for _, m := range p.legalMoves(nil) {
+		if m.flags&flagEnPassant != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Hash возвращает хеш Зобриста позиции на доске. Позиции с одинаковой расстановкой,
+// очередью хода, правами на рокировку и возможностью взятия на проходе имеют
+// одинаковый хеш; счетчики ходов в хеш не входят.
+func Hash(board *domain.Board) (uint64, error) {
+	p, err := newPosition(board)
+	if err != nil {
+		return 0, err
+	}
+	return p.positionKey(), nil
+}
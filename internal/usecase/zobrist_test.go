@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"chessboard/internal/domain"
+	"chessboard/internal/usecase/usecasetest"
+)
+
+// mustHash возвращает хеш позиции FEN или прерывает тест
+func mustHash(t *testing.T, fen string) uint64 {
+	t.Helper()
+
+	board, err := domain.ParseFEN(fen)
+	if err != nil {
+		t.Fatalf("неверный FEN %q: %v", fen, err)
+	}
+	hash, err := Hash(board)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	return hash
+}
+
+func TestHash_Stable(t *testing.T) {
+	// Значения зафиксированы: хеши сохраняются во внешних коллекциях позиций,
+	// поэтому не должны меняться между запусками и версиями
+	testCases := []struct {
+		name     string
+		fen      string
+		expected uint64
+	}{
+		{"начальная позиция", domain.StartFEN, 0x357ebf2299980e74},
+		{"доска 10x10", "10/10/10/10/10/10/10/10/4P5/4K4k w - - 0 1", 0x68ba44f4dd0a2030},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := mustHash(t, tc.fen); actual != tc.expected {
+				t.Errorf("ожидалось %#016x, получено %#016x", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestZobristKeys_Unique(t *testing.T) {
+	seen := make(map[uint64]string)
+	add := func(key uint64, name string) {
+		if key == 0 {
+			t.Fatalf("ключ %s равен нулю", name)
+		}
+		if other, ok := seen[key]; ok {
+			t.Fatalf("ключи %s и %s совпадают", other, name)
+		}
+		seen[key] = name
+	}
+
+	for i, key := range pieceKeys {
+		add(key, "фигуры "+strconv.Itoa(i))
+	}
+	for file := 0; file < 100; file++ {
+		add(enPassantKey(file), "взятия на проходе "+strconv.Itoa(file))
+	}
+	for rights := domain.CastlingRights(1); rights <= domain.AllCastling; rights++ {
+		add(castlingKeys[rights], "рокировки "+rights.String())
+	}
+	add(sideKey, "очереди хода")
+}
+
+func TestHash_PositionEquality(t *testing.T) {
+	testCases := []struct {
+		name  string
+		first string
+		other string
+		equal bool
+	}{
+		{
+			name:  "счетчики ходов не учитываются",
+			first: domain.StartFEN,
+			other: "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 12 40",
+			equal: true,
+		},
+		{
+			name:  "очередь хода",
+			first: domain.StartFEN,
+			other: "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR b KQkq - 0 1",
+		},
+		{
+			name:  "права на рокировку",
+			first: domain.StartFEN,
+			other: "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w Kkq - 0 1",
+		},
+		{
+			name:  "взятие на проходе некому",
+			first: "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+			other: "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - 0 1",
+			equal: true,
+		},
+		{
+			name:  "возможное взятие на проходе",
+			first: "4k3/8/8/8/3pP3/8/8/4K3 b - e3 0 1",
+			other: "4k3/8/8/8/3pP3/8/8/4K3 b - - 0 1",
+		},
+		{
+			name:  "связанная пешка не берет на проходе",
+			first: "8/8/8/8/k2pP2R/8/8/4K3 b - e3 0 1",
+			other: "8/8/8/8/k2pP2R/8/8/4K3 b - - 0 1",
+			equal: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			first, other := mustHash(t, tc.first), mustHash(t, tc.other)
+			if (first == other) != tc.equal {
+				t.Errorf("ожидалось равенство %v, получены хеши %#016x и %#016x", tc.equal, first, other)
+			}
+		})
+	}
+}
+
+func TestHash_Transposition(t *testing.T) {
+	first := usecasetest.MustGame(t, NewGame, domain.StartFEN)
+	playMoves(t, first, "g1f3 g8f6 b1c3")
+	other := usecasetest.MustGame(t, NewGame, domain.StartFEN)
+	playMoves(t, other, "b1c3 g8f6 g1f3")
+
+	firstHash, err := Hash(first.Board())
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	otherHash, err := Hash(other.Board())
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if firstHash != otherHash {
+		t.Errorf("позиции после перестановки ходов должны совпадать: %#016x и %#016x", firstHash, otherHash)
+	}
+}
+
+// checkIncrementalHash проверяет во всех узлах дерева глубины depth, что хеш,
+// обновляемый при ходах, совпадает с вычисленным заново и с хешем битовых досок
+func checkIncrementalHash(t *testing.T, p *position, bp *BitboardPosition, depth int) {
+	t.Helper()
+
+	if p.hash != p.computeHash() {
+		t.Fatalf("позиция %s: инкрементальный хеш %#016x, вычисленный %#016x",
+			p.board().FEN(), p.hash, p.computeHash())
+	}
+	if bp != nil && bp.hash != p.hash {
+		t.Fatalf("позиция %s: хеш по клеткам %#016x, на битовых досках %#016x", p.board().FEN(), p.hash, bp.hash)
+	}
+	if depth == 0 {
+		return
+	}
+
+	for _, m := range p.legalMoves(nil) {
+		u := p.makeMove(m)
+		if bp != nil {
+			next := bp.play(m)
+			checkIncrementalHash(t, p, &next, depth-1)
+		} else {
+			checkIncrementalHash(t, p, nil, depth-1)
+		}
+		p.unmakeMove(m, u)
+	}
+}
+
+func TestHash_Incremental(t *testing.T) {
+	depth := 3
+	if testing.Short() {
+		depth = 2
+	}
+
+	for _, tc := range PerftSuite {
+		t.Run(tc.Name, func(t *testing.T) {
+			checkIncrementalHash(t, mustPosition(t, tc.FEN), mustBitboards(t, tc.FEN), depth)
+		})
+	}
+
+	t.Run("доска 10x10", func(t *testing.T) {
+		p := mustPosition(t, "r3k4r/pppppppppp/10/10/10/10/10/10/PPPPPPPPPP/R3K4R w KQkq - 0 1")
+		checkIncrementalHash(t, p, nil, depth)
+	})
+}
+
+func TestBitboardPosition_Hash(t *testing.T) {
+	for _, fen := range []string{
+		domain.StartFEN,
+		"4k3/8/8/8/3pP3/8/8/4K3 b - e3 0 1",
+		"8/8/8/8/k2pP2R/8/8/4K3 b - e3 0 1",
+	} {
+		if expected, actual := mustHash(t, fen), mustBitboards(t, fen).Hash(); actual != expected {
+			t.Errorf("%s: ожидалось %#016x, получено %#016x", fen, expected, actual)
+		}
+	}
+}
+
+func TestHash_Errors(t *testing.T) {
+	_, err := NewBoardUsecase(NewBoardRepository()).Hash(&domain.Board{Width: 8, Height: 8, Squares: make([]domain.Piece, 5)})
+	if err == nil || !strings.Contains(err.Error(), "число клеток (5)") {
+		t.Errorf("ожидалась ошибка о числе клеток, получено: %v", err)
+	}
+}